@@ -0,0 +1,213 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const activityStreamBasePath = "activity_stream/"
+
+// Activity stream operations.
+const (
+	ActivityCreate       = "create"
+	ActivityUpdate       = "update"
+	ActivityDelete       = "delete"
+	ActivityAssociate    = "associate"
+	ActivityDisassociate = "disassociate"
+)
+
+// ActivityStreamService is an interface for interfacing with the ActivityStream
+// endpoints of the AWX API
+// See: http://localhost/api/v2/activity_stream/
+type ActivityStreamService interface {
+	List(context.Context, *ActivityStreamListOptions) ([]ActivityStreamEntry, *Response, error)
+	Watch(context.Context, *WatchOptions) (<-chan ChangeEvent, error)
+}
+
+// ActivityStreamServiceOp handles communication with the ActivityStream related methods of the
+// AWX API.
+type ActivityStreamServiceOp struct {
+	client *Client
+}
+
+// ActivityStreamEntry represents a single change recorded in the AWX
+// activity stream. Object1 is the type of the object that changed; for
+// associate and disassociate operations Object2 is the type of the object it
+// was linked to or unlinked from.
+type ActivityStreamEntry struct {
+	ID      int    `json:"id"`
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+	Related struct {
+		Actor string `json:"actor"`
+	} `json:"related"`
+	SummaryFields     ActivityStreamSummary  `json:"summary_fields"`
+	Timestamp         time.Time              `json:"timestamp"`
+	Operation         string                 `json:"operation"`
+	Changes           map[string]interface{} `json:"changes"`
+	Object1           string                 `json:"object1"`
+	Object2           string                 `json:"object2"`
+	ObjectAssociation string                 `json:"object_association"`
+	ActionNode        string                 `json:"action_node"`
+	ObjectType        string                 `json:"object_type"`
+}
+
+// ActivityStreamSummary holds the summary fields of an ActivityStreamEntry:
+// the user who made the change, nil for changes made by AWX itself, and the
+// objects involved, keyed by type.
+type ActivityStreamSummary struct {
+	Actor   *UserSummary
+	Objects map[string][]ActivityStreamObject
+}
+
+// ActivityStreamObject is the short form of an object involved in an
+// ActivityStreamEntry.
+type ActivityStreamObject struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// UnmarshalJSON splits the summary fields AWX returns into the actor and the
+// objects involved in the change.
+func (s *ActivityStreamSummary) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	s.Objects = make(map[string][]ActivityStreamObject)
+	for key, raw := range fields {
+		if key == "actor" {
+			if err := json.Unmarshal(raw, &s.Actor); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var objects []ActivityStreamObject
+		if err := json.Unmarshal(raw, &objects); err != nil {
+			// Not every summary field is a list of objects; skip the rest.
+			continue
+		}
+		s.Objects[key] = objects
+	}
+
+	return nil
+}
+
+// ActivityStreamChange is the value of a field before and after an update.
+type ActivityStreamChange struct {
+	Old interface{}
+	New interface{}
+}
+
+// Diff returns the fields changed by an update operation with their old and
+// new values. For other operations AWX records only the resulting values,
+// which are returned as New.
+func (e *ActivityStreamEntry) Diff() map[string]ActivityStreamChange {
+	diff := make(map[string]ActivityStreamChange, len(e.Changes))
+	for field, value := range e.Changes {
+		if pair, ok := value.([]interface{}); ok && e.Operation == ActivityUpdate && len(pair) == 2 {
+			diff[field] = ActivityStreamChange{Old: pair[0], New: pair[1]}
+			continue
+		}
+		diff[field] = ActivityStreamChange{New: value}
+	}
+	return diff
+}
+
+// ActivityStreamListOptions filters the entries returned by
+// ActivityStreamService.List. Zero values are ignored.
+type ActivityStreamListOptions struct {
+	// ObjectType matches entries about objects of a type, e.g. "job_template".
+	ObjectType string
+	// ObjectTypes matches entries about objects of any of the given types.
+	ObjectTypes []string
+	// ObjectID narrows ObjectType to a single object.
+	ObjectID int
+	// Operation matches one of the activity operations, e.g. ActivityUpdate.
+	Operation string
+	// Actor matches the username of the user who made the change.
+	Actor string
+	// Since and Until bound the time the change was made.
+	Since time.Time
+	Until time.Time
+	// AfterID matches entries recorded after the entry with the given ID.
+	AfterID int
+	// OrderBy sorts the result by a field, prefixed with "-" to reverse it.
+	OrderBy string
+}
+
+func (o *ActivityStreamListOptions) values() (url.Values, error) {
+	v := url.Values{"page_size": {"200"}}
+	if o == nil {
+		return v, nil
+	}
+
+	if o.ObjectID != 0 {
+		if o.ObjectType == "" {
+			return nil, NewArgError("ObjectID", "requires ObjectType to be set")
+		}
+		if o.ObjectID < 1 {
+			return nil, NewArgError("ObjectID", "cannot be less than 1")
+		}
+		v.Set(fmt.Sprintf("%s__id", o.ObjectType), strconv.Itoa(o.ObjectID))
+	}
+	if o.ObjectType != "" {
+		v.Set("object1", o.ObjectType)
+	}
+	if len(o.ObjectTypes) > 0 {
+		v.Set("object1__in", strings.Join(o.ObjectTypes, ","))
+	}
+	if o.Operation != "" {
+		v.Set("operation", o.Operation)
+	}
+	if o.Actor != "" {
+		v.Set("actor__username", o.Actor)
+	}
+	if !o.Since.IsZero() {
+		v.Set("timestamp__gte", o.Since.UTC().Format(time.RFC3339Nano))
+	}
+	if !o.Until.IsZero() {
+		v.Set("timestamp__lt", o.Until.UTC().Format(time.RFC3339Nano))
+	}
+	if o.AfterID > 0 {
+		v.Set("id__gt", strconv.Itoa(o.AfterID))
+	}
+	if o.OrderBy != "" {
+		v.Set("order_by", o.OrderBy)
+	}
+
+	return v, nil
+}
+
+// List every ActivityStreamEntry matching opts, across all pages.
+func (s *ActivityStreamServiceOp) List(ctx context.Context, opts *ActivityStreamListOptions) ([]ActivityStreamEntry, *Response, error) {
+	query, err := opts.values()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := activityStreamBasePath + "?" + query.Encode()
+
+	var entries []ActivityStreamEntry
+	resp, err := listAllPages(ctx, s.client, path, func(results json.RawMessage) error {
+		var batch []ActivityStreamEntry
+		if err := json.Unmarshal(results, &batch); err != nil {
+			return err
+		}
+		entries = append(entries, batch...)
+		return nil
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return entries, resp, err
+}
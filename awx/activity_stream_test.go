@@ -0,0 +1,114 @@
+package awx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestActivityStreamList(t *testing.T) {
+	client, mux := setup(t)
+	query := "actor__username=admin&id__gt=100&inventory__id=2&object1=inventory&operation=update&order_by=id&page_size=200&timestamp__gte=2024-06-03T10%3A00%3A00Z&timestamp__lt=2024-06-04T10%3A00%3A00Z"
+	handle(t, mux, http.MethodGet, "/api/v2/activity_stream/", query, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{
+			"count": 2,
+			"next":  "/api/v2/activity_stream/page2/",
+			"results": []map[string]interface{}{
+				{
+					"id": 101, "operation": "update", "object1": "inventory", "object2": "",
+					"timestamp": "2024-06-03T10:05:00Z",
+					"changes":   map[string]interface{}{"name": []string{"prod", "production"}, "id": 2},
+					"summary_fields": map[string]interface{}{
+						"actor":     map[string]interface{}{"id": 1, "username": "admin", "first_name": "", "last_name": ""},
+						"inventory": []map[string]interface{}{{"id": 2, "name": "production", "description": ""}},
+						"setting":   "not a list",
+					},
+				},
+			},
+		})
+	})
+	handle(t, mux, http.MethodGet, "/api/v2/activity_stream/page2/", "", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{
+			"count": 2,
+			"results": []map[string]interface{}{
+				{
+					"id": 102, "operation": "associate", "object1": "inventory", "object2": "label",
+					"timestamp":      "2024-06-03T10:06:00Z",
+					"changes":        map[string]interface{}{"object1": "inventory", "object1_pk": 2, "object2": "label", "object2_pk": 9},
+					"summary_fields": map[string]interface{}{"actor": nil, "inventory": []map[string]interface{}{{"id": 2, "name": "production"}}, "label": []map[string]interface{}{{"id": 9, "name": "prod"}}},
+				},
+			},
+		})
+	})
+
+	entries, _, err := client.ActivityStream.List(context.Background(), &ActivityStreamListOptions{
+		ObjectType: "inventory",
+		ObjectID:   2,
+		Operation:  ActivityUpdate,
+		Actor:      "admin",
+		Since:      time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC),
+		Until:      time.Date(2024, 6, 4, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60)),
+		AfterID:    100,
+		OrderBy:    "id",
+	})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("List returned %d entries, want 2 across both pages", len(entries))
+	}
+
+	update := entries[0]
+	if actor := update.SummaryFields.Actor; actor == nil || actor.Username != "admin" {
+		t.Errorf("actor = %+v, want admin", actor)
+	}
+	if want := map[string][]ActivityStreamObject{"inventory": {{ID: 2, Name: "production"}}}; !reflect.DeepEqual(update.SummaryFields.Objects, want) {
+		t.Errorf("objects = %+v, want %+v", update.SummaryFields.Objects, want)
+	}
+	want := map[string]ActivityStreamChange{
+		"name": {Old: "prod", New: "production"},
+		"id":   {New: float64(2)},
+	}
+	if diff := update.Diff(); !reflect.DeepEqual(diff, want) {
+		t.Errorf("Diff = %+v, want %+v", diff, want)
+	}
+
+	associate := entries[1]
+	if associate.SummaryFields.Actor != nil {
+		t.Errorf("actor = %+v, want nil for a change made by AWX", associate.SummaryFields.Actor)
+	}
+	if labels := associate.SummaryFields.Objects["label"]; associate.Object2 != "label" || len(labels) != 1 || labels[0].ID != 9 {
+		t.Errorf("associate entry = %+v, want the label 9 linked", associate)
+	}
+}
+
+func TestActivityStreamListObjectTypes(t *testing.T) {
+	client, mux := setup(t)
+	handle(t, mux, http.MethodGet, "/api/v2/activity_stream/", "object1__in=job_template%2Cproject&page_size=200", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{"count": 0, "results": []interface{}{}})
+	})
+
+	entries, _, err := client.ActivityStream.List(context.Background(), &ActivityStreamListOptions{ObjectTypes: []string{"job_template", "project"}})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("List returned %d entries, want none", len(entries))
+	}
+}
+
+func TestActivityStreamListArgErrors(t *testing.T) {
+	client := NewClient(nil)
+	for _, opts := range []*ActivityStreamListOptions{
+		{ObjectID: 2},
+		{ObjectType: "inventory", ObjectID: -1},
+	} {
+		var argErr *ArgError
+		if _, _, err := client.ActivityStream.List(context.Background(), opts); !errors.As(err, &argErr) {
+			t.Errorf("List(%+v): %v, want an *ArgError", opts, err)
+		}
+	}
+}
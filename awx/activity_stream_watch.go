@@ -0,0 +1,179 @@
+package awx
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultWatchInterval = 10 * time.Second
+
+	// watchOverlap is how far below the cursor Watch looks for entries.
+	// AWX assigns the ID of an entry before the transaction recording it
+	// commits, so an entry can become visible after one with a higher ID.
+	watchOverlap = 100
+)
+
+// ChangeEvent is a change to an AWX resource delivered by
+// ActivityStreamService.Watch.
+type ChangeEvent struct {
+	// Cursor identifies the position of the event in the activity stream:
+	// the highest entry ID delivered so far. Persist it and pass it back as
+	// WatchOptions.Cursor to resume watching after this event.
+	Cursor int
+	// Action is one of ActivityCreate, ActivityUpdate, ActivityDelete,
+	// ActivityAssociate or ActivityDisassociate.
+	Action string
+	// ResourceType is the type of the resource that changed, e.g. "inventory".
+	ResourceType string
+	// ResourceID is the ID of the resource that changed, or 0 if AWX did not
+	// record it.
+	ResourceID int
+	// Entry is the activity stream entry the event was built from.
+	Entry ActivityStreamEntry
+}
+
+// WatchOptions configures ActivityStreamService.Watch.
+type WatchOptions struct {
+	// Cursor resumes watching after the event with this cursor. When 0 only
+	// changes made after Watch is called are delivered.
+	Cursor int
+	// Interval is the time between polls of the activity stream. It defaults
+	// to 10 seconds.
+	Interval time.Duration
+	// ResourceTypes limits events to resources of the given types.
+	ResourceTypes []string
+	// OnError is called when a poll fails. Watching continues with the next
+	// poll from the same cursor.
+	OnError func(error)
+}
+
+// Watch polls the activity stream and delivers the changes after the cursor
+// in opts on the returned channel, in the order of their IDs within a poll.
+// The channel is closed once ctx is done.
+//
+// AWX can make an entry visible after entries with higher IDs, so each poll
+// also looks at the last 100 IDs below the cursor and delivers the entries
+// among them that were not delivered yet. An entry that becomes visible
+// later than that, or that precedes the cursor Watch was started with, is
+// missed.
+func (s *ActivityStreamServiceOp) Watch(ctx context.Context, opts *WatchOptions) (<-chan ChangeEvent, error) {
+	if opts == nil {
+		opts = &WatchOptions{}
+	}
+	if opts.Cursor < 0 {
+		return nil, NewArgError("Cursor", "cannot be less than 0")
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	cursor := opts.Cursor
+	if cursor == 0 {
+		head, err := s.head(ctx)
+		if err != nil {
+			return nil, err
+		}
+		cursor = head
+	}
+	// start bounds the entries delivered, and seen holds those delivered
+	// within watchOverlap of the cursor.
+	start := cursor
+	seen := make(map[int]bool)
+
+	events := make(chan ChangeEvent)
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			floor := cursor - watchOverlap
+			if floor < start {
+				floor = start
+			}
+			entries, _, err := s.List(ctx, &ActivityStreamListOptions{
+				ObjectTypes: opts.ResourceTypes,
+				AfterID:     floor,
+				OrderBy:     "id",
+			})
+			if err != nil && ctx.Err() == nil && opts.OnError != nil {
+				opts.OnError(err)
+			}
+
+			for _, entry := range entries {
+				if entry.ID <= floor || seen[entry.ID] {
+					continue
+				}
+				event := newChangeEvent(entry)
+				if cursor > event.Cursor {
+					event.Cursor = cursor
+				}
+				select {
+				case events <- event:
+					cursor = event.Cursor
+					seen[entry.ID] = true
+				case <-ctx.Done():
+					return
+				}
+			}
+			for id := range seen {
+				if id <= cursor-watchOverlap {
+					delete(seen, id)
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// head returns the ID of the most recent activity stream entry.
+func (s *ActivityStreamServiceOp) head(ctx context.Context) (int, error) {
+	path := activityStreamBasePath + "?" + url.Values{"order_by": {"-id"}, "page_size": {"1"}}.Encode()
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	root := new(struct {
+		Results []ActivityStreamEntry `json:"results"`
+	})
+	if _, err := s.client.Do(ctx, req, root); err != nil {
+		return 0, err
+	}
+
+	if len(root.Results) == 0 {
+		return 0, nil
+	}
+	return root.Results[0].ID, nil
+}
+
+func newChangeEvent(entry ActivityStreamEntry) ChangeEvent {
+	event := ChangeEvent{
+		Cursor:       entry.ID,
+		Action:       entry.Operation,
+		ResourceType: entry.Object1,
+		Entry:        entry,
+	}
+
+	if objects := entry.SummaryFields.Objects[entry.Object1]; len(objects) > 0 {
+		event.ResourceID = objects[0].ID
+	} else if id, ok := entry.Changes["id"].(float64); ok {
+		event.ResourceID = int(id)
+	}
+
+	return event
+}
@@ -0,0 +1,194 @@
+package awx
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// activityStream serves the entries added to it from the activity stream
+// endpoint, filtered by id__gt and sorted by order_by, and records the
+// queries it was sent.
+type activityStream struct {
+	mu      sync.Mutex
+	entries []map[string]interface{}
+	queries []string
+}
+
+func newActivityStream(t *testing.T, mux *http.ServeMux) *activityStream {
+	s := &activityStream{}
+	mux.HandleFunc("/api/v2/activity_stream/", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		query := r.URL.Query()
+		s.queries = append(s.queries, query.Encode())
+		after, _ := strconv.Atoi(query.Get("id__gt"))
+
+		var results []map[string]interface{}
+		for _, entry := range s.entries {
+			if entry["id"].(int) > after {
+				results = append(results, entry)
+			}
+		}
+		desc := query.Get("order_by") == "-id"
+		sort.Slice(results, func(i, j int) bool {
+			return (results[i]["id"].(int) < results[j]["id"].(int)) != desc
+		})
+		if size, err := strconv.Atoi(query.Get("page_size")); err == nil && len(results) > size {
+			results = results[:size]
+		}
+
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{"count": len(results), "results": results})
+	})
+	return s
+}
+
+func (s *activityStream) add(ids ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range ids {
+		s.entries = append(s.entries, map[string]interface{}{
+			"id":        id,
+			"operation": ActivityUpdate,
+			"object1":   "inventory",
+			"changes":   map[string]interface{}{"id": 2},
+			"timestamp": "2024-06-03T10:05:00Z",
+		})
+	}
+}
+
+func (s *activityStream) lastQuery() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.queries[len(s.queries)-1]
+}
+
+// receive reads n events from events, failing the test if they take more
+// than a few seconds.
+func receive(t *testing.T, events <-chan ChangeEvent, n int) []ChangeEvent {
+	t.Helper()
+
+	var got []ChangeEvent
+	for len(got) < n {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatalf("events closed after %d events, want %d", len(got), n)
+			}
+			got = append(got, event)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("received %d events, want %d", len(got), n)
+		}
+	}
+	return got
+}
+
+func eventIDs(events []ChangeEvent) (ids, cursors []int) {
+	for _, event := range events {
+		ids = append(ids, event.Entry.ID)
+		cursors = append(cursors, event.Cursor)
+	}
+	return ids, cursors
+}
+
+func TestWatchResumesFromCursor(t *testing.T) {
+	client, mux := setup(t)
+	stream := newActivityStream(t, mux)
+	stream.add(3, 5, 6, 7)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.ActivityStream.Watch(ctx, &WatchOptions{Cursor: 5, Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	got := receive(t, events, 2)
+	if ids, _ := eventIDs(got); !reflect.DeepEqual(ids, []int{6, 7}) {
+		t.Errorf("delivered entries %v, want [6 7]", ids)
+	}
+	event := got[0]
+	if event.Action != ActivityUpdate || event.ResourceType != "inventory" || event.ResourceID != 2 {
+		t.Errorf("event = %+v, want an update of inventory 2", event)
+	}
+}
+
+func TestWatchStartsAtHead(t *testing.T) {
+	client, mux := setup(t)
+	stream := newActivityStream(t, mux)
+	stream.add(1, 2, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.ActivityStream.Watch(ctx, &WatchOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	if want := "order_by=-id&page_size=1"; stream.queries[0] != want {
+		t.Errorf("head query = %q, want %q", stream.queries[0], want)
+	}
+
+	stream.add(4)
+	got := receive(t, events, 1)
+	if got[0].Entry.ID != 4 || got[0].Cursor != 4 {
+		t.Errorf("delivered entry %d with cursor %d, want only the entry added after Watch", got[0].Entry.ID, got[0].Cursor)
+	}
+	if query := stream.lastQuery(); query != "id__gt=3&order_by=id&page_size=200" {
+		t.Errorf("poll query = %q, want the entries after the head", query)
+	}
+}
+
+func TestWatchDeliversLateEntries(t *testing.T) {
+	client, mux := setup(t)
+	stream := newActivityStream(t, mux)
+	stream.add(10, 12)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.ActivityStream.Watch(ctx, &WatchOptions{Cursor: 9, Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	got := receive(t, events, 2)
+
+	// Entry 11 commits after entry 12 was delivered.
+	stream.add(11, 13)
+	got = append(got, receive(t, events, 2)...)
+
+	ids, cursors := eventIDs(got)
+	if !reflect.DeepEqual(ids, []int{10, 12, 11, 13}) {
+		t.Errorf("delivered entries %v, want [10 12 11 13] with each once", ids)
+	}
+	if !reflect.DeepEqual(cursors, []int{10, 12, 12, 13}) {
+		t.Errorf("cursors %v, want [10 12 12 13]", cursors)
+	}
+}
+
+func TestWatchStopsWhenCanceled(t *testing.T) {
+	client, mux := setup(t)
+	newActivityStream(t, mux)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events, err := client.ActivityStream.Watch(ctx, &WatchOptions{Cursor: 1, Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Errorf("received an event, want none")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("events not closed after the context was canceled")
+	}
+}
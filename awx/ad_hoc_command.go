@@ -0,0 +1,323 @@
+package awx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const adHocCommandBasePath = "ad_hoc_commands/"
+
+// AdHocCommandService is an interface for interfacing with the AdHocCommand
+// endpoints of the AWX API
+// See: http://localhost/api/v2/ad_hoc_commands/
+type AdHocCommandService interface {
+	List(context.Context) ([]AdHocCommand, *Response, error)
+	Get(context.Context, int) (*AdHocCommand, *Response, error)
+	Create(context.Context, *AdHocCommandCreateRequest) (*AdHocCommand, *Response, error)
+	Delete(context.Context, int) (*Response, error)
+	Cancel(context.Context, int) (*Response, error)
+	Relaunch(context.Context, int) (*AdHocCommand, *Response, error)
+	Events(context.Context, int) ([]AdHocCommandEvent, *Response, error)
+	Stdout(context.Context, int) (string, *Response, error)
+}
+
+// AdHocCommandServiceOp handles communication with the AdHocCommand related methods of the
+// AWX API.
+type AdHocCommandServiceOp struct {
+	client *Client
+}
+
+// AdHocCommand represents a AWX AdHocCommand, a single module run against
+// the hosts of an Inventory.
+type AdHocCommand struct {
+	ID      int    `json:"id"`
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+	Related struct {
+		CreatedBy      string `json:"created_by"`
+		Stdout         string `json:"stdout"`
+		Credential     string `json:"credential"`
+		Inventory      string `json:"inventory"`
+		Events         string `json:"events"`
+		ActivityStream string `json:"activity_stream"`
+		Notifications  string `json:"notifications"`
+		Cancel         string `json:"cancel"`
+		Relaunch       string `json:"relaunch"`
+	} `json:"related"`
+	SummaryFields struct {
+		Inventory        *InventorySummary  `json:"inventory"`
+		Credential       *CredentialSummary `json:"credential"`
+		CreatedBy        *UserSummary       `json:"created_by"`
+		UserCapabilities UserCapabilities   `json:"user_capabilities"`
+	} `json:"summary_fields"`
+	Created         time.Time  `json:"created"`
+	Modified        time.Time  `json:"modified"`
+	Name            string     `json:"name"`
+	LaunchType      string     `json:"launch_type"`
+	Status          string     `json:"status"`
+	Failed          bool       `json:"failed"`
+	Started         *time.Time `json:"started"`
+	Finished        *time.Time `json:"finished"`
+	Elapsed         float64    `json:"elapsed"`
+	JobExplanation  string     `json:"job_explanation"`
+	ExecutionNode   string     `json:"execution_node"`
+	ResultTraceback string     `json:"result_traceback"`
+	JobType         string     `json:"job_type"`
+	Inventory       *int       `json:"inventory"`
+	Limit           string     `json:"limit"`
+	Credential      *int       `json:"credential"`
+	ModuleName      string     `json:"module_name"`
+	ModuleArgs      string     `json:"module_args"`
+	Forks           int        `json:"forks"`
+	Verbosity       int        `json:"verbosity"`
+	ExtraVars       Vars       `json:"extra_vars"`
+	BecomeEnabled   bool       `json:"become_enabled"`
+	DiffMode        bool       `json:"diff_mode"`
+	// Extra holds the fields returned by AWX that have no field above,
+	// such as fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a AdHocCommand, keeping unknown fields in Extra.
+func (ahc *AdHocCommand) UnmarshalJSON(data []byte) error {
+	type adHocCommand AdHocCommand
+	extra, err := unmarshalWithExtra(data, (*adHocCommand)(ahc))
+	ahc.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a AdHocCommand together with the fields in Extra.
+func (ahc AdHocCommand) MarshalJSON() ([]byte, error) {
+	type adHocCommand AdHocCommand
+	return marshalWithExtra(adHocCommand(ahc), ahc.Extra)
+}
+
+// AdHocCommandCreateRequest represents a request to run an AdHocCommand.
+type AdHocCommandCreateRequest struct {
+	JobType       string `json:"job_type,omitempty"`
+	Inventory     int    `json:"inventory"`
+	Limit         string `json:"limit,omitempty"`
+	Credential    int    `json:"credential"`
+	ModuleName    string `json:"module_name"`
+	ModuleArgs    string `json:"module_args,omitempty"`
+	Forks         int    `json:"forks,omitempty"`
+	Verbosity     int    `json:"verbosity,omitempty"`
+	ExtraVars     *Vars  `json:"extra_vars,omitempty"`
+	BecomeEnabled bool   `json:"become_enabled,omitempty"`
+	DiffMode      bool   `json:"diff_mode,omitempty"`
+	// Extra holds fields to send that have no field above, such as
+	// fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes a AdHocCommandCreateRequest together with the fields in Extra.
+func (r AdHocCommandCreateRequest) MarshalJSON() ([]byte, error) {
+	type request AdHocCommandCreateRequest
+	return marshalWithExtra(request(r), r.Extra)
+}
+
+// AdHocCommandEvent represents a single event emitted while an AdHocCommand runs.
+type AdHocCommandEvent struct {
+	ID           int                    `json:"id"`
+	Type         string                 `json:"type"`
+	URL          string                 `json:"url"`
+	Created      time.Time              `json:"created"`
+	Modified     time.Time              `json:"modified"`
+	AdHocCommand int                    `json:"ad_hoc_command"`
+	Event        string                 `json:"event"`
+	Counter      int                    `json:"counter"`
+	EventDisplay string                 `json:"event_display"`
+	EventData    map[string]interface{} `json:"event_data"`
+	Failed       bool                   `json:"failed"`
+	Changed      bool                   `json:"changed"`
+	UUID         string                 `json:"uuid"`
+	Host         *int                   `json:"host"`
+	HostName     string                 `json:"host_name"`
+	Stdout       string                 `json:"stdout"`
+	StartLine    int                    `json:"start_line"`
+	EndLine      int                    `json:"end_line"`
+	Verbosity    int                    `json:"verbosity"`
+}
+
+// adHocCommandRoot represents a AdHocCommand root
+type adHocCommandRoot struct {
+	Count    int            `json:"count"`
+	Next     string         `json:"next"`
+	Previous string         `json:"previous"`
+	Results  []AdHocCommand `json:"results"`
+}
+
+// adHocCommandEventRoot represents a AdHocCommandEvent root
+type adHocCommandEventRoot struct {
+	Count    int                 `json:"count"`
+	Next     string              `json:"next"`
+	Previous string              `json:"previous"`
+	Results  []AdHocCommandEvent `json:"results"`
+}
+
+// List all AdHocCommands.
+func (s *AdHocCommandServiceOp) List(ctx context.Context) ([]AdHocCommand, *Response, error) {
+	path := adHocCommandBasePath
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(adHocCommandRoot)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root.Results, resp, err
+}
+
+// Get individual AdHocCommand.
+func (s *AdHocCommandServiceOp) Get(ctx context.Context, adHocCommandID int) (*AdHocCommand, *Response, error) {
+	if adHocCommandID < 1 {
+		return nil, nil, NewArgError("adHocCommandID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/", adHocCommandBasePath, adHocCommandID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(AdHocCommand)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Create runs a new AdHocCommand.
+func (s *AdHocCommandServiceOp) Create(ctx context.Context, createRequest *AdHocCommandCreateRequest) (*AdHocCommand, *Response, error) {
+	if createRequest == nil {
+		return nil, nil, NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := adHocCommandBasePath
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(AdHocCommand)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Delete AdHocCommand.
+func (s *AdHocCommandServiceOp) Delete(ctx context.Context, adHocCommandID int) (*Response, error) {
+	if adHocCommandID < 1 {
+		return nil, NewArgError("adHocCommandID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/", adHocCommandBasePath, adHocCommandID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+
+	return resp, err
+}
+
+// Cancel a pending or running AdHocCommand.
+func (s *AdHocCommandServiceOp) Cancel(ctx context.Context, adHocCommandID int) (*Response, error) {
+	if adHocCommandID < 1 {
+		return nil, NewArgError("adHocCommandID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/cancel/", adHocCommandBasePath, adHocCommandID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+
+	return resp, err
+}
+
+// Relaunch runs an AdHocCommand again with the same parameters.
+func (s *AdHocCommandServiceOp) Relaunch(ctx context.Context, adHocCommandID int) (*AdHocCommand, *Response, error) {
+	if adHocCommandID < 1 {
+		return nil, nil, NewArgError("adHocCommandID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/relaunch/", adHocCommandBasePath, adHocCommandID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(AdHocCommand)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Events lists the events of an AdHocCommand.
+func (s *AdHocCommandServiceOp) Events(ctx context.Context, adHocCommandID int) ([]AdHocCommandEvent, *Response, error) {
+	if adHocCommandID < 1 {
+		return nil, nil, NewArgError("adHocCommandID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/events/", adHocCommandBasePath, adHocCommandID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(adHocCommandEventRoot)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root.Results, resp, err
+}
+
+// Stdout returns the plain text output of an AdHocCommand.
+func (s *AdHocCommandServiceOp) Stdout(ctx context.Context, adHocCommandID int) (string, *Response, error) {
+	if adHocCommandID < 1 {
+		return "", nil, NewArgError("adHocCommandID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/stdout/?format=txt", adHocCommandBasePath, adHocCommandID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	resp, err := s.client.Do(ctx, req, buf)
+	if err != nil {
+		return "", resp, err
+	}
+
+	return buf.String(), resp, err
+}
@@ -0,0 +1,172 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestAdHocCommandService(t *testing.T) {
+	client, mux := setup(t)
+	ctx := context.Background()
+	command := map[string]interface{}{
+		"id": 30, "type": "ad_hoc_command", "name": "ping",
+		"status": "pending", "inventory": 2, "credential": 5, "limit": "web",
+		"module_name": "ping", "module_args": "", "extra_vars": "",
+		"started": nil, "finished": nil,
+	}
+
+	handle(t, mux, http.MethodPost, "/api/v2/ad_hoc_commands/", "", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("reading request: %v", err)
+		}
+		want := `{"inventory": 2, "limit": "web", "credential": 5, "module_name": "ping", "extra_vars": "{\"retries\":3}", "become_enabled": true}`
+		if !equalJSON(t, body, []byte(want)) {
+			t.Errorf("request body = %s, want %s", body, want)
+		}
+		writeJSON(t, w, http.StatusCreated, command)
+	})
+	handle(t, mux, http.MethodGet, "/api/v2/ad_hoc_commands/30/", "", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, command)
+	})
+	handle(t, mux, http.MethodPost, "/api/v2/ad_hoc_commands/30/cancel/", "", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	handle(t, mux, http.MethodPost, "/api/v2/ad_hoc_commands/30/relaunch/", "", func(w http.ResponseWriter, r *http.Request) {
+		relaunched := map[string]interface{}{"id": 31, "type": "ad_hoc_command", "status": "pending", "module_name": "ping"}
+		writeJSON(t, w, http.StatusCreated, relaunched)
+	})
+	handle(t, mux, http.MethodGet, "/api/v2/ad_hoc_commands/30/events/", "", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{
+			"count": 1,
+			"results": []map[string]interface{}{
+				{"id": 900, "ad_hoc_command": 30, "event": "runner_on_ok", "counter": 1, "host": 4, "host_name": "web1", "event_data": map[string]interface{}{"res": map[string]interface{}{"ping": "pong"}}},
+			},
+		})
+	})
+	handle(t, mux, http.MethodGet, "/api/v2/ad_hoc_commands/30/stdout/", "format=txt", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, "web1 | SUCCESS\n")
+	})
+	handle(t, mux, http.MethodDelete, "/api/v2/ad_hoc_commands/31/", "", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	extraVars, err := NewVars(map[string]interface{}{"retries": 3})
+	if err != nil {
+		t.Fatalf("NewVars: %v", err)
+	}
+	ahc, _, err := client.AdHocCommand.Create(ctx, &AdHocCommandCreateRequest{
+		Inventory:     2,
+		Limit:         "web",
+		Credential:    5,
+		ModuleName:    "ping",
+		ExtraVars:     extraVars,
+		BecomeEnabled: true,
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if ahc.ID != 30 || ahc.Status != "pending" || ahc.Inventory == nil || *ahc.Inventory != 2 || ahc.Started != nil {
+		t.Errorf("Create = %+v, want pending command 30 on inventory 2", ahc)
+	}
+
+	ahc, _, err = client.AdHocCommand.Get(ctx, 30)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if ahc.ModuleName != "ping" || ahc.Limit != "web" || ahc.Credential == nil || *ahc.Credential != 5 {
+		t.Errorf("Get = %+v, want the ping command limited to web", ahc)
+	}
+
+	if _, err := client.AdHocCommand.Cancel(ctx, 30); err != nil {
+		t.Errorf("Cancel: %v", err)
+	}
+
+	relaunched, _, err := client.AdHocCommand.Relaunch(ctx, 30)
+	if err != nil {
+		t.Fatalf("Relaunch: %v", err)
+	}
+	if relaunched.ID != 31 {
+		t.Errorf("Relaunch = command %d, want the new command 31", relaunched.ID)
+	}
+
+	events, _, err := client.AdHocCommand.Events(ctx, 30)
+	if err != nil {
+		t.Fatalf("Events: %v", err)
+	}
+	if len(events) != 1 || events[0].Event != "runner_on_ok" || events[0].Host == nil || *events[0].Host != 4 || events[0].HostName != "web1" {
+		t.Fatalf("Events = %+v, want the runner_on_ok event of web1", events)
+	}
+	if res, _ := json.Marshal(events[0].EventData["res"]); string(res) != `{"ping":"pong"}` {
+		t.Errorf("event_data res = %s, want the module result", res)
+	}
+
+	stdout, _, err := client.AdHocCommand.Stdout(ctx, 30)
+	if err != nil {
+		t.Fatalf("Stdout: %v", err)
+	}
+	if stdout != "web1 | SUCCESS\n" {
+		t.Errorf("Stdout = %q, want the plain text output", stdout)
+	}
+
+	if _, err := client.AdHocCommand.Delete(ctx, 31); err != nil {
+		t.Errorf("Delete: %v", err)
+	}
+}
+
+func TestAdHocCommandList(t *testing.T) {
+	client, mux := setup(t)
+	handle(t, mux, http.MethodGet, "/api/v2/ad_hoc_commands/", "", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{
+			"count": 2,
+			"results": []map[string]interface{}{
+				{"id": 30, "module_name": "ping", "status": "successful", "inventory": 2},
+				{"id": 29, "module_name": "shell", "status": "failed", "failed": true, "inventory": nil},
+			},
+		})
+	})
+
+	commands, _, err := client.AdHocCommand.List(context.Background())
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(commands) != 2 {
+		t.Fatalf("List returned %d commands, want 2", len(commands))
+	}
+	if commands[1].ModuleName != "shell" || !commands[1].Failed || commands[1].Inventory != nil {
+		t.Errorf("commands[1] = %+v, want the failed shell command without an inventory", commands[1])
+	}
+}
+
+func TestAdHocCommandServiceArgErrors(t *testing.T) {
+	client := NewClient(nil)
+	ctx := context.Background()
+
+	var argErr *ArgError
+	if _, _, err := client.AdHocCommand.Create(ctx, nil); !errors.As(err, &argErr) {
+		t.Errorf("Create(nil): %v, want an *ArgError", err)
+	}
+	if _, _, err := client.AdHocCommand.Get(ctx, 0); !errors.As(err, &argErr) {
+		t.Errorf("Get(0): %v, want an *ArgError", err)
+	}
+	if _, err := client.AdHocCommand.Delete(ctx, 0); !errors.As(err, &argErr) {
+		t.Errorf("Delete(0): %v, want an *ArgError", err)
+	}
+	if _, err := client.AdHocCommand.Cancel(ctx, 0); !errors.As(err, &argErr) {
+		t.Errorf("Cancel(0): %v, want an *ArgError", err)
+	}
+	if _, _, err := client.AdHocCommand.Relaunch(ctx, 0); !errors.As(err, &argErr) {
+		t.Errorf("Relaunch(0): %v, want an *ArgError", err)
+	}
+	if _, _, err := client.AdHocCommand.Events(ctx, 0); !errors.As(err, &argErr) {
+		t.Errorf("Events(0): %v, want an *ArgError", err)
+	}
+	if _, _, err := client.AdHocCommand.Stdout(ctx, 0); !errors.As(err, &argErr) {
+		t.Errorf("Stdout(0): %v, want an *ArgError", err)
+	}
+}
@@ -0,0 +1,150 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// defaultAPIRoot is the root of the AWX API, relative to the BaseURL.
+const defaultAPIRoot = "api/v2/"
+
+// apiIndexPath is where AWX, and the gateway of Ansible Automation Platform,
+// list the APIs they serve.
+const apiIndexPath = "api/"
+
+// apiIndex is the response of an API index endpoint. AWX reports the
+// current version of its API; the platform gateway lists the APIs of the
+// services behind it instead.
+type apiIndex struct {
+	CurrentVersion string            `json:"current_version"`
+	APIs           map[string]string `json:"apis"`
+}
+
+// WithAPIRoot is a client option that sets the root the paths used by the
+// services are resolved against, e.g. "api/controller/v2/" for Ansible
+// Automation Platform 2.5 and later. A root without a leading slash is
+// relative to the BaseURL. Paths passed to NewRequest that start with "api/"
+// are not affected by it.
+func WithAPIRoot(root string) ClientOpt {
+	return func(c *Client) error {
+		if root == "" {
+			return NewArgError("root", "cannot be empty")
+		}
+		if !strings.HasSuffix(root, "/") {
+			root += "/"
+		}
+		c.APIRoot = root
+		return nil
+	}
+}
+
+// WithAPIDiscovery is a client option that discovers the API root with
+// DiscoverAPIRoot before the first request is made, so the same Client
+// works against AWX and the Ansible Automation Platform gateway.
+func WithAPIDiscovery() ClientOpt {
+	return func(c *Client) error {
+		c.discoverAPIRoot = true
+		return nil
+	}
+}
+
+// DiscoverAPIRoot asks the server at BaseURL for the root of the AWX API and
+// sets APIRoot to it. AWX reports its current API version directly; behind
+// the Ansible Automation Platform gateway the root of the controller API is
+// looked up first.
+func (c *Client) DiscoverAPIRoot(ctx context.Context) (string, error) {
+	c.rootMu.Lock()
+	defer c.rootMu.Unlock()
+
+	root, err := c.fetchAPIRoot(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.APIRoot = root
+	c.discoverAPIRoot = false
+
+	return root, nil
+}
+
+func (c *Client) fetchAPIRoot(ctx context.Context) (string, error) {
+	index, err := c.getAPIIndex(ctx, c.BaseURL.ResolveReference(&url.URL{Path: apiIndexPath}))
+	if err != nil {
+		return "", err
+	}
+	if index.CurrentVersion != "" {
+		return index.CurrentVersion, nil
+	}
+
+	controller, ok := index.APIs["controller"]
+	if !ok {
+		return "", fmt.Errorf("awx: no controller API found at %s", apiIndexPath)
+	}
+	index, err = c.getAPIIndex(ctx, c.BaseURL.ResolveReference(&url.URL{Path: controller}))
+	if err != nil {
+		return "", err
+	}
+	if index.CurrentVersion == "" {
+		return "", fmt.Errorf("awx: no current API version found at %s", controller)
+	}
+
+	return index.CurrentVersion, nil
+}
+
+func (c *Client) getAPIIndex(ctx context.Context, u *url.URL) (*apiIndex, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	root := new(apiIndex)
+	if _, err := c.Do(ctx, req, root); err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
+
+// resolveURL resolves urlStr as described by NewRequest. Paths under "api/"
+// stay relative to the BaseURL, so callers that spelled out the full path
+// before APIRoot existed keep working.
+func (c *Client) resolveURL(ctx context.Context, urlStr string) (*url.URL, error) {
+	rel, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	if rel.IsAbs() || strings.HasPrefix(rel.Path, "/") || strings.HasPrefix(rel.Path, apiIndexPath) {
+		return c.BaseURL.ResolveReference(rel), nil
+	}
+
+	root, err := c.apiRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	base := c.BaseURL.ResolveReference(&url.URL{Path: root})
+
+	return base.ResolveReference(rel), nil
+}
+
+// apiRoot returns the API root, discovering it first if WithAPIDiscovery was
+// given.
+func (c *Client) apiRoot(ctx context.Context) (string, error) {
+	c.rootMu.Lock()
+	defer c.rootMu.Unlock()
+
+	if c.discoverAPIRoot {
+		root, err := c.fetchAPIRoot(ctx)
+		if err != nil {
+			return "", fmt.Errorf("awx: discovering API root: %w", err)
+		}
+		c.APIRoot = root
+		c.discoverAPIRoot = false
+	}
+	if c.APIRoot == "" {
+		return defaultAPIRoot, nil
+	}
+
+	return c.APIRoot, nil
+}
@@ -0,0 +1,83 @@
+package awx
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestNewRequestResolvesURL(t *testing.T) {
+	tests := []struct {
+		root   string
+		urlStr string
+		want   string
+	}{
+		{root: defaultAPIRoot, urlStr: "inventories/", want: "http://awx.example.com/api/v2/inventories/"},
+		{root: defaultAPIRoot, urlStr: "api/v2/inventories/", want: "http://awx.example.com/api/v2/inventories/"},
+		{root: defaultAPIRoot, urlStr: "/api/v2/inventories/?page=2", want: "http://awx.example.com/api/v2/inventories/?page=2"},
+		{root: "api/controller/v2/", urlStr: "inventories/", want: "http://awx.example.com/api/controller/v2/inventories/"},
+		{root: "api/controller/v2/", urlStr: "api/v2/inventories/", want: "http://awx.example.com/api/v2/inventories/"},
+		{root: "api/controller/v2/", urlStr: "/api/controller/v2/inventories/", want: "http://awx.example.com/api/controller/v2/inventories/"},
+		{root: "api/controller/v2/", urlStr: "https://other.example.com/x/", want: "https://other.example.com/x/"},
+	}
+
+	for _, tt := range tests {
+		client := NewClient(nil)
+		client.BaseURL, _ = url.Parse("http://awx.example.com/")
+		if err := WithAPIRoot(tt.root)(client); err != nil {
+			t.Fatalf("WithAPIRoot: %v", err)
+		}
+
+		req, err := client.NewRequest(context.Background(), http.MethodGet, tt.urlStr, nil)
+		if err != nil {
+			t.Fatalf("NewRequest(%q): %v", tt.urlStr, err)
+		}
+		if got := req.URL.String(); got != tt.want {
+			t.Errorf("NewRequest(%q) with root %q: URL %s, want %s", tt.urlStr, tt.root, got, tt.want)
+		}
+	}
+}
+
+func TestDiscoverAPIRoot(t *testing.T) {
+	tests := []struct {
+		name  string
+		index map[string]string
+		want  string
+	}{
+		{
+			name:  "awx",
+			index: map[string]string{"/api/": `{"current_version": "/api/v2/"}`},
+			want:  "/api/v2/",
+		},
+		{
+			name: "platform gateway",
+			index: map[string]string{
+				"/api/":            `{"apis": {"gateway": "/api/gateway/", "controller": "/api/controller/"}}`,
+				"/api/controller/": `{"current_version": "/api/controller/v2/"}`,
+			},
+			want: "/api/controller/v2/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux := setup(t)
+			for path, body := range tt.index {
+				body := body
+				mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					w.Write([]byte(body))
+				})
+			}
+
+			root, err := client.DiscoverAPIRoot(context.Background())
+			if err != nil {
+				t.Fatalf("DiscoverAPIRoot: %v", err)
+			}
+			if root != tt.want || client.APIRoot != tt.want {
+				t.Errorf("DiscoverAPIRoot = %q, APIRoot %q, want %q", root, client.APIRoot, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// associationRequest is the body AWX expects when linking or unlinking an
+// object to one of the related collections of another.
+type associationRequest struct {
+	ID           int  `json:"id"`
+	Disassociate bool `json:"disassociate,omitempty"`
+}
+
+// associate links the object with the given ID to the related collection at path.
+func associate(ctx context.Context, client *Client, path string, id int) (*Response, error) {
+	if id < 1 {
+		return nil, NewArgError("id", "cannot be less than 1")
+	}
+
+	req, err := client.NewRequest(ctx, http.MethodPost, path, &associationRequest{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(ctx, req, nil)
+
+	return resp, err
+}
+
+// disassociate unlinks the object with the given ID from the related collection at path.
+func disassociate(ctx context.Context, client *Client, path string, id int) (*Response, error) {
+	if id < 1 {
+		return nil, NewArgError("id", "cannot be less than 1")
+	}
+
+	req, err := client.NewRequest(ctx, http.MethodPost, path, &associationRequest{ID: id, Disassociate: true})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(ctx, req, nil)
+
+	return resp, err
+}
+
+// PartialAssociationError is returned when replacing the members of an
+// ordered related collection, such as the instance groups of an
+// Organization, fails part way through.
+type PartialAssociationError struct {
+	// Members are the IDs the collection was left with, in order.
+	Members []int
+	// Err is the error the change stopped at.
+	Err error
+}
+
+func (e *PartialAssociationError) Error() string {
+	return fmt.Sprintf("collection only partially updated, members are now %v: %v", e.Members, e.Err)
+}
+
+func (e *PartialAssociationError) Unwrap() error {
+	return e.Err
+}
+
+// setOrderedAssociation replaces the members of an ordered related collection,
+// such as the instance groups of an Organization. AWX keeps members in the
+// order they were associated, so from the first position where current and
+// ids differ every current member is removed and the remaining IDs are
+// associated in order. Members before that position are left alone. If a
+// request fails once the collection has been changed, the error is a
+// *PartialAssociationError.
+func setOrderedAssociation(ctx context.Context, client *Client, path string, current, ids []int) (*Response, error) {
+	for _, id := range ids {
+		if id < 1 {
+			return nil, NewArgError("ids", "cannot contain IDs less than 1")
+		}
+	}
+
+	keep := 0
+	for keep < len(current) && keep < len(ids) && current[keep] == ids[keep] {
+		keep++
+	}
+
+	members := append([]int(nil), current...)
+	changed := false
+	fail := func(resp *Response, err error) (*Response, error) {
+		if !changed {
+			return resp, err
+		}
+		return resp, &PartialAssociationError{Members: members, Err: err}
+	}
+
+	var resp *Response
+	var err error
+	for _, id := range current[keep:] {
+		resp, err = disassociate(ctx, client, path, id)
+		if err != nil {
+			return fail(resp, err)
+		}
+		members = without(members, id)
+		changed = true
+	}
+
+	for _, id := range ids[keep:] {
+		resp, err = associate(ctx, client, path, id)
+		if err != nil {
+			return fail(resp, err)
+		}
+		members = append(members, id)
+		changed = true
+	}
+
+	return resp, err
+}
+
+// without returns ids without the first occurrence of id.
+func without(ids []int, id int) []int {
+	for i, v := range ids {
+		if v == id {
+			return append(ids[:i:i], ids[i+1:]...)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,123 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// orderedCollection serves a related collection of instance groups the way
+// AWX does: one member per page, and new members appended at the end.
+type orderedCollection struct {
+	t       *testing.T
+	members []int
+	// failOn makes associating or disassociating this ID fail.
+	failOn int
+	// requests counts the association requests made.
+	requests int
+}
+
+func (c *orderedCollection) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 1 {
+			page = 1
+		}
+		body := map[string]interface{}{"count": len(c.members), "results": []InstanceGroup{}}
+		if page <= len(c.members) {
+			body["results"] = []InstanceGroup{{ID: c.members[page-1]}}
+		}
+		if page < len(c.members) {
+			body["next"] = fmt.Sprintf("%s?page=%d", r.URL.Path, page+1)
+		}
+		writeJSON(c.t, w, http.StatusOK, body)
+		return
+	}
+
+	var req associationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.t.Fatalf("decoding association: %v", err)
+	}
+	c.requests++
+	if req.ID == c.failOn {
+		writeJSON(c.t, w, http.StatusBadRequest, map[string]string{"detail": "failed"})
+		return
+	}
+	if req.Disassociate {
+		c.members = without(c.members, req.ID)
+	} else {
+		c.members = append(c.members, req.ID)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestSetInstanceGroups(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  []int
+		want     []int
+		requests int
+	}{
+		{name: "unchanged", current: []int{1, 2, 3}, want: []int{1, 2, 3}, requests: 0},
+		{name: "append", current: []int{1, 2}, want: []int{1, 2, 3}, requests: 1},
+		{name: "reorder tail", current: []int{1, 2, 3}, want: []int{1, 3, 2}, requests: 4},
+		{name: "remove beyond first page", current: []int{1, 2, 3, 4}, want: []int{1}, requests: 3},
+		{name: "clear", current: []int{1, 2}, want: nil, requests: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux := setup(t)
+			collection := &orderedCollection{t: t, members: tt.current}
+			mux.Handle("/api/v2/organizations/1/instance_groups/", collection)
+
+			if _, err := client.Organization.SetInstanceGroups(context.Background(), 1, tt.want); err != nil {
+				t.Fatalf("SetInstanceGroups: %v", err)
+			}
+			if len(collection.members)+len(tt.want) > 0 && !reflect.DeepEqual(collection.members, tt.want) {
+				t.Errorf("members = %v, want %v", collection.members, tt.want)
+			}
+			if collection.requests != tt.requests {
+				t.Errorf("made %d association requests, want %d", collection.requests, tt.requests)
+			}
+		})
+	}
+}
+
+func TestSetInstanceGroupsPartialFailure(t *testing.T) {
+	client, mux := setup(t)
+	collection := &orderedCollection{t: t, members: []int{1, 2}, failOn: 4}
+	mux.Handle("/api/v2/organizations/1/instance_groups/", collection)
+
+	_, err := client.Organization.SetInstanceGroups(context.Background(), 1, []int{1, 3, 4})
+
+	var partial *PartialAssociationError
+	if !errors.As(err, &partial) {
+		t.Fatalf("SetInstanceGroups: %v, want a *PartialAssociationError", err)
+	}
+	if want := []int{1, 3}; !reflect.DeepEqual(partial.Members, want) || !reflect.DeepEqual(collection.members, want) {
+		t.Errorf("reported members %v, left with %v, want %v", partial.Members, collection.members, want)
+	}
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Errorf("SetInstanceGroups: %v, want it to wrap the *ErrorResponse", err)
+	}
+}
+
+func TestSetInstanceGroupsFailureBeforeChange(t *testing.T) {
+	client, mux := setup(t)
+	collection := &orderedCollection{t: t, members: []int{1, 2}, failOn: 2}
+	mux.Handle("/api/v2/organizations/1/instance_groups/", collection)
+
+	_, err := client.Organization.SetInstanceGroups(context.Background(), 1, []int{1})
+
+	var partial *PartialAssociationError
+	if err == nil || errors.As(err, &partial) {
+		t.Fatalf("SetInstanceGroups: %v, want the plain error of the first request", err)
+	}
+}
@@ -0,0 +1,761 @@
+package awxfake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sparkacus/awx-go-client/awx"
+)
+
+// The in-memory defaults of the methods other than List, Get, Create, Update
+// and Delete, which are shared by every service with a collection.
+
+// finished reports whether a job status is final.
+func finished(status interface{}) bool {
+	switch status {
+	case "successful", "failed", "error", "canceled":
+		return true
+	}
+	return false
+}
+
+// cancel cancels a job that has not finished yet.
+func cancel(f *Fake, collection, arg string, id int) (*awx.Response, error) {
+	if err := checkID(arg, id); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p := path(collection, id) + "cancel/"
+	job, resp, err := f.lookup(http.MethodPost, collection, id)
+	if err != nil {
+		return resp, err
+	}
+	if finished(job["status"]) {
+		return errorResponse(http.MethodPost, p, http.StatusMethodNotAllowed, `Method "POST" not allowed.`)
+	}
+
+	job["status"] = "canceled"
+	job["failed"] = true
+	job["finished"] = now()
+	job["canceled_on"] = job["finished"]
+
+	return response(http.MethodPost, p, http.StatusAccepted), nil
+}
+
+// stdout returns the output of a job, which the fakes do not run.
+func stdout(f *Fake, collection, arg string, id int) (string, *awx.Response, error) {
+	if err := checkID(arg, id); err != nil {
+		return "", nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, resp, err := f.lookup(http.MethodGet, collection, id); err != nil {
+		return "", resp, err
+	}
+
+	return "", response(http.MethodGet, path(collection, id)+"stdout/", http.StatusOK), nil
+}
+
+// launch starts a job of a template, e.g. an inventory update of an
+// inventory source, copying the fields the job shares with it. It must be
+// called with f.mu held.
+func (s *state) launch(collection string, tmpl object, fields ...string) object {
+	typ, _ := tmpl["type"].(string)
+	job := object{
+		typ:                    tmpl.id(),
+		"unified_job_template": tmpl.id(),
+		"name":                 tmpl["name"],
+		"description":          tmpl["description"],
+	}
+	for _, name := range fields {
+		if v, ok := tmpl[name]; ok {
+			job[name] = v
+		}
+	}
+
+	job = s.insert(collection, job)
+	s.track(awx.ActivityCreate, job, changes(job), nil, "")
+	return job
+}
+
+func getSurveySpec(f *Fake, collection, arg string, id int) (*awx.SurveySpec, *awx.Response, error) {
+	if err := checkID(arg, id); err != nil {
+		return nil, nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, resp, err := f.lookup(http.MethodGet, collection, id); err != nil {
+		return nil, resp, err
+	}
+
+	spec := new(awx.SurveySpec)
+	if err := decode(f.surveys[path(collection, id)], spec); err != nil {
+		return nil, nil, err
+	}
+
+	return spec, response(http.MethodGet, path(collection, id)+"survey_spec/", http.StatusOK), nil
+}
+
+func setSurveySpec(f *Fake, collection, arg string, id int, spec *awx.SurveySpec) (*awx.Response, error) {
+	if err := checkID(arg, id); err != nil {
+		return nil, err
+	}
+	if spec == nil {
+		return nil, awx.NewArgError("spec", "cannot be nil")
+	}
+	values, err := encode(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, resp, err := f.lookup(http.MethodPost, collection, id); err != nil {
+		return resp, err
+	}
+	f.surveys[path(collection, id)] = values
+
+	return response(http.MethodPost, path(collection, id)+"survey_spec/", http.StatusOK), nil
+}
+
+func deleteSurveySpec(f *Fake, collection, arg string, id int) (*awx.Response, error) {
+	if err := checkID(arg, id); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, resp, err := f.lookup(http.MethodDelete, collection, id); err != nil {
+		return resp, err
+	}
+	delete(f.surveys, path(collection, id))
+
+	return response(http.MethodDelete, path(collection, id)+"survey_spec/", http.StatusOK), nil
+}
+
+// list lists the changes made through the fakes, filtered by opts and in
+// order of ID, or in reverse if opts.OrderBy starts with "-".
+func (s *ActivityStreamService) list(ctx context.Context, opts *awx.ActivityStreamListOptions) ([]awx.ActivityStreamEntry, *awx.Response, error) {
+	if opts == nil {
+		opts = &awx.ActivityStreamListOptions{}
+	}
+	if opts.ObjectID != 0 && opts.ObjectType == "" {
+		return nil, nil, awx.NewArgError("ObjectID", "requires ObjectType to be set")
+	}
+
+	s.fake.mu.Lock()
+	all, err := decodeAll[awx.ActivityStreamEntry](s.fake.activity)
+	s.fake.mu.Unlock()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries := []awx.ActivityStreamEntry{}
+	for _, entry := range all {
+		if activityMatches(entry, opts) {
+			entries = append(entries, entry)
+		}
+	}
+	if strings.HasPrefix(opts.OrderBy, "-") {
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].ID > entries[j].ID })
+	}
+
+	return entries, response(http.MethodGet, "activity_stream/", http.StatusOK), nil
+}
+
+func activityMatches(entry awx.ActivityStreamEntry, opts *awx.ActivityStreamListOptions) bool {
+	about := func(typ string) bool { return entry.Object1 == typ || entry.Object2 == typ }
+
+	if opts.ObjectType != "" && !about(opts.ObjectType) {
+		return false
+	}
+	if len(opts.ObjectTypes) > 0 {
+		found := false
+		for _, typ := range opts.ObjectTypes {
+			found = found || about(typ)
+		}
+		if !found {
+			return false
+		}
+	}
+	if opts.ObjectID != 0 {
+		found := false
+		for _, obj := range entry.SummaryFields.Objects[opts.ObjectType] {
+			found = found || obj.ID == opts.ObjectID
+		}
+		if !found {
+			return false
+		}
+	}
+
+	switch {
+	case opts.Operation != "" && entry.Operation != opts.Operation,
+		opts.Actor != "" && (entry.SummaryFields.Actor == nil || entry.SummaryFields.Actor.Username != opts.Actor),
+		!opts.Since.IsZero() && entry.Timestamp.Before(opts.Since),
+		!opts.Until.IsZero() && !entry.Timestamp.Before(opts.Until),
+		entry.ID <= opts.AfterID:
+		return false
+	}
+	return true
+}
+
+// watch delivers the changes made through the fakes after the cursor in
+// opts as soon as they are made. The channel is closed once ctx is done.
+func (s *ActivityStreamService) watch(ctx context.Context, opts *awx.WatchOptions) (<-chan awx.ChangeEvent, error) {
+	if opts == nil {
+		opts = &awx.WatchOptions{}
+	}
+	if opts.Cursor < 0 {
+		return nil, awx.NewArgError("Cursor", "cannot be less than 0")
+	}
+
+	cursor := opts.Cursor
+	if cursor == 0 {
+		s.fake.mu.Lock()
+		if n := len(s.fake.activity); n > 0 {
+			cursor = s.fake.activity[n-1].id()
+		}
+		s.fake.mu.Unlock()
+	}
+
+	events := make(chan awx.ChangeEvent)
+	go func() {
+		defer close(events)
+
+		for {
+			s.fake.mu.Lock()
+			changed := s.fake.changed
+			s.fake.mu.Unlock()
+
+			entries, _, err := s.list(ctx, &awx.ActivityStreamListOptions{ObjectTypes: opts.ResourceTypes, AfterID: cursor})
+			if err != nil && opts.OnError != nil {
+				opts.OnError(err)
+			}
+
+			for _, entry := range entries {
+				select {
+				case events <- newChangeEvent(entry):
+					cursor = entry.ID
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-changed:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+func newChangeEvent(entry awx.ActivityStreamEntry) awx.ChangeEvent {
+	event := awx.ChangeEvent{
+		Cursor:       entry.ID,
+		Action:       entry.Operation,
+		ResourceType: entry.Object1,
+		Entry:        entry,
+	}
+	if objects := entry.SummaryFields.Objects[entry.Object1]; len(objects) > 0 {
+		event.ResourceID = objects[0].ID
+	}
+	return event
+}
+
+func (s *AdHocCommandService) cancel(ctx context.Context, adHocCommandID int) (*awx.Response, error) {
+	return cancel(s.fake, "ad_hoc_commands", "adHocCommandID", adHocCommandID)
+}
+
+// relaunch starts a new ad hoc command with the fields of an existing one.
+func (s *AdHocCommandService) relaunch(ctx context.Context, adHocCommandID int) (*awx.AdHocCommand, *awx.Response, error) {
+	if err := checkID("adHocCommandID", adHocCommandID); err != nil {
+		return nil, nil, err
+	}
+
+	s.fake.mu.Lock()
+	defer s.fake.mu.Unlock()
+
+	command, resp, err := s.fake.lookup(http.MethodPost, "ad_hoc_commands", adHocCommandID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	relaunched := object{}
+	for name, v := range command {
+		switch name {
+		case "id", "url", "created", "modified", "status", "failed", "started", "finished", "elapsed", "canceled_on", "summary_fields":
+			continue
+		}
+		relaunched[name] = v
+	}
+	relaunched["launch_type"] = "relaunch"
+	relaunched = s.fake.insert("ad_hoc_commands", relaunched)
+	s.fake.track(awx.ActivityCreate, relaunched, changes(relaunched), nil, "")
+
+	out := new(awx.AdHocCommand)
+	if err := decode(relaunched, out); err != nil {
+		return nil, nil, err
+	}
+
+	return out, response(http.MethodPost, path("ad_hoc_commands", adHocCommandID)+"relaunch/", http.StatusCreated), nil
+}
+
+// events returns no events, as the fakes do not run ad hoc commands.
+func (s *AdHocCommandService) events(ctx context.Context, adHocCommandID int) ([]awx.AdHocCommandEvent, *awx.Response, error) {
+	if _, resp, err := stdout(s.fake, "ad_hoc_commands", "adHocCommandID", adHocCommandID); err != nil {
+		return nil, resp, err
+	}
+	return []awx.AdHocCommandEvent{}, response(http.MethodGet, path("ad_hoc_commands", adHocCommandID)+"events/", http.StatusOK), nil
+}
+
+func (s *AdHocCommandService) stdout(ctx context.Context, adHocCommandID int) (string, *awx.Response, error) {
+	return stdout(s.fake, "ad_hoc_commands", "adHocCommandID", adHocCommandID)
+}
+
+func (s *InstanceGroupService) listInstances(ctx context.Context, instanceGroupID int) ([]awx.Instance, *awx.Response, error) {
+	return listRelated[awx.Instance](s.fake, "instance_groups", "instanceGroupID", instanceGroupID, "instances")
+}
+
+func (s *InstanceGroupService) associateInstance(ctx context.Context, instanceGroupID, instanceID int) (*awx.Response, error) {
+	return associate(s.fake, "instance_groups", "instanceGroupID", instanceGroupID, "instances", "instanceID", instanceID)
+}
+
+func (s *InstanceGroupService) disassociateInstance(ctx context.Context, instanceGroupID, instanceID int) (*awx.Response, error) {
+	return disassociate(s.fake, "instance_groups", "instanceGroupID", instanceGroupID, "instances", "instanceID", instanceID)
+}
+
+// syncAllSources starts an inventory update of every inventory source of an
+// inventory.
+func (s *InventoryService) syncAllSources(ctx context.Context, inventoryID int) ([]awx.InventorySourceSync, *awx.Response, error) {
+	if err := checkID("inventoryID", inventoryID); err != nil {
+		return nil, nil, err
+	}
+
+	s.fake.mu.Lock()
+	defer s.fake.mu.Unlock()
+
+	p := path("inventories", inventoryID) + "update_inventory_sources/"
+	if _, resp, err := s.fake.lookup(http.MethodPost, "inventories", inventoryID); err != nil {
+		return nil, resp, err
+	}
+
+	syncs := []awx.InventorySourceSync{}
+	for _, src := range s.fake.sorted("inventory_sources") {
+		if intValue(src["inventory"]) != inventoryID {
+			continue
+		}
+		update := s.fake.launch("inventory_updates", src, "inventory", "source", "source_path", "source_vars", "limit", "verbosity")
+		syncs = append(syncs, awx.InventorySourceSync{InventorySource: src.id(), InventoryUpdate: update.id(), Status: "started"})
+	}
+	if len(syncs) == 0 {
+		resp, err := errorResponse(http.MethodPost, p, http.StatusBadRequest, "No inventory sources to update.")
+		return nil, resp, err
+	}
+
+	return syncs, response(http.MethodPost, p, http.StatusAccepted), nil
+}
+
+func (s *InventoryService) listInstanceGroups(ctx context.Context, inventoryID int) ([]awx.InstanceGroup, *awx.Response, error) {
+	return listRelated[awx.InstanceGroup](s.fake, "inventories", "inventoryID", inventoryID, "instance_groups")
+}
+
+func (s *InventoryService) setInstanceGroups(ctx context.Context, inventoryID int, instanceGroupIDs []int) (*awx.Response, error) {
+	return setRelated(s.fake, "inventories", "inventoryID", inventoryID, "instance_groups", instanceGroupIDs)
+}
+
+// sync starts an inventory update of an inventory source.
+func (s *InventorySourceService) sync(ctx context.Context, inventorySourceID int) (*awx.InventoryUpdate, *awx.Response, error) {
+	if err := checkID("inventorySourceID", inventorySourceID); err != nil {
+		return nil, nil, err
+	}
+
+	s.fake.mu.Lock()
+	defer s.fake.mu.Unlock()
+
+	src, resp, err := s.fake.lookup(http.MethodPost, "inventory_sources", inventorySourceID)
+	if err != nil {
+		return nil, resp, err
+	}
+	update := s.fake.launch("inventory_updates", src, "inventory", "source", "source_path", "source_vars", "limit", "verbosity")
+
+	out := new(awx.InventoryUpdate)
+	if err := decode(update, out); err != nil {
+		return nil, nil, err
+	}
+
+	return out, response(http.MethodPost, path("inventory_sources", inventorySourceID)+"update/", http.StatusAccepted), nil
+}
+
+func (s *InventoryUpdateService) cancel(ctx context.Context, inventoryUpdateID int) (*awx.Response, error) {
+	return cancel(s.fake, "inventory_updates", "inventoryUpdateID", inventoryUpdateID)
+}
+
+func (s *InventoryUpdateService) stdout(ctx context.Context, inventoryUpdateID int) (string, *awx.Response, error) {
+	return stdout(s.fake, "inventory_updates", "inventoryUpdateID", inventoryUpdateID)
+}
+
+func (s *JobTemplateService) getSurveySpec(ctx context.Context, jobTemplateID int) (*awx.SurveySpec, *awx.Response, error) {
+	return getSurveySpec(s.fake, "job_templates", "jobTemplateID", jobTemplateID)
+}
+
+func (s *JobTemplateService) setSurveySpec(ctx context.Context, jobTemplateID int, spec *awx.SurveySpec) (*awx.Response, error) {
+	return setSurveySpec(s.fake, "job_templates", "jobTemplateID", jobTemplateID, spec)
+}
+
+func (s *JobTemplateService) deleteSurveySpec(ctx context.Context, jobTemplateID int) (*awx.Response, error) {
+	return deleteSurveySpec(s.fake, "job_templates", "jobTemplateID", jobTemplateID)
+}
+
+func (s *JobTemplateService) listInstanceGroups(ctx context.Context, jobTemplateID int) ([]awx.InstanceGroup, *awx.Response, error) {
+	return listRelated[awx.InstanceGroup](s.fake, "job_templates", "jobTemplateID", jobTemplateID, "instance_groups")
+}
+
+func (s *JobTemplateService) setInstanceGroups(ctx context.Context, jobTemplateID int, instanceGroupIDs []int) (*awx.Response, error) {
+	return setRelated(s.fake, "job_templates", "jobTemplateID", jobTemplateID, "instance_groups", instanceGroupIDs)
+}
+
+func (s *JobTemplateService) listLabels(ctx context.Context, jobTemplateID int) ([]awx.Label, *awx.Response, error) {
+	return listRelated[awx.Label](s.fake, "job_templates", "jobTemplateID", jobTemplateID, "labels")
+}
+
+func (s *JobTemplateService) associateLabel(ctx context.Context, jobTemplateID, labelID int) (*awx.Response, error) {
+	return associate(s.fake, "job_templates", "jobTemplateID", jobTemplateID, "labels", "labelID", labelID)
+}
+
+func (s *JobTemplateService) disassociateLabel(ctx context.Context, jobTemplateID, labelID int) (*awx.Response, error) {
+	return disassociate(s.fake, "job_templates", "jobTemplateID", jobTemplateID, "labels", "labelID", labelID)
+}
+
+// findTemplates finds the job templates and workflow job templates with a
+// label of the given name.
+func (s *LabelService) findTemplates(ctx context.Context, labelName string) (*awx.LabeledTemplates, *awx.Response, error) {
+	if labelName == "" {
+		return nil, nil, awx.NewArgError("labelName", "cannot be empty")
+	}
+
+	s.fake.mu.Lock()
+	defer s.fake.mu.Unlock()
+
+	labeled := func(collection string) []object {
+		var objs []object
+		for _, tmpl := range s.fake.sorted(collection) {
+			for _, label := range s.fake.members(collection, tmpl.id(), "labels") {
+				if label["name"] == labelName {
+					objs = append(objs, tmpl)
+					break
+				}
+			}
+		}
+		return objs
+	}
+
+	var err error
+	templates := new(awx.LabeledTemplates)
+	if templates.JobTemplates, err = decodeAll[awx.JobTemplate](labeled("job_templates")); err != nil {
+		return nil, nil, err
+	}
+	if templates.WorkflowJobTemplates, err = decodeAll[awx.WorkflowJobTemplate](labeled("workflow_job_templates")); err != nil {
+		return nil, nil, err
+	}
+
+	return templates, response(http.MethodGet, "workflow_job_templates/", http.StatusOK), nil
+}
+
+// get returns metadata without any actions, as the fakes do not describe
+// their endpoints.
+func (s *MetadataService) get(ctx context.Context, path string) (*awx.EndpointMetadata, *awx.Response, error) {
+	if path == "" {
+		return nil, nil, awx.NewArgError("path", "cannot be empty")
+	}
+	metadata := &awx.EndpointMetadata{Actions: map[string]map[string]awx.FieldMetadata{}}
+	return metadata, response(http.MethodOptions, path, http.StatusOK), nil
+}
+
+func (s *MetadataService) choices(ctx context.Context, path, field string) ([]awx.FieldChoice, error) {
+	return nil, fmt.Errorf("awx: field %q of %s has no choices", field, path)
+}
+
+// validate accepts every body.
+func (s *MetadataService) validate(ctx context.Context, method, path string, body interface{}) error {
+	if body == nil {
+		return awx.NewArgError("body", "cannot be nil")
+	}
+	return nil
+}
+
+func (s *OrganizationService) listInstanceGroups(ctx context.Context, organizationID int) ([]awx.InstanceGroup, *awx.Response, error) {
+	return listRelated[awx.InstanceGroup](s.fake, "organizations", "organizationID", organizationID, "instance_groups")
+}
+
+func (s *OrganizationService) setInstanceGroups(ctx context.Context, organizationID int, instanceGroupIDs []int) (*awx.Response, error) {
+	return setRelated(s.fake, "organizations", "organizationID", organizationID, "instance_groups", instanceGroupIDs)
+}
+
+func (s *PingService) get(ctx context.Context) (*awx.Ping, *awx.Response, error) {
+	ping := &awx.Ping{
+		Version:    version,
+		ActiveNode: "awx",
+		Instances: []awx.PingInstance{
+			{Node: "awx", NodeType: "hybrid", Heartbeat: time.Now().UTC(), Capacity: 100, Version: version},
+		},
+	}
+	return ping, response(http.MethodGet, "ping/", http.StatusOK), nil
+}
+
+func (s *ServerConfigService) get(ctx context.Context) (*awx.ServerConfig, *awx.Response, error) {
+	config := &awx.ServerConfig{
+		TimeZone:       "UTC",
+		Version:        version,
+		ProjectBaseDir: "/var/lib/awx/projects",
+	}
+	return config, response(http.MethodGet, "config/", http.StatusOK), nil
+}
+
+// playbooks returns no playbooks, as the fakes do not check out projects.
+func (s *ProjectService) playbooks(ctx context.Context, projectID int) ([]string, *awx.Response, error) {
+	return projectFiles(s.fake, projectID, "playbooks/")
+}
+
+// inventoryFiles returns no files, as the fakes do not check out projects.
+func (s *ProjectService) inventoryFiles(ctx context.Context, projectID int) ([]string, *awx.Response, error) {
+	return projectFiles(s.fake, projectID, "inventories/")
+}
+
+func projectFiles(f *Fake, projectID int, endpoint string) ([]string, *awx.Response, error) {
+	if err := checkID("projectID", projectID); err != nil {
+		return nil, nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, resp, err := f.lookup(http.MethodGet, "projects", projectID); err != nil {
+		return nil, resp, err
+	}
+
+	return []string{}, response(http.MethodGet, path("projects", projectID)+endpoint, http.StatusOK), nil
+}
+
+// objectPath returns the collection and ID of the object at p, which may be
+// relative to the API root or absolute.
+func objectPath(p string) (string, int, bool) {
+	p, _, _ = strings.Cut(p, "?")
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	if len(segments) < 2 {
+		return "", 0, false
+	}
+	collection := segments[len(segments)-2]
+	if _, ok := types[collection]; !ok {
+		return "", 0, false
+	}
+	id, err := strconv.Atoi(segments[len(segments)-1])
+	if err != nil {
+		return "", 0, false
+	}
+	return collection, id, true
+}
+
+// rawObject returns the object at p, or the response and error AWX would
+// answer a request for it with. It must be called with f.mu held.
+func rawObject(f *Fake, method, p string) (string, object, *awx.Response, error) {
+	collection, id, ok := objectPath(p)
+	if !ok {
+		resp, err := notFound(method, strings.TrimPrefix(p, "/"))
+		return "", nil, resp, err
+	}
+	obj, resp, err := f.lookup(method, collection, id)
+	return collection, obj, resp, err
+}
+
+// get returns the object at p, if it is one of the objects held.
+func (s *RawService) get(ctx context.Context, p string) (map[string]interface{}, *awx.Response, error) {
+	if strings.TrimSpace(p) == "" {
+		return nil, nil, awx.NewArgError("path", "cannot be empty")
+	}
+
+	s.fake.mu.Lock()
+	defer s.fake.mu.Unlock()
+
+	collection, obj, resp, err := rawObject(s.fake, http.MethodGet, p)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var fields map[string]interface{}
+	if err := decode(obj, &fields); err != nil {
+		return nil, nil, err
+	}
+	return fields, response(http.MethodGet, path(collection, obj.id()), http.StatusOK), nil
+}
+
+// patch sets the given fields of the object at p, whether or not the typed
+// resources have a field for them.
+func (s *RawService) patch(ctx context.Context, p string, fields map[string]interface{}) (map[string]interface{}, *awx.Response, error) {
+	if strings.TrimSpace(p) == "" {
+		return nil, nil, awx.NewArgError("path", "cannot be empty")
+	}
+	if len(fields) == 0 {
+		return nil, nil, awx.NewArgError("fields", "cannot be empty")
+	}
+	values, err := encode(fields)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.fake.mu.Lock()
+	defer s.fake.mu.Unlock()
+
+	collection, obj, resp, err := rawObject(s.fake, http.MethodPatch, p)
+	if err != nil {
+		return nil, resp, err
+	}
+	s.fake.patch(obj, values)
+
+	var changed map[string]interface{}
+	if err := decode(obj, &changed); err != nil {
+		return nil, nil, err
+	}
+	return changed, response(http.MethodPatch, path(collection, obj.id()), http.StatusOK), nil
+}
+
+// unified returns the objects of several collections in order of ID, or in
+// reverse if orderBy starts with "-". It must be called with f.mu held.
+func (s *state) unified(collections []string, orderBy string) []object {
+	var objs []object
+	for _, collection := range collections {
+		objs = append(objs, s.sorted(collection)...)
+	}
+	sort.SliceStable(objs, func(i, j int) bool { return objs[i].id() < objs[j].id() })
+	if strings.HasPrefix(orderBy, "-") {
+		sort.SliceStable(objs, func(i, j int) bool { return objs[i].id() > objs[j].id() })
+	}
+	return objs
+}
+
+// list lists the jobs of every kind, filtered by opts.
+func (s *UnifiedJobService) list(ctx context.Context, opts *awx.UnifiedJobListOptions) ([]awx.UnifiedJob, *awx.Response, error) {
+	if opts == nil {
+		opts = &awx.UnifiedJobListOptions{}
+	}
+
+	s.fake.mu.Lock()
+	defer s.fake.mu.Unlock()
+
+	jobs := []awx.UnifiedJob{}
+	for _, obj := range s.fake.unified(unifiedJobs, opts.OrderBy) {
+		status, _ := obj["status"].(string)
+		created, _ := time.Parse(time.RFC3339Nano, fmt.Sprint(obj["created"]))
+		createdBy, _ := obj["summary_fields"].(map[string]interface{})["created_by"].(map[string]interface{})
+
+		switch {
+		case len(opts.Status) > 0 && !contains(opts.Status, status),
+			opts.LaunchType != "" && obj["launch_type"] != opts.LaunchType,
+			!opts.CreatedAfter.IsZero() && created.Before(opts.CreatedAfter),
+			!opts.CreatedBefore.IsZero() && !created.Before(opts.CreatedBefore),
+			opts.CreatedBy != "" && createdBy["username"] != opts.CreatedBy:
+			continue
+		}
+
+		var job awx.UnifiedJob
+		switch obj["type"] {
+		case "job":
+			job = new(awx.Job)
+		case "project_update":
+			job = new(awx.ProjectUpdate)
+		case "inventory_update":
+			job = new(awx.InventoryUpdate)
+		case "workflow_job":
+			job = new(awx.WorkflowJob)
+		case "ad_hoc_command":
+			job = new(awx.AdHocCommand)
+		case "system_job":
+			job = new(awx.SystemJob)
+		}
+		if err := decode(obj, job); err != nil {
+			return nil, nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, response(http.MethodGet, "unified_jobs/", http.StatusOK), nil
+}
+
+// list lists the templates of every kind, filtered by opts.
+func (s *UnifiedJobTemplateService) list(ctx context.Context, opts *awx.UnifiedJobTemplateListOptions) ([]awx.UnifiedJobTemplate, *awx.Response, error) {
+	if opts == nil {
+		opts = &awx.UnifiedJobTemplateListOptions{}
+	}
+
+	s.fake.mu.Lock()
+	defer s.fake.mu.Unlock()
+
+	templates := []awx.UnifiedJobTemplate{}
+	for _, obj := range s.fake.unified(unifiedJobTemplates, opts.OrderBy) {
+		typ, _ := obj["type"].(string)
+		status, _ := obj["status"].(string)
+		if len(opts.Type) > 0 && !contains(opts.Type, typ) || len(opts.Status) > 0 && !contains(opts.Status, status) {
+			continue
+		}
+
+		var tmpl awx.UnifiedJobTemplate
+		switch typ {
+		case "job_template":
+			tmpl = new(awx.JobTemplate)
+		case "project":
+			tmpl = new(awx.Project)
+		case "inventory_source":
+			tmpl = new(awx.InventorySource)
+		case "workflow_job_template":
+			tmpl = new(awx.WorkflowJobTemplate)
+		case "system_job_template":
+			tmpl = new(awx.SystemJobTemplate)
+		}
+		if err := decode(obj, tmpl); err != nil {
+			return nil, nil, err
+		}
+		templates = append(templates, tmpl)
+	}
+
+	return templates, response(http.MethodGet, "unified_job_templates/", http.StatusOK), nil
+}
+
+func (s *WorkflowJobTemplateService) getSurveySpec(ctx context.Context, workflowJobTemplateID int) (*awx.SurveySpec, *awx.Response, error) {
+	return getSurveySpec(s.fake, "workflow_job_templates", "workflowJobTemplateID", workflowJobTemplateID)
+}
+
+func (s *WorkflowJobTemplateService) setSurveySpec(ctx context.Context, workflowJobTemplateID int, spec *awx.SurveySpec) (*awx.Response, error) {
+	return setSurveySpec(s.fake, "workflow_job_templates", "workflowJobTemplateID", workflowJobTemplateID, spec)
+}
+
+func (s *WorkflowJobTemplateService) deleteSurveySpec(ctx context.Context, workflowJobTemplateID int) (*awx.Response, error) {
+	return deleteSurveySpec(s.fake, "workflow_job_templates", "workflowJobTemplateID", workflowJobTemplateID)
+}
+
+func (s *WorkflowJobTemplateService) listLabels(ctx context.Context, workflowJobTemplateID int) ([]awx.Label, *awx.Response, error) {
+	return listRelated[awx.Label](s.fake, "workflow_job_templates", "workflowJobTemplateID", workflowJobTemplateID, "labels")
+}
+
+func (s *WorkflowJobTemplateService) associateLabel(ctx context.Context, workflowJobTemplateID, labelID int) (*awx.Response, error) {
+	return associate(s.fake, "workflow_job_templates", "workflowJobTemplateID", workflowJobTemplateID, "labels", "labelID", labelID)
+}
+
+func (s *WorkflowJobTemplateService) disassociateLabel(ctx context.Context, workflowJobTemplateID, labelID int) (*awx.Response, error) {
+	return disassociate(s.fake, "workflow_job_templates", "workflowJobTemplateID", workflowJobTemplateID, "labels", "labelID", labelID)
+}
@@ -0,0 +1,25 @@
+// Package awxfake provides in-memory fakes of the services of awx.Client for
+// unit tests of code that uses the client.
+//
+// Fake holds a fake of every service. Each fake method records its call and
+// then calls the function in the matching Func field of the service, e.g.
+// JobTemplate.CreateFunc, when it is set. Otherwise it falls back to an
+// in-memory default: objects created through the fakes or added with Add can
+// be listed, fetched, updated, deleted and associated, jobs can be synced,
+// canceled and relaunched, and every change is recorded in the activity
+// stream.
+//
+//	fake := awxfake.New()
+//	fake.JobTemplate.GetFunc = func(ctx context.Context, id int) (*awx.JobTemplate, *awx.Response, error) {
+//		return &awx.JobTemplate{ID: id, Name: "deploy"}, nil, nil
+//	}
+//
+//	err := Deploy(ctx, fake.Client())
+//
+//	fake.AssertCalled(t, "JobTemplate.Get", 42)
+//
+// The fake services are generated from the service interfaces of package
+// awx; run go generate after changing them.
+package awxfake
+
+//go:generate go run ./internal/gen
@@ -0,0 +1,201 @@
+package awxfake
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/sparkacus/awx-go-client/awx"
+)
+
+// Call is a call made to a fake service.
+type Call struct {
+	// Method is the service and method called, e.g. "JobTemplate.Create".
+	Method string
+	// Args are the arguments of the call after the context.
+	Args []interface{}
+}
+
+func (c Call) String() string {
+	args := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		args[i] = format(arg)
+	}
+	return fmt.Sprintf("%s(%s)", c.Method, strings.Join(args, ", "))
+}
+
+func format(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		return fmt.Sprintf("&%+v", rv.Elem().Interface())
+	}
+	return fmt.Sprintf("%#v", v)
+}
+
+func (s *state) record(method string, args ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, Call{Method: method, Args: args})
+}
+
+// Client returns a awx.Client whose services are the fakes of f. Requests
+// sent with its NewRequest and Do methods are not faked.
+func (f *Fake) Client() *awx.Client {
+	c := awx.NewClient(nil)
+	f.install(c)
+	return c
+}
+
+// Add adds an object to a collection such as "job_templates" or "jobs", as
+// if it had been created in AWX, and returns its ID. The object is encoded to
+// JSON first, so it may be a resource such as a awx.JobTemplate or a map of
+// its fields. It keeps its ID if it has one. Objects added are not recorded
+// in the activity stream.
+func (f *Fake) Add(collection string, obj interface{}) (int, error) {
+	if _, ok := types[collection]; !ok {
+		return 0, fmt.Errorf("awxfake: unknown collection %q", collection)
+	}
+	values, err := encode(obj)
+	if err != nil {
+		return 0, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.insert(collection, values).id(), nil
+}
+
+// Associate adds the object memberID to the related collection name of an
+// object, e.g. a label to the "labels" of a job template, as if it had been
+// associated in AWX.
+func (f *Fake) Associate(collection string, id int, name string, memberID int) error {
+	if _, ok := types[name]; !ok {
+		return fmt.Errorf("awxfake: unknown collection %q", name)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, err := f.associate(collection, id, name, memberID)
+	return err
+}
+
+// Calls returns the calls made to the fake services, in order.
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]Call(nil), f.calls...)
+}
+
+// CallsTo returns the calls made to method, e.g. "JobTemplate.Create".
+func (f *Fake) CallsTo(method string) []Call {
+	var calls []Call
+	for _, call := range f.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// ResetCalls forgets the calls made so far. The objects held are kept.
+func (f *Fake) ResetCalls() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = nil
+}
+
+// TB is the part of testing.TB used by the assertion helpers.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+func (f *Fake) checkMethod(t TB, method string) bool {
+	t.Helper()
+	if !methods[method] {
+		t.Errorf("awxfake: unknown method %q, expected a service and method such as \"JobTemplate.Create\"", method)
+		return false
+	}
+	return true
+}
+
+func matches(call Call, args []interface{}) bool {
+	if len(args) == 0 {
+		return true
+	}
+	if len(args) != len(call.Args) {
+		return false
+	}
+	for i, arg := range args {
+		if !reflect.DeepEqual(arg, call.Args[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// AssertCalled reports an error on t unless method, e.g.
+// "JobTemplate.Create", was called with args, compared with
+// reflect.DeepEqual. With no args any call to method matches.
+func (f *Fake) AssertCalled(t TB, method string, args ...interface{}) bool {
+	t.Helper()
+	if !f.checkMethod(t, method) {
+		return false
+	}
+
+	calls := f.CallsTo(method)
+	for _, call := range calls {
+		if matches(call, args) {
+			return true
+		}
+	}
+
+	expected := Call{Method: method, Args: args}
+	if len(calls) == 0 {
+		t.Errorf("awxfake: expected a call to %s, got none", expected)
+		return false
+	}
+	made := make([]string, len(calls))
+	for i, call := range calls {
+		made[i] = "\t" + call.String()
+	}
+	t.Errorf("awxfake: expected a call to %s, got:\n%s", expected, strings.Join(made, "\n"))
+	return false
+}
+
+// AssertNotCalled reports an error on t if method was called with args, or
+// at all if no args are given.
+func (f *Fake) AssertNotCalled(t TB, method string, args ...interface{}) bool {
+	t.Helper()
+	if !f.checkMethod(t, method) {
+		return false
+	}
+
+	for _, call := range f.CallsTo(method) {
+		if matches(call, args) {
+			t.Errorf("awxfake: unexpected call to %s", call)
+			return false
+		}
+	}
+	return true
+}
+
+// AssertNumberOfCalls reports an error on t unless method was called n
+// times.
+func (f *Fake) AssertNumberOfCalls(t TB, method string, n int) bool {
+	t.Helper()
+	if !f.checkMethod(t, method) {
+		return false
+	}
+
+	if calls := f.CallsTo(method); len(calls) != n {
+		t.Errorf("awxfake: expected %d calls to %s, got %d", n, method, len(calls))
+		return false
+	}
+	return true
+}
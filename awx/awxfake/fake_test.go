@@ -0,0 +1,132 @@
+package awxfake_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sparkacus/awx-go-client/awx"
+	"github.com/sparkacus/awx-go-client/awx/awxfake"
+)
+
+func TestJobTemplateLifecycle(t *testing.T) {
+	fake := awxfake.New()
+	client := fake.Client()
+	ctx := context.Background()
+
+	created, _, err := client.JobTemplate.Create(ctx, &awx.JobTemplateCreateRequest{Name: "deploy", Playbook: "site.yml"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.ID == 0 || created.Name != "deploy" {
+		t.Fatalf("Create = %+v, want an ID and the name", created)
+	}
+
+	got, _, err := client.JobTemplate.Get(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Playbook != "site.yml" {
+		t.Errorf("Get: playbook %q, want site.yml", got.Playbook)
+	}
+
+	if _, err := client.JobTemplate.Update(ctx, &awx.JobTemplateCreateRequest{Name: "deploy", Playbook: "deploy.yml"}, created.ID); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	list, _, err := client.JobTemplate.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(list) != 1 || list[0].Playbook != "deploy.yml" {
+		t.Errorf("List = %+v, want the updated job template", list)
+	}
+
+	if _, err := client.JobTemplate.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	_, resp, err := client.JobTemplate.Get(ctx, created.ID)
+	var errResp *awx.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusNotFound {
+		t.Fatalf("Get after Delete: %v, want a 404 *awx.ErrorResponse", err)
+	}
+	if resp == nil || resp.Request.URL.Path != fmt.Sprintf("/api/v2/job_templates/%d/", created.ID) {
+		t.Errorf("Get after Delete: response %+v, want one for the job template", resp)
+	}
+
+	fake.AssertCalled(t, "JobTemplate.Get", created.ID)
+	fake.AssertNumberOfCalls(t, "JobTemplate.Get", 2)
+	fake.AssertNotCalled(t, "JobTemplate.Create", &awx.JobTemplateCreateRequest{Name: "other"})
+}
+
+func TestAddAndFunc(t *testing.T) {
+	fake := awxfake.New()
+	id, err := fake.Add("organizations", awx.Organization{ID: 7, Name: "Default"})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if id != 7 {
+		t.Errorf("Add = %d, want the ID of the object", id)
+	}
+
+	org, _, err := fake.Client().Organization.Get(context.Background(), 7)
+	if err != nil || org.Name != "Default" {
+		t.Fatalf("Get = %+v, %v, want the added organization", org, err)
+	}
+
+	fake.Organization.GetFunc = func(ctx context.Context, id int) (*awx.Organization, *awx.Response, error) {
+		return nil, nil, errors.New("unavailable")
+	}
+	if _, _, err := fake.Client().Organization.Get(context.Background(), 7); err == nil || err.Error() != "unavailable" {
+		t.Errorf("Get with GetFunc: %v, want the error of GetFunc", err)
+	}
+	fake.AssertNumberOfCalls(t, "Organization.Get", 2)
+}
+
+func TestArgErrors(t *testing.T) {
+	fake := awxfake.New()
+
+	_, _, err := fake.Client().JobTemplate.Get(context.Background(), 0)
+	var argErr *awx.ArgError
+	if !errors.As(err, &argErr) {
+		t.Errorf("Get(0): %v, want an *awx.ArgError", err)
+	}
+}
+
+// recorder is an awxfake.TB that keeps the errors reported to it.
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertions(t *testing.T) {
+	fake := awxfake.New()
+	fake.Client().Label.Get(context.Background(), 3)
+
+	tests := []struct {
+		name   string
+		assert func(awxfake.TB) bool
+		ok     bool
+	}{
+		{name: "called", assert: func(tb awxfake.TB) bool { return fake.AssertCalled(tb, "Label.Get", 3) }, ok: true},
+		{name: "called with other args", assert: func(tb awxfake.TB) bool { return fake.AssertCalled(tb, "Label.Get", 4) }},
+		{name: "not called", assert: func(tb awxfake.TB) bool { return fake.AssertNotCalled(tb, "Label.List") }, ok: true},
+		{name: "unknown method", assert: func(tb awxfake.TB) bool { return fake.AssertCalled(tb, "Label.Fetch") }},
+		{name: "number of calls", assert: func(tb awxfake.TB) bool { return fake.AssertNumberOfCalls(tb, "Label.Get", 2) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{}
+			if ok := tt.assert(r); ok != tt.ok || (len(r.errors) == 0) != tt.ok {
+				t.Errorf("assertion = %v with errors %q, want %v", ok, r.errors, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,393 @@
+// Command gen generates the fake services of package awxfake from the
+// service interfaces of package awx. Run it with go generate from the
+// awxfake directory.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// service is a service of awx.Client.
+type service struct {
+	// field is the name of the Client field, e.g. "JobTemplate".
+	field string
+	// collection is the collection of its base path, e.g. "job_templates".
+	collection string
+	methods    []method
+}
+
+type method struct {
+	name    string
+	params  []param
+	results []string
+}
+
+type param struct {
+	name string
+	typ  string
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gen: ")
+
+	dir := flag.String("dir", "..", "directory of package awx")
+	out := flag.String("out", "services.go", "output file")
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, *dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	pkg, ok := pkgs["awx"]
+	if !ok {
+		log.Fatalf("no package awx in %s", *dir)
+	}
+
+	services, err := parse(pkg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := format.Source(generate(services))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parse finds the services of awx.Client, their interfaces, the parameter
+// names of their implementations and their base paths.
+func parse(pkg *ast.Package) ([]service, error) {
+	types := map[string]ast.Expr{}
+	consts := map[string]string{}
+	params := map[string][]string{}
+
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						types[spec.Name.Name] = spec.Type
+					case *ast.ValueSpec:
+						for i, name := range spec.Names {
+							if i >= len(spec.Values) {
+								continue
+							}
+							if lit, ok := spec.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+								consts[name.Name], _ = strconv.Unquote(lit.Value)
+							}
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				if decl.Recv == nil || len(decl.Recv.List) != 1 {
+					continue
+				}
+				star, ok := decl.Recv.List[0].Type.(*ast.StarExpr)
+				if !ok {
+					continue
+				}
+				recv, ok := star.X.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				var names []string
+				for _, field := range decl.Type.Params.List {
+					for _, name := range field.Names {
+						names = append(names, name.Name)
+					}
+				}
+				params[recv.Name+"."+decl.Name.Name] = names
+			}
+		}
+	}
+
+	client, ok := types["Client"].(*ast.StructType)
+	if !ok {
+		return nil, fmt.Errorf("no Client struct")
+	}
+
+	var services []service
+	for _, field := range client.Fields.List {
+		ident, ok := field.Type.(*ast.Ident)
+		if !ok || !strings.HasSuffix(ident.Name, "Service") {
+			continue
+		}
+		iface, ok := types[ident.Name].(*ast.InterfaceType)
+		if !ok {
+			continue
+		}
+
+		for _, name := range field.Names {
+			s := service{field: name.Name}
+			if path, ok := consts[lowerFirst(name.Name)+"BasePath"]; ok {
+				s.collection = strings.TrimSuffix(path, "/")
+			}
+
+			for _, m := range iface.Methods.List {
+				fn, ok := m.Type.(*ast.FuncType)
+				if !ok || len(m.Names) != 1 {
+					return nil, fmt.Errorf("%s: embedded interfaces are not supported", ident.Name)
+				}
+				s.methods = append(s.methods, newMethod(m.Names[0].Name, fn, params[ident.Name+"Op."+m.Names[0].Name]))
+			}
+			services = append(services, s)
+		}
+	}
+
+	return services, nil
+}
+
+func newMethod(name string, fn *ast.FuncType, names []string) method {
+	m := method{name: name}
+
+	i := 0
+	for _, field := range fn.Params.List {
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		for j := 0; j < n; j++ {
+			p := param{typ: typeString(field.Type)}
+			switch {
+			case i < len(names):
+				p.name = names[i]
+			case p.typ == "context.Context":
+				p.name = "ctx"
+			default:
+				p.name = fmt.Sprintf("arg%d", i)
+			}
+			m.params = append(m.params, p)
+			i++
+		}
+	}
+
+	if fn.Results != nil {
+		for _, field := range fn.Results.List {
+			n := len(field.Names)
+			if n == 0 {
+				n = 1
+			}
+			for j := 0; j < n; j++ {
+				m.results = append(m.results, typeString(field.Type))
+			}
+		}
+	}
+
+	return m
+}
+
+// typeString formats a type of package awx as seen from another package.
+func typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(t.Name) {
+			return "awx." + t.Name
+		}
+		return t.Name
+	case *ast.SelectorExpr:
+		return t.X.(*ast.Ident).Name + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X)
+	case *ast.ArrayType:
+		if t.Len != nil {
+			log.Fatalf("unsupported array type")
+		}
+		return "[]" + typeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + typeString(t.Key) + "]" + typeString(t.Value)
+	case *ast.ChanType:
+		switch t.Dir {
+		case ast.RECV:
+			return "<-chan " + typeString(t.Value)
+		case ast.SEND:
+			return "chan<- " + typeString(t.Value)
+		}
+		return "chan " + typeString(t.Value)
+	case *ast.InterfaceType:
+		if len(t.Methods.List) > 0 {
+			log.Fatalf("unsupported interface type")
+		}
+		return "interface{}"
+	case *ast.Ellipsis:
+		return "..." + typeString(t.Elt)
+	}
+	log.Fatalf("unsupported type %T", expr)
+	return ""
+}
+
+func lowerFirst(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+// crud returns the call of the generic in-memory default of m, if m is one
+// of the List, Get, Create, Update and Delete methods of a collection.
+func crud(s service, m method) (string, bool) {
+	if s.collection == "" {
+		return "", false
+	}
+
+	shape := make([]string, len(m.params))
+	for i, p := range m.params {
+		switch {
+		case p.typ == "context.Context":
+			shape[i] = "ctx"
+		case p.typ == "int":
+			shape[i] = "id"
+		case strings.HasPrefix(p.typ, "*awx.") && strings.HasSuffix(p.typ, "Request"):
+			shape[i] = "req"
+		default:
+			shape[i] = p.typ
+		}
+	}
+	results := strings.Join(m.results, ", ")
+	sig := m.name + "(" + strings.Join(shape, ", ") + ")"
+	coll := strconv.Quote(s.collection)
+
+	switch {
+	case sig == "List(ctx)" && len(m.results) == 3 && strings.HasPrefix(results, "[]awx."):
+		return fmt.Sprintf("list[%s](s.fake, %s)", strings.TrimPrefix(m.results[0], "[]"), coll), true
+	case sig == "Get(ctx, id)" && len(m.results) == 3 && strings.HasPrefix(results, "*awx."):
+		return fmt.Sprintf("get[%s](s.fake, %s, %q, %s)", strings.TrimPrefix(m.results[0], "*"), coll, m.params[1].name, m.params[1].name), true
+	case sig == "Create(ctx, req)" && len(m.results) == 3 && strings.HasPrefix(results, "*awx."):
+		return fmt.Sprintf("create[%s](s.fake, %s, %q, %s)", strings.TrimPrefix(m.results[0], "*"), coll, m.params[1].name, m.params[1].name), true
+	case sig == "Update(ctx, req, id)" && results == "*awx.Response, error":
+		return fmt.Sprintf("update(s.fake, %s, %q, %s, %q, %s)", coll, m.params[1].name, m.params[1].name, m.params[2].name, m.params[2].name), true
+	case sig == "Delete(ctx, id)" && results == "*awx.Response, error":
+		return fmt.Sprintf("remove(s.fake, %s, %q, %s)", coll, m.params[1].name, m.params[1].name), true
+	}
+	return "", false
+}
+
+func generate(services []service) []byte {
+	var b bytes.Buffer
+	p := func(format string, args ...interface{}) { fmt.Fprintf(&b, format, args...) }
+
+	imports := map[string]bool{"github.com/sparkacus/awx-go-client/awx": true}
+	for _, s := range services {
+		for _, m := range s.methods {
+			for _, param := range m.params {
+				if strings.Contains(param.typ, "context.") {
+					imports["context"] = true
+				}
+			}
+		}
+	}
+	paths := make([]string, 0, len(imports))
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	p("// Code generated by awxfake/internal/gen. DO NOT EDIT.\n\n")
+	p("package awxfake\n\nimport (\n")
+	for i, path := range paths {
+		if i > 0 && strings.Contains(path, ".") && !strings.Contains(paths[i-1], ".") {
+			p("\n")
+		}
+		p("%q\n", path)
+	}
+	p(")\n\n")
+
+	p("// Fake holds a fake of every service of awx.Client. Create one with New.\n")
+	p("type Fake struct {\n")
+	for _, s := range services {
+		p("%s *%sService\n", s.field, s.field)
+	}
+	p("\n*state\n}\n\n")
+
+	p("// New returns a Fake holding no objects.\n")
+	p("func New() *Fake {\nf := &Fake{state: newState()}\n")
+	for _, s := range services {
+		p("f.%s = &%sService{fake: f}\n", s.field, s.field)
+	}
+	p("return f\n}\n\n")
+
+	p("// install replaces the services of c with the fakes of f.\n")
+	p("func (f *Fake) install(c *awx.Client) {\n")
+	for _, s := range services {
+		p("c.%s = f.%s\n", s.field, s.field)
+	}
+	p("}\n\n")
+
+	p("// methods are the methods that calls are recorded for.\n")
+	p("var methods = map[string]bool{\n")
+	for _, s := range services {
+		for _, m := range s.methods {
+			p("%q: true,\n", s.field+"."+m.name)
+		}
+	}
+	p("}\n")
+
+	for _, s := range services {
+		name := s.field + "Service"
+		p("\n// %s is a fake awx.%s.\n", name, name)
+		p("type %s struct {\n", name)
+		for _, m := range s.methods {
+			p("%sFunc func(%s) %s\n", m.name, paramList(m.params), resultList(m.results))
+		}
+		p("\nfake *Fake\n}\n\n")
+		p("var _ awx.%s = &%s{}\n", name, name)
+
+		for _, m := range s.methods {
+			var args []string
+			var recorded []string
+			for _, param := range m.params {
+				args = append(args, param.name)
+				if param.typ != "context.Context" {
+					recorded = append(recorded, param.name)
+				}
+			}
+
+			call, ok := crud(s, m)
+			if !ok {
+				call = fmt.Sprintf("s.%s(%s)", lowerFirst(m.name), strings.Join(args, ", "))
+			}
+
+			p("\n// %s records the call and calls %sFunc, or the in-memory default if it\n// is nil.\n", m.name, m.name)
+			p("func (s *%s) %s(%s) %s {\n", name, m.name, paramList(m.params), resultList(m.results))
+			p("s.fake.record(%s)\n", strings.Join(append([]string{strconv.Quote(s.field + "." + m.name)}, recorded...), ", "))
+			p("if s.%sFunc != nil {\nreturn s.%sFunc(%s)\n}\n", m.name, m.name, strings.Join(args, ", "))
+			p("return %s\n}\n", call)
+		}
+	}
+
+	return b.Bytes()
+}
+
+func paramList(params []param) string {
+	list := make([]string, len(params))
+	for i, p := range params {
+		list[i] = p.name + " " + p.typ
+	}
+	return strings.Join(list, ", ")
+}
+
+func resultList(results []string) string {
+	if len(results) == 1 {
+		return results[0]
+	}
+	return "(" + strings.Join(results, ", ") + ")"
+}
@@ -0,0 +1,1390 @@
+// Code generated by awxfake/internal/gen. DO NOT EDIT.
+
+package awxfake
+
+import (
+	"context"
+
+	"github.com/sparkacus/awx-go-client/awx"
+)
+
+// Fake holds a fake of every service of awx.Client. Create one with New.
+type Fake struct {
+	Inventory            *InventoryService
+	InventorySource      *InventorySourceService
+	InventoryUpdate      *InventoryUpdateService
+	Organization         *OrganizationService
+	Project              *ProjectService
+	JobTemplate          *JobTemplateService
+	WorkflowJobTemplate  *WorkflowJobTemplateService
+	AdHocCommand         *AdHocCommandService
+	ExecutionEnvironment *ExecutionEnvironmentService
+	InstanceGroup        *InstanceGroupService
+	Instance             *InstanceService
+	Label                *LabelService
+	UnifiedJob           *UnifiedJobService
+	UnifiedJobTemplate   *UnifiedJobTemplateService
+	ActivityStream       *ActivityStreamService
+	Ping                 *PingService
+	ServerConfig         *ServerConfigService
+	Metadata             *MetadataService
+	Raw                  *RawService
+
+	*state
+}
+
+// New returns a Fake holding no objects.
+func New() *Fake {
+	f := &Fake{state: newState()}
+	f.Inventory = &InventoryService{fake: f}
+	f.InventorySource = &InventorySourceService{fake: f}
+	f.InventoryUpdate = &InventoryUpdateService{fake: f}
+	f.Organization = &OrganizationService{fake: f}
+	f.Project = &ProjectService{fake: f}
+	f.JobTemplate = &JobTemplateService{fake: f}
+	f.WorkflowJobTemplate = &WorkflowJobTemplateService{fake: f}
+	f.AdHocCommand = &AdHocCommandService{fake: f}
+	f.ExecutionEnvironment = &ExecutionEnvironmentService{fake: f}
+	f.InstanceGroup = &InstanceGroupService{fake: f}
+	f.Instance = &InstanceService{fake: f}
+	f.Label = &LabelService{fake: f}
+	f.UnifiedJob = &UnifiedJobService{fake: f}
+	f.UnifiedJobTemplate = &UnifiedJobTemplateService{fake: f}
+	f.ActivityStream = &ActivityStreamService{fake: f}
+	f.Ping = &PingService{fake: f}
+	f.ServerConfig = &ServerConfigService{fake: f}
+	f.Metadata = &MetadataService{fake: f}
+	f.Raw = &RawService{fake: f}
+	return f
+}
+
+// install replaces the services of c with the fakes of f.
+func (f *Fake) install(c *awx.Client) {
+	c.Inventory = f.Inventory
+	c.InventorySource = f.InventorySource
+	c.InventoryUpdate = f.InventoryUpdate
+	c.Organization = f.Organization
+	c.Project = f.Project
+	c.JobTemplate = f.JobTemplate
+	c.WorkflowJobTemplate = f.WorkflowJobTemplate
+	c.AdHocCommand = f.AdHocCommand
+	c.ExecutionEnvironment = f.ExecutionEnvironment
+	c.InstanceGroup = f.InstanceGroup
+	c.Instance = f.Instance
+	c.Label = f.Label
+	c.UnifiedJob = f.UnifiedJob
+	c.UnifiedJobTemplate = f.UnifiedJobTemplate
+	c.ActivityStream = f.ActivityStream
+	c.Ping = f.Ping
+	c.ServerConfig = f.ServerConfig
+	c.Metadata = f.Metadata
+	c.Raw = f.Raw
+}
+
+// methods are the methods that calls are recorded for.
+var methods = map[string]bool{
+	"Inventory.List":                        true,
+	"Inventory.Get":                         true,
+	"Inventory.Create":                      true,
+	"Inventory.Update":                      true,
+	"Inventory.Delete":                      true,
+	"Inventory.SyncAllSources":              true,
+	"Inventory.ListInstanceGroups":          true,
+	"Inventory.SetInstanceGroups":           true,
+	"InventorySource.List":                  true,
+	"InventorySource.Get":                   true,
+	"InventorySource.Create":                true,
+	"InventorySource.Update":                true,
+	"InventorySource.Delete":                true,
+	"InventorySource.Sync":                  true,
+	"InventoryUpdate.List":                  true,
+	"InventoryUpdate.Get":                   true,
+	"InventoryUpdate.Cancel":                true,
+	"InventoryUpdate.Stdout":                true,
+	"Organization.List":                     true,
+	"Organization.Get":                      true,
+	"Organization.Create":                   true,
+	"Organization.Update":                   true,
+	"Organization.Delete":                   true,
+	"Organization.ListInstanceGroups":       true,
+	"Organization.SetInstanceGroups":        true,
+	"Project.List":                          true,
+	"Project.Get":                           true,
+	"Project.Create":                        true,
+	"Project.Update":                        true,
+	"Project.Delete":                        true,
+	"Project.Playbooks":                     true,
+	"Project.InventoryFiles":                true,
+	"JobTemplate.List":                      true,
+	"JobTemplate.Get":                       true,
+	"JobTemplate.Create":                    true,
+	"JobTemplate.Update":                    true,
+	"JobTemplate.Delete":                    true,
+	"JobTemplate.GetSurveySpec":             true,
+	"JobTemplate.SetSurveySpec":             true,
+	"JobTemplate.DeleteSurveySpec":          true,
+	"JobTemplate.ListInstanceGroups":        true,
+	"JobTemplate.SetInstanceGroups":         true,
+	"JobTemplate.ListLabels":                true,
+	"JobTemplate.AssociateLabel":            true,
+	"JobTemplate.DisassociateLabel":         true,
+	"WorkflowJobTemplate.List":              true,
+	"WorkflowJobTemplate.Get":               true,
+	"WorkflowJobTemplate.Create":            true,
+	"WorkflowJobTemplate.Update":            true,
+	"WorkflowJobTemplate.Delete":            true,
+	"WorkflowJobTemplate.GetSurveySpec":     true,
+	"WorkflowJobTemplate.SetSurveySpec":     true,
+	"WorkflowJobTemplate.DeleteSurveySpec":  true,
+	"WorkflowJobTemplate.ListLabels":        true,
+	"WorkflowJobTemplate.AssociateLabel":    true,
+	"WorkflowJobTemplate.DisassociateLabel": true,
+	"AdHocCommand.List":                     true,
+	"AdHocCommand.Get":                      true,
+	"AdHocCommand.Create":                   true,
+	"AdHocCommand.Delete":                   true,
+	"AdHocCommand.Cancel":                   true,
+	"AdHocCommand.Relaunch":                 true,
+	"AdHocCommand.Events":                   true,
+	"AdHocCommand.Stdout":                   true,
+	"ExecutionEnvironment.List":             true,
+	"ExecutionEnvironment.Get":              true,
+	"ExecutionEnvironment.Create":           true,
+	"ExecutionEnvironment.Update":           true,
+	"ExecutionEnvironment.Delete":           true,
+	"InstanceGroup.List":                    true,
+	"InstanceGroup.Get":                     true,
+	"InstanceGroup.Create":                  true,
+	"InstanceGroup.Update":                  true,
+	"InstanceGroup.Delete":                  true,
+	"InstanceGroup.ListInstances":           true,
+	"InstanceGroup.AssociateInstance":       true,
+	"InstanceGroup.DisassociateInstance":    true,
+	"Instance.List":                         true,
+	"Instance.Get":                          true,
+	"Instance.Update":                       true,
+	"Label.List":                            true,
+	"Label.Get":                             true,
+	"Label.Create":                          true,
+	"Label.Update":                          true,
+	"Label.FindTemplates":                   true,
+	"UnifiedJob.List":                       true,
+	"UnifiedJobTemplate.List":               true,
+	"ActivityStream.List":                   true,
+	"ActivityStream.Watch":                  true,
+	"Ping.Get":                              true,
+	"ServerConfig.Get":                      true,
+	"Metadata.Get":                          true,
+	"Metadata.Choices":                      true,
+	"Metadata.Validate":                     true,
+	"Raw.Get":                               true,
+	"Raw.Patch":                             true,
+}
+
+// InventoryService is a fake awx.InventoryService.
+type InventoryService struct {
+	ListFunc               func(ctx context.Context) ([]awx.Inventory, *awx.Response, error)
+	GetFunc                func(ctx context.Context, inventoryID int) (*awx.Inventory, *awx.Response, error)
+	CreateFunc             func(ctx context.Context, createRequest *awx.InventoryCreateRequest) (*awx.Inventory, *awx.Response, error)
+	UpdateFunc             func(ctx context.Context, createRequest *awx.InventoryCreateRequest, inventoryID int) (*awx.Response, error)
+	DeleteFunc             func(ctx context.Context, inventoryID int) (*awx.Response, error)
+	SyncAllSourcesFunc     func(ctx context.Context, inventoryID int) ([]awx.InventorySourceSync, *awx.Response, error)
+	ListInstanceGroupsFunc func(ctx context.Context, inventoryID int) ([]awx.InstanceGroup, *awx.Response, error)
+	SetInstanceGroupsFunc  func(ctx context.Context, inventoryID int, instanceGroupIDs []int) (*awx.Response, error)
+
+	fake *Fake
+}
+
+var _ awx.InventoryService = &InventoryService{}
+
+// List records the call and calls ListFunc, or the in-memory default if it
+// is nil.
+func (s *InventoryService) List(ctx context.Context) ([]awx.Inventory, *awx.Response, error) {
+	s.fake.record("Inventory.List")
+	if s.ListFunc != nil {
+		return s.ListFunc(ctx)
+	}
+	return list[awx.Inventory](s.fake, "inventories")
+}
+
+// Get records the call and calls GetFunc, or the in-memory default if it
+// is nil.
+func (s *InventoryService) Get(ctx context.Context, inventoryID int) (*awx.Inventory, *awx.Response, error) {
+	s.fake.record("Inventory.Get", inventoryID)
+	if s.GetFunc != nil {
+		return s.GetFunc(ctx, inventoryID)
+	}
+	return get[awx.Inventory](s.fake, "inventories", "inventoryID", inventoryID)
+}
+
+// Create records the call and calls CreateFunc, or the in-memory default if it
+// is nil.
+func (s *InventoryService) Create(ctx context.Context, createRequest *awx.InventoryCreateRequest) (*awx.Inventory, *awx.Response, error) {
+	s.fake.record("Inventory.Create", createRequest)
+	if s.CreateFunc != nil {
+		return s.CreateFunc(ctx, createRequest)
+	}
+	return create[awx.Inventory](s.fake, "inventories", "createRequest", createRequest)
+}
+
+// Update records the call and calls UpdateFunc, or the in-memory default if it
+// is nil.
+func (s *InventoryService) Update(ctx context.Context, createRequest *awx.InventoryCreateRequest, inventoryID int) (*awx.Response, error) {
+	s.fake.record("Inventory.Update", createRequest, inventoryID)
+	if s.UpdateFunc != nil {
+		return s.UpdateFunc(ctx, createRequest, inventoryID)
+	}
+	return update(s.fake, "inventories", "createRequest", createRequest, "inventoryID", inventoryID)
+}
+
+// Delete records the call and calls DeleteFunc, or the in-memory default if it
+// is nil.
+func (s *InventoryService) Delete(ctx context.Context, inventoryID int) (*awx.Response, error) {
+	s.fake.record("Inventory.Delete", inventoryID)
+	if s.DeleteFunc != nil {
+		return s.DeleteFunc(ctx, inventoryID)
+	}
+	return remove(s.fake, "inventories", "inventoryID", inventoryID)
+}
+
+// SyncAllSources records the call and calls SyncAllSourcesFunc, or the in-memory default if it
+// is nil.
+func (s *InventoryService) SyncAllSources(ctx context.Context, inventoryID int) ([]awx.InventorySourceSync, *awx.Response, error) {
+	s.fake.record("Inventory.SyncAllSources", inventoryID)
+	if s.SyncAllSourcesFunc != nil {
+		return s.SyncAllSourcesFunc(ctx, inventoryID)
+	}
+	return s.syncAllSources(ctx, inventoryID)
+}
+
+// ListInstanceGroups records the call and calls ListInstanceGroupsFunc, or the in-memory default if it
+// is nil.
+func (s *InventoryService) ListInstanceGroups(ctx context.Context, inventoryID int) ([]awx.InstanceGroup, *awx.Response, error) {
+	s.fake.record("Inventory.ListInstanceGroups", inventoryID)
+	if s.ListInstanceGroupsFunc != nil {
+		return s.ListInstanceGroupsFunc(ctx, inventoryID)
+	}
+	return s.listInstanceGroups(ctx, inventoryID)
+}
+
+// SetInstanceGroups records the call and calls SetInstanceGroupsFunc, or the in-memory default if it
+// is nil.
+func (s *InventoryService) SetInstanceGroups(ctx context.Context, inventoryID int, instanceGroupIDs []int) (*awx.Response, error) {
+	s.fake.record("Inventory.SetInstanceGroups", inventoryID, instanceGroupIDs)
+	if s.SetInstanceGroupsFunc != nil {
+		return s.SetInstanceGroupsFunc(ctx, inventoryID, instanceGroupIDs)
+	}
+	return s.setInstanceGroups(ctx, inventoryID, instanceGroupIDs)
+}
+
+// InventorySourceService is a fake awx.InventorySourceService.
+type InventorySourceService struct {
+	ListFunc   func(ctx context.Context) ([]awx.InventorySource, *awx.Response, error)
+	GetFunc    func(ctx context.Context, inventorySourceID int) (*awx.InventorySource, *awx.Response, error)
+	CreateFunc func(ctx context.Context, createRequest *awx.InventorySourceCreateRequest) (*awx.InventorySource, *awx.Response, error)
+	UpdateFunc func(ctx context.Context, createRequest *awx.InventorySourceCreateRequest, inventorySourceID int) (*awx.Response, error)
+	DeleteFunc func(ctx context.Context, inventorySourceID int) (*awx.Response, error)
+	SyncFunc   func(ctx context.Context, inventorySourceID int) (*awx.InventoryUpdate, *awx.Response, error)
+
+	fake *Fake
+}
+
+var _ awx.InventorySourceService = &InventorySourceService{}
+
+// List records the call and calls ListFunc, or the in-memory default if it
+// is nil.
+func (s *InventorySourceService) List(ctx context.Context) ([]awx.InventorySource, *awx.Response, error) {
+	s.fake.record("InventorySource.List")
+	if s.ListFunc != nil {
+		return s.ListFunc(ctx)
+	}
+	return list[awx.InventorySource](s.fake, "inventory_sources")
+}
+
+// Get records the call and calls GetFunc, or the in-memory default if it
+// is nil.
+func (s *InventorySourceService) Get(ctx context.Context, inventorySourceID int) (*awx.InventorySource, *awx.Response, error) {
+	s.fake.record("InventorySource.Get", inventorySourceID)
+	if s.GetFunc != nil {
+		return s.GetFunc(ctx, inventorySourceID)
+	}
+	return get[awx.InventorySource](s.fake, "inventory_sources", "inventorySourceID", inventorySourceID)
+}
+
+// Create records the call and calls CreateFunc, or the in-memory default if it
+// is nil.
+func (s *InventorySourceService) Create(ctx context.Context, createRequest *awx.InventorySourceCreateRequest) (*awx.InventorySource, *awx.Response, error) {
+	s.fake.record("InventorySource.Create", createRequest)
+	if s.CreateFunc != nil {
+		return s.CreateFunc(ctx, createRequest)
+	}
+	return create[awx.InventorySource](s.fake, "inventory_sources", "createRequest", createRequest)
+}
+
+// Update records the call and calls UpdateFunc, or the in-memory default if it
+// is nil.
+func (s *InventorySourceService) Update(ctx context.Context, createRequest *awx.InventorySourceCreateRequest, inventorySourceID int) (*awx.Response, error) {
+	s.fake.record("InventorySource.Update", createRequest, inventorySourceID)
+	if s.UpdateFunc != nil {
+		return s.UpdateFunc(ctx, createRequest, inventorySourceID)
+	}
+	return update(s.fake, "inventory_sources", "createRequest", createRequest, "inventorySourceID", inventorySourceID)
+}
+
+// Delete records the call and calls DeleteFunc, or the in-memory default if it
+// is nil.
+func (s *InventorySourceService) Delete(ctx context.Context, inventorySourceID int) (*awx.Response, error) {
+	s.fake.record("InventorySource.Delete", inventorySourceID)
+	if s.DeleteFunc != nil {
+		return s.DeleteFunc(ctx, inventorySourceID)
+	}
+	return remove(s.fake, "inventory_sources", "inventorySourceID", inventorySourceID)
+}
+
+// Sync records the call and calls SyncFunc, or the in-memory default if it
+// is nil.
+func (s *InventorySourceService) Sync(ctx context.Context, inventorySourceID int) (*awx.InventoryUpdate, *awx.Response, error) {
+	s.fake.record("InventorySource.Sync", inventorySourceID)
+	if s.SyncFunc != nil {
+		return s.SyncFunc(ctx, inventorySourceID)
+	}
+	return s.sync(ctx, inventorySourceID)
+}
+
+// InventoryUpdateService is a fake awx.InventoryUpdateService.
+type InventoryUpdateService struct {
+	ListFunc   func(ctx context.Context) ([]awx.InventoryUpdate, *awx.Response, error)
+	GetFunc    func(ctx context.Context, inventoryUpdateID int) (*awx.InventoryUpdate, *awx.Response, error)
+	CancelFunc func(ctx context.Context, inventoryUpdateID int) (*awx.Response, error)
+	StdoutFunc func(ctx context.Context, inventoryUpdateID int) (string, *awx.Response, error)
+
+	fake *Fake
+}
+
+var _ awx.InventoryUpdateService = &InventoryUpdateService{}
+
+// List records the call and calls ListFunc, or the in-memory default if it
+// is nil.
+func (s *InventoryUpdateService) List(ctx context.Context) ([]awx.InventoryUpdate, *awx.Response, error) {
+	s.fake.record("InventoryUpdate.List")
+	if s.ListFunc != nil {
+		return s.ListFunc(ctx)
+	}
+	return list[awx.InventoryUpdate](s.fake, "inventory_updates")
+}
+
+// Get records the call and calls GetFunc, or the in-memory default if it
+// is nil.
+func (s *InventoryUpdateService) Get(ctx context.Context, inventoryUpdateID int) (*awx.InventoryUpdate, *awx.Response, error) {
+	s.fake.record("InventoryUpdate.Get", inventoryUpdateID)
+	if s.GetFunc != nil {
+		return s.GetFunc(ctx, inventoryUpdateID)
+	}
+	return get[awx.InventoryUpdate](s.fake, "inventory_updates", "inventoryUpdateID", inventoryUpdateID)
+}
+
+// Cancel records the call and calls CancelFunc, or the in-memory default if it
+// is nil.
+func (s *InventoryUpdateService) Cancel(ctx context.Context, inventoryUpdateID int) (*awx.Response, error) {
+	s.fake.record("InventoryUpdate.Cancel", inventoryUpdateID)
+	if s.CancelFunc != nil {
+		return s.CancelFunc(ctx, inventoryUpdateID)
+	}
+	return s.cancel(ctx, inventoryUpdateID)
+}
+
+// Stdout records the call and calls StdoutFunc, or the in-memory default if it
+// is nil.
+func (s *InventoryUpdateService) Stdout(ctx context.Context, inventoryUpdateID int) (string, *awx.Response, error) {
+	s.fake.record("InventoryUpdate.Stdout", inventoryUpdateID)
+	if s.StdoutFunc != nil {
+		return s.StdoutFunc(ctx, inventoryUpdateID)
+	}
+	return s.stdout(ctx, inventoryUpdateID)
+}
+
+// OrganizationService is a fake awx.OrganizationService.
+type OrganizationService struct {
+	ListFunc               func(ctx context.Context) ([]awx.Organization, *awx.Response, error)
+	GetFunc                func(ctx context.Context, organizationID int) (*awx.Organization, *awx.Response, error)
+	CreateFunc             func(ctx context.Context, createRequest *awx.OrganizationCreateRequest) (*awx.Organization, *awx.Response, error)
+	UpdateFunc             func(ctx context.Context, createRequest *awx.OrganizationCreateRequest, organizationID int) (*awx.Response, error)
+	DeleteFunc             func(ctx context.Context, organizationID int) (*awx.Response, error)
+	ListInstanceGroupsFunc func(ctx context.Context, organizationID int) ([]awx.InstanceGroup, *awx.Response, error)
+	SetInstanceGroupsFunc  func(ctx context.Context, organizationID int, instanceGroupIDs []int) (*awx.Response, error)
+
+	fake *Fake
+}
+
+var _ awx.OrganizationService = &OrganizationService{}
+
+// List records the call and calls ListFunc, or the in-memory default if it
+// is nil.
+func (s *OrganizationService) List(ctx context.Context) ([]awx.Organization, *awx.Response, error) {
+	s.fake.record("Organization.List")
+	if s.ListFunc != nil {
+		return s.ListFunc(ctx)
+	}
+	return list[awx.Organization](s.fake, "organizations")
+}
+
+// Get records the call and calls GetFunc, or the in-memory default if it
+// is nil.
+func (s *OrganizationService) Get(ctx context.Context, organizationID int) (*awx.Organization, *awx.Response, error) {
+	s.fake.record("Organization.Get", organizationID)
+	if s.GetFunc != nil {
+		return s.GetFunc(ctx, organizationID)
+	}
+	return get[awx.Organization](s.fake, "organizations", "organizationID", organizationID)
+}
+
+// Create records the call and calls CreateFunc, or the in-memory default if it
+// is nil.
+func (s *OrganizationService) Create(ctx context.Context, createRequest *awx.OrganizationCreateRequest) (*awx.Organization, *awx.Response, error) {
+	s.fake.record("Organization.Create", createRequest)
+	if s.CreateFunc != nil {
+		return s.CreateFunc(ctx, createRequest)
+	}
+	return create[awx.Organization](s.fake, "organizations", "createRequest", createRequest)
+}
+
+// Update records the call and calls UpdateFunc, or the in-memory default if it
+// is nil.
+func (s *OrganizationService) Update(ctx context.Context, createRequest *awx.OrganizationCreateRequest, organizationID int) (*awx.Response, error) {
+	s.fake.record("Organization.Update", createRequest, organizationID)
+	if s.UpdateFunc != nil {
+		return s.UpdateFunc(ctx, createRequest, organizationID)
+	}
+	return update(s.fake, "organizations", "createRequest", createRequest, "organizationID", organizationID)
+}
+
+// Delete records the call and calls DeleteFunc, or the in-memory default if it
+// is nil.
+func (s *OrganizationService) Delete(ctx context.Context, organizationID int) (*awx.Response, error) {
+	s.fake.record("Organization.Delete", organizationID)
+	if s.DeleteFunc != nil {
+		return s.DeleteFunc(ctx, organizationID)
+	}
+	return remove(s.fake, "organizations", "organizationID", organizationID)
+}
+
+// ListInstanceGroups records the call and calls ListInstanceGroupsFunc, or the in-memory default if it
+// is nil.
+func (s *OrganizationService) ListInstanceGroups(ctx context.Context, organizationID int) ([]awx.InstanceGroup, *awx.Response, error) {
+	s.fake.record("Organization.ListInstanceGroups", organizationID)
+	if s.ListInstanceGroupsFunc != nil {
+		return s.ListInstanceGroupsFunc(ctx, organizationID)
+	}
+	return s.listInstanceGroups(ctx, organizationID)
+}
+
+// SetInstanceGroups records the call and calls SetInstanceGroupsFunc, or the in-memory default if it
+// is nil.
+func (s *OrganizationService) SetInstanceGroups(ctx context.Context, organizationID int, instanceGroupIDs []int) (*awx.Response, error) {
+	s.fake.record("Organization.SetInstanceGroups", organizationID, instanceGroupIDs)
+	if s.SetInstanceGroupsFunc != nil {
+		return s.SetInstanceGroupsFunc(ctx, organizationID, instanceGroupIDs)
+	}
+	return s.setInstanceGroups(ctx, organizationID, instanceGroupIDs)
+}
+
+// ProjectService is a fake awx.ProjectService.
+type ProjectService struct {
+	ListFunc           func(ctx context.Context) ([]awx.Project, *awx.Response, error)
+	GetFunc            func(ctx context.Context, projectID int) (*awx.Project, *awx.Response, error)
+	CreateFunc         func(ctx context.Context, createRequest *awx.ProjectCreateRequest) (*awx.Project, *awx.Response, error)
+	UpdateFunc         func(ctx context.Context, createRequest *awx.ProjectCreateRequest, projectID int) (*awx.Response, error)
+	DeleteFunc         func(ctx context.Context, projectID int) (*awx.Response, error)
+	PlaybooksFunc      func(ctx context.Context, projectID int) ([]string, *awx.Response, error)
+	InventoryFilesFunc func(ctx context.Context, projectID int) ([]string, *awx.Response, error)
+
+	fake *Fake
+}
+
+var _ awx.ProjectService = &ProjectService{}
+
+// List records the call and calls ListFunc, or the in-memory default if it
+// is nil.
+func (s *ProjectService) List(ctx context.Context) ([]awx.Project, *awx.Response, error) {
+	s.fake.record("Project.List")
+	if s.ListFunc != nil {
+		return s.ListFunc(ctx)
+	}
+	return list[awx.Project](s.fake, "projects")
+}
+
+// Get records the call and calls GetFunc, or the in-memory default if it
+// is nil.
+func (s *ProjectService) Get(ctx context.Context, projectID int) (*awx.Project, *awx.Response, error) {
+	s.fake.record("Project.Get", projectID)
+	if s.GetFunc != nil {
+		return s.GetFunc(ctx, projectID)
+	}
+	return get[awx.Project](s.fake, "projects", "projectID", projectID)
+}
+
+// Create records the call and calls CreateFunc, or the in-memory default if it
+// is nil.
+func (s *ProjectService) Create(ctx context.Context, createRequest *awx.ProjectCreateRequest) (*awx.Project, *awx.Response, error) {
+	s.fake.record("Project.Create", createRequest)
+	if s.CreateFunc != nil {
+		return s.CreateFunc(ctx, createRequest)
+	}
+	return create[awx.Project](s.fake, "projects", "createRequest", createRequest)
+}
+
+// Update records the call and calls UpdateFunc, or the in-memory default if it
+// is nil.
+func (s *ProjectService) Update(ctx context.Context, createRequest *awx.ProjectCreateRequest, projectID int) (*awx.Response, error) {
+	s.fake.record("Project.Update", createRequest, projectID)
+	if s.UpdateFunc != nil {
+		return s.UpdateFunc(ctx, createRequest, projectID)
+	}
+	return update(s.fake, "projects", "createRequest", createRequest, "projectID", projectID)
+}
+
+// Delete records the call and calls DeleteFunc, or the in-memory default if it
+// is nil.
+func (s *ProjectService) Delete(ctx context.Context, projectID int) (*awx.Response, error) {
+	s.fake.record("Project.Delete", projectID)
+	if s.DeleteFunc != nil {
+		return s.DeleteFunc(ctx, projectID)
+	}
+	return remove(s.fake, "projects", "projectID", projectID)
+}
+
+// Playbooks records the call and calls PlaybooksFunc, or the in-memory default if it
+// is nil.
+func (s *ProjectService) Playbooks(ctx context.Context, projectID int) ([]string, *awx.Response, error) {
+	s.fake.record("Project.Playbooks", projectID)
+	if s.PlaybooksFunc != nil {
+		return s.PlaybooksFunc(ctx, projectID)
+	}
+	return s.playbooks(ctx, projectID)
+}
+
+// InventoryFiles records the call and calls InventoryFilesFunc, or the in-memory default if it
+// is nil.
+func (s *ProjectService) InventoryFiles(ctx context.Context, projectID int) ([]string, *awx.Response, error) {
+	s.fake.record("Project.InventoryFiles", projectID)
+	if s.InventoryFilesFunc != nil {
+		return s.InventoryFilesFunc(ctx, projectID)
+	}
+	return s.inventoryFiles(ctx, projectID)
+}
+
+// JobTemplateService is a fake awx.JobTemplateService.
+type JobTemplateService struct {
+	ListFunc               func(ctx context.Context) ([]awx.JobTemplate, *awx.Response, error)
+	GetFunc                func(ctx context.Context, jobTemplateID int) (*awx.JobTemplate, *awx.Response, error)
+	CreateFunc             func(ctx context.Context, createRequest *awx.JobTemplateCreateRequest) (*awx.JobTemplate, *awx.Response, error)
+	UpdateFunc             func(ctx context.Context, createRequest *awx.JobTemplateCreateRequest, jobTemplateID int) (*awx.Response, error)
+	DeleteFunc             func(ctx context.Context, jobTemplateID int) (*awx.Response, error)
+	GetSurveySpecFunc      func(ctx context.Context, jobTemplateID int) (*awx.SurveySpec, *awx.Response, error)
+	SetSurveySpecFunc      func(ctx context.Context, jobTemplateID int, spec *awx.SurveySpec) (*awx.Response, error)
+	DeleteSurveySpecFunc   func(ctx context.Context, jobTemplateID int) (*awx.Response, error)
+	ListInstanceGroupsFunc func(ctx context.Context, jobTemplateID int) ([]awx.InstanceGroup, *awx.Response, error)
+	SetInstanceGroupsFunc  func(ctx context.Context, jobTemplateID int, instanceGroupIDs []int) (*awx.Response, error)
+	ListLabelsFunc         func(ctx context.Context, jobTemplateID int) ([]awx.Label, *awx.Response, error)
+	AssociateLabelFunc     func(ctx context.Context, jobTemplateID int, labelID int) (*awx.Response, error)
+	DisassociateLabelFunc  func(ctx context.Context, jobTemplateID int, labelID int) (*awx.Response, error)
+
+	fake *Fake
+}
+
+var _ awx.JobTemplateService = &JobTemplateService{}
+
+// List records the call and calls ListFunc, or the in-memory default if it
+// is nil.
+func (s *JobTemplateService) List(ctx context.Context) ([]awx.JobTemplate, *awx.Response, error) {
+	s.fake.record("JobTemplate.List")
+	if s.ListFunc != nil {
+		return s.ListFunc(ctx)
+	}
+	return list[awx.JobTemplate](s.fake, "job_templates")
+}
+
+// Get records the call and calls GetFunc, or the in-memory default if it
+// is nil.
+func (s *JobTemplateService) Get(ctx context.Context, jobTemplateID int) (*awx.JobTemplate, *awx.Response, error) {
+	s.fake.record("JobTemplate.Get", jobTemplateID)
+	if s.GetFunc != nil {
+		return s.GetFunc(ctx, jobTemplateID)
+	}
+	return get[awx.JobTemplate](s.fake, "job_templates", "jobTemplateID", jobTemplateID)
+}
+
+// Create records the call and calls CreateFunc, or the in-memory default if it
+// is nil.
+func (s *JobTemplateService) Create(ctx context.Context, createRequest *awx.JobTemplateCreateRequest) (*awx.JobTemplate, *awx.Response, error) {
+	s.fake.record("JobTemplate.Create", createRequest)
+	if s.CreateFunc != nil {
+		return s.CreateFunc(ctx, createRequest)
+	}
+	return create[awx.JobTemplate](s.fake, "job_templates", "createRequest", createRequest)
+}
+
+// Update records the call and calls UpdateFunc, or the in-memory default if it
+// is nil.
+func (s *JobTemplateService) Update(ctx context.Context, createRequest *awx.JobTemplateCreateRequest, jobTemplateID int) (*awx.Response, error) {
+	s.fake.record("JobTemplate.Update", createRequest, jobTemplateID)
+	if s.UpdateFunc != nil {
+		return s.UpdateFunc(ctx, createRequest, jobTemplateID)
+	}
+	return update(s.fake, "job_templates", "createRequest", createRequest, "jobTemplateID", jobTemplateID)
+}
+
+// Delete records the call and calls DeleteFunc, or the in-memory default if it
+// is nil.
+func (s *JobTemplateService) Delete(ctx context.Context, jobTemplateID int) (*awx.Response, error) {
+	s.fake.record("JobTemplate.Delete", jobTemplateID)
+	if s.DeleteFunc != nil {
+		return s.DeleteFunc(ctx, jobTemplateID)
+	}
+	return remove(s.fake, "job_templates", "jobTemplateID", jobTemplateID)
+}
+
+// GetSurveySpec records the call and calls GetSurveySpecFunc, or the in-memory default if it
+// is nil.
+func (s *JobTemplateService) GetSurveySpec(ctx context.Context, jobTemplateID int) (*awx.SurveySpec, *awx.Response, error) {
+	s.fake.record("JobTemplate.GetSurveySpec", jobTemplateID)
+	if s.GetSurveySpecFunc != nil {
+		return s.GetSurveySpecFunc(ctx, jobTemplateID)
+	}
+	return s.getSurveySpec(ctx, jobTemplateID)
+}
+
+// SetSurveySpec records the call and calls SetSurveySpecFunc, or the in-memory default if it
+// is nil.
+func (s *JobTemplateService) SetSurveySpec(ctx context.Context, jobTemplateID int, spec *awx.SurveySpec) (*awx.Response, error) {
+	s.fake.record("JobTemplate.SetSurveySpec", jobTemplateID, spec)
+	if s.SetSurveySpecFunc != nil {
+		return s.SetSurveySpecFunc(ctx, jobTemplateID, spec)
+	}
+	return s.setSurveySpec(ctx, jobTemplateID, spec)
+}
+
+// DeleteSurveySpec records the call and calls DeleteSurveySpecFunc, or the in-memory default if it
+// is nil.
+func (s *JobTemplateService) DeleteSurveySpec(ctx context.Context, jobTemplateID int) (*awx.Response, error) {
+	s.fake.record("JobTemplate.DeleteSurveySpec", jobTemplateID)
+	if s.DeleteSurveySpecFunc != nil {
+		return s.DeleteSurveySpecFunc(ctx, jobTemplateID)
+	}
+	return s.deleteSurveySpec(ctx, jobTemplateID)
+}
+
+// ListInstanceGroups records the call and calls ListInstanceGroupsFunc, or the in-memory default if it
+// is nil.
+func (s *JobTemplateService) ListInstanceGroups(ctx context.Context, jobTemplateID int) ([]awx.InstanceGroup, *awx.Response, error) {
+	s.fake.record("JobTemplate.ListInstanceGroups", jobTemplateID)
+	if s.ListInstanceGroupsFunc != nil {
+		return s.ListInstanceGroupsFunc(ctx, jobTemplateID)
+	}
+	return s.listInstanceGroups(ctx, jobTemplateID)
+}
+
+// SetInstanceGroups records the call and calls SetInstanceGroupsFunc, or the in-memory default if it
+// is nil.
+func (s *JobTemplateService) SetInstanceGroups(ctx context.Context, jobTemplateID int, instanceGroupIDs []int) (*awx.Response, error) {
+	s.fake.record("JobTemplate.SetInstanceGroups", jobTemplateID, instanceGroupIDs)
+	if s.SetInstanceGroupsFunc != nil {
+		return s.SetInstanceGroupsFunc(ctx, jobTemplateID, instanceGroupIDs)
+	}
+	return s.setInstanceGroups(ctx, jobTemplateID, instanceGroupIDs)
+}
+
+// ListLabels records the call and calls ListLabelsFunc, or the in-memory default if it
+// is nil.
+func (s *JobTemplateService) ListLabels(ctx context.Context, jobTemplateID int) ([]awx.Label, *awx.Response, error) {
+	s.fake.record("JobTemplate.ListLabels", jobTemplateID)
+	if s.ListLabelsFunc != nil {
+		return s.ListLabelsFunc(ctx, jobTemplateID)
+	}
+	return s.listLabels(ctx, jobTemplateID)
+}
+
+// AssociateLabel records the call and calls AssociateLabelFunc, or the in-memory default if it
+// is nil.
+func (s *JobTemplateService) AssociateLabel(ctx context.Context, jobTemplateID int, labelID int) (*awx.Response, error) {
+	s.fake.record("JobTemplate.AssociateLabel", jobTemplateID, labelID)
+	if s.AssociateLabelFunc != nil {
+		return s.AssociateLabelFunc(ctx, jobTemplateID, labelID)
+	}
+	return s.associateLabel(ctx, jobTemplateID, labelID)
+}
+
+// DisassociateLabel records the call and calls DisassociateLabelFunc, or the in-memory default if it
+// is nil.
+func (s *JobTemplateService) DisassociateLabel(ctx context.Context, jobTemplateID int, labelID int) (*awx.Response, error) {
+	s.fake.record("JobTemplate.DisassociateLabel", jobTemplateID, labelID)
+	if s.DisassociateLabelFunc != nil {
+		return s.DisassociateLabelFunc(ctx, jobTemplateID, labelID)
+	}
+	return s.disassociateLabel(ctx, jobTemplateID, labelID)
+}
+
+// WorkflowJobTemplateService is a fake awx.WorkflowJobTemplateService.
+type WorkflowJobTemplateService struct {
+	ListFunc              func(ctx context.Context) ([]awx.WorkflowJobTemplate, *awx.Response, error)
+	GetFunc               func(ctx context.Context, workflowJobTemplateID int) (*awx.WorkflowJobTemplate, *awx.Response, error)
+	CreateFunc            func(ctx context.Context, createRequest *awx.WorkflowJobTemplateCreateRequest) (*awx.WorkflowJobTemplate, *awx.Response, error)
+	UpdateFunc            func(ctx context.Context, createRequest *awx.WorkflowJobTemplateCreateRequest, workflowJobTemplateID int) (*awx.Response, error)
+	DeleteFunc            func(ctx context.Context, workflowJobTemplateID int) (*awx.Response, error)
+	GetSurveySpecFunc     func(ctx context.Context, workflowJobTemplateID int) (*awx.SurveySpec, *awx.Response, error)
+	SetSurveySpecFunc     func(ctx context.Context, workflowJobTemplateID int, spec *awx.SurveySpec) (*awx.Response, error)
+	DeleteSurveySpecFunc  func(ctx context.Context, workflowJobTemplateID int) (*awx.Response, error)
+	ListLabelsFunc        func(ctx context.Context, workflowJobTemplateID int) ([]awx.Label, *awx.Response, error)
+	AssociateLabelFunc    func(ctx context.Context, workflowJobTemplateID int, labelID int) (*awx.Response, error)
+	DisassociateLabelFunc func(ctx context.Context, workflowJobTemplateID int, labelID int) (*awx.Response, error)
+
+	fake *Fake
+}
+
+var _ awx.WorkflowJobTemplateService = &WorkflowJobTemplateService{}
+
+// List records the call and calls ListFunc, or the in-memory default if it
+// is nil.
+func (s *WorkflowJobTemplateService) List(ctx context.Context) ([]awx.WorkflowJobTemplate, *awx.Response, error) {
+	s.fake.record("WorkflowJobTemplate.List")
+	if s.ListFunc != nil {
+		return s.ListFunc(ctx)
+	}
+	return list[awx.WorkflowJobTemplate](s.fake, "workflow_job_templates")
+}
+
+// Get records the call and calls GetFunc, or the in-memory default if it
+// is nil.
+func (s *WorkflowJobTemplateService) Get(ctx context.Context, workflowJobTemplateID int) (*awx.WorkflowJobTemplate, *awx.Response, error) {
+	s.fake.record("WorkflowJobTemplate.Get", workflowJobTemplateID)
+	if s.GetFunc != nil {
+		return s.GetFunc(ctx, workflowJobTemplateID)
+	}
+	return get[awx.WorkflowJobTemplate](s.fake, "workflow_job_templates", "workflowJobTemplateID", workflowJobTemplateID)
+}
+
+// Create records the call and calls CreateFunc, or the in-memory default if it
+// is nil.
+func (s *WorkflowJobTemplateService) Create(ctx context.Context, createRequest *awx.WorkflowJobTemplateCreateRequest) (*awx.WorkflowJobTemplate, *awx.Response, error) {
+	s.fake.record("WorkflowJobTemplate.Create", createRequest)
+	if s.CreateFunc != nil {
+		return s.CreateFunc(ctx, createRequest)
+	}
+	return create[awx.WorkflowJobTemplate](s.fake, "workflow_job_templates", "createRequest", createRequest)
+}
+
+// Update records the call and calls UpdateFunc, or the in-memory default if it
+// is nil.
+func (s *WorkflowJobTemplateService) Update(ctx context.Context, createRequest *awx.WorkflowJobTemplateCreateRequest, workflowJobTemplateID int) (*awx.Response, error) {
+	s.fake.record("WorkflowJobTemplate.Update", createRequest, workflowJobTemplateID)
+	if s.UpdateFunc != nil {
+		return s.UpdateFunc(ctx, createRequest, workflowJobTemplateID)
+	}
+	return update(s.fake, "workflow_job_templates", "createRequest", createRequest, "workflowJobTemplateID", workflowJobTemplateID)
+}
+
+// Delete records the call and calls DeleteFunc, or the in-memory default if it
+// is nil.
+func (s *WorkflowJobTemplateService) Delete(ctx context.Context, workflowJobTemplateID int) (*awx.Response, error) {
+	s.fake.record("WorkflowJobTemplate.Delete", workflowJobTemplateID)
+	if s.DeleteFunc != nil {
+		return s.DeleteFunc(ctx, workflowJobTemplateID)
+	}
+	return remove(s.fake, "workflow_job_templates", "workflowJobTemplateID", workflowJobTemplateID)
+}
+
+// GetSurveySpec records the call and calls GetSurveySpecFunc, or the in-memory default if it
+// is nil.
+func (s *WorkflowJobTemplateService) GetSurveySpec(ctx context.Context, workflowJobTemplateID int) (*awx.SurveySpec, *awx.Response, error) {
+	s.fake.record("WorkflowJobTemplate.GetSurveySpec", workflowJobTemplateID)
+	if s.GetSurveySpecFunc != nil {
+		return s.GetSurveySpecFunc(ctx, workflowJobTemplateID)
+	}
+	return s.getSurveySpec(ctx, workflowJobTemplateID)
+}
+
+// SetSurveySpec records the call and calls SetSurveySpecFunc, or the in-memory default if it
+// is nil.
+func (s *WorkflowJobTemplateService) SetSurveySpec(ctx context.Context, workflowJobTemplateID int, spec *awx.SurveySpec) (*awx.Response, error) {
+	s.fake.record("WorkflowJobTemplate.SetSurveySpec", workflowJobTemplateID, spec)
+	if s.SetSurveySpecFunc != nil {
+		return s.SetSurveySpecFunc(ctx, workflowJobTemplateID, spec)
+	}
+	return s.setSurveySpec(ctx, workflowJobTemplateID, spec)
+}
+
+// DeleteSurveySpec records the call and calls DeleteSurveySpecFunc, or the in-memory default if it
+// is nil.
+func (s *WorkflowJobTemplateService) DeleteSurveySpec(ctx context.Context, workflowJobTemplateID int) (*awx.Response, error) {
+	s.fake.record("WorkflowJobTemplate.DeleteSurveySpec", workflowJobTemplateID)
+	if s.DeleteSurveySpecFunc != nil {
+		return s.DeleteSurveySpecFunc(ctx, workflowJobTemplateID)
+	}
+	return s.deleteSurveySpec(ctx, workflowJobTemplateID)
+}
+
+// ListLabels records the call and calls ListLabelsFunc, or the in-memory default if it
+// is nil.
+func (s *WorkflowJobTemplateService) ListLabels(ctx context.Context, workflowJobTemplateID int) ([]awx.Label, *awx.Response, error) {
+	s.fake.record("WorkflowJobTemplate.ListLabels", workflowJobTemplateID)
+	if s.ListLabelsFunc != nil {
+		return s.ListLabelsFunc(ctx, workflowJobTemplateID)
+	}
+	return s.listLabels(ctx, workflowJobTemplateID)
+}
+
+// AssociateLabel records the call and calls AssociateLabelFunc, or the in-memory default if it
+// is nil.
+func (s *WorkflowJobTemplateService) AssociateLabel(ctx context.Context, workflowJobTemplateID int, labelID int) (*awx.Response, error) {
+	s.fake.record("WorkflowJobTemplate.AssociateLabel", workflowJobTemplateID, labelID)
+	if s.AssociateLabelFunc != nil {
+		return s.AssociateLabelFunc(ctx, workflowJobTemplateID, labelID)
+	}
+	return s.associateLabel(ctx, workflowJobTemplateID, labelID)
+}
+
+// DisassociateLabel records the call and calls DisassociateLabelFunc, or the in-memory default if it
+// is nil.
+func (s *WorkflowJobTemplateService) DisassociateLabel(ctx context.Context, workflowJobTemplateID int, labelID int) (*awx.Response, error) {
+	s.fake.record("WorkflowJobTemplate.DisassociateLabel", workflowJobTemplateID, labelID)
+	if s.DisassociateLabelFunc != nil {
+		return s.DisassociateLabelFunc(ctx, workflowJobTemplateID, labelID)
+	}
+	return s.disassociateLabel(ctx, workflowJobTemplateID, labelID)
+}
+
+// AdHocCommandService is a fake awx.AdHocCommandService.
+type AdHocCommandService struct {
+	ListFunc     func(ctx context.Context) ([]awx.AdHocCommand, *awx.Response, error)
+	GetFunc      func(ctx context.Context, adHocCommandID int) (*awx.AdHocCommand, *awx.Response, error)
+	CreateFunc   func(ctx context.Context, createRequest *awx.AdHocCommandCreateRequest) (*awx.AdHocCommand, *awx.Response, error)
+	DeleteFunc   func(ctx context.Context, adHocCommandID int) (*awx.Response, error)
+	CancelFunc   func(ctx context.Context, adHocCommandID int) (*awx.Response, error)
+	RelaunchFunc func(ctx context.Context, adHocCommandID int) (*awx.AdHocCommand, *awx.Response, error)
+	EventsFunc   func(ctx context.Context, adHocCommandID int) ([]awx.AdHocCommandEvent, *awx.Response, error)
+	StdoutFunc   func(ctx context.Context, adHocCommandID int) (string, *awx.Response, error)
+
+	fake *Fake
+}
+
+var _ awx.AdHocCommandService = &AdHocCommandService{}
+
+// List records the call and calls ListFunc, or the in-memory default if it
+// is nil.
+func (s *AdHocCommandService) List(ctx context.Context) ([]awx.AdHocCommand, *awx.Response, error) {
+	s.fake.record("AdHocCommand.List")
+	if s.ListFunc != nil {
+		return s.ListFunc(ctx)
+	}
+	return list[awx.AdHocCommand](s.fake, "ad_hoc_commands")
+}
+
+// Get records the call and calls GetFunc, or the in-memory default if it
+// is nil.
+func (s *AdHocCommandService) Get(ctx context.Context, adHocCommandID int) (*awx.AdHocCommand, *awx.Response, error) {
+	s.fake.record("AdHocCommand.Get", adHocCommandID)
+	if s.GetFunc != nil {
+		return s.GetFunc(ctx, adHocCommandID)
+	}
+	return get[awx.AdHocCommand](s.fake, "ad_hoc_commands", "adHocCommandID", adHocCommandID)
+}
+
+// Create records the call and calls CreateFunc, or the in-memory default if it
+// is nil.
+func (s *AdHocCommandService) Create(ctx context.Context, createRequest *awx.AdHocCommandCreateRequest) (*awx.AdHocCommand, *awx.Response, error) {
+	s.fake.record("AdHocCommand.Create", createRequest)
+	if s.CreateFunc != nil {
+		return s.CreateFunc(ctx, createRequest)
+	}
+	return create[awx.AdHocCommand](s.fake, "ad_hoc_commands", "createRequest", createRequest)
+}
+
+// Delete records the call and calls DeleteFunc, or the in-memory default if it
+// is nil.
+func (s *AdHocCommandService) Delete(ctx context.Context, adHocCommandID int) (*awx.Response, error) {
+	s.fake.record("AdHocCommand.Delete", adHocCommandID)
+	if s.DeleteFunc != nil {
+		return s.DeleteFunc(ctx, adHocCommandID)
+	}
+	return remove(s.fake, "ad_hoc_commands", "adHocCommandID", adHocCommandID)
+}
+
+// Cancel records the call and calls CancelFunc, or the in-memory default if it
+// is nil.
+func (s *AdHocCommandService) Cancel(ctx context.Context, adHocCommandID int) (*awx.Response, error) {
+	s.fake.record("AdHocCommand.Cancel", adHocCommandID)
+	if s.CancelFunc != nil {
+		return s.CancelFunc(ctx, adHocCommandID)
+	}
+	return s.cancel(ctx, adHocCommandID)
+}
+
+// Relaunch records the call and calls RelaunchFunc, or the in-memory default if it
+// is nil.
+func (s *AdHocCommandService) Relaunch(ctx context.Context, adHocCommandID int) (*awx.AdHocCommand, *awx.Response, error) {
+	s.fake.record("AdHocCommand.Relaunch", adHocCommandID)
+	if s.RelaunchFunc != nil {
+		return s.RelaunchFunc(ctx, adHocCommandID)
+	}
+	return s.relaunch(ctx, adHocCommandID)
+}
+
+// Events records the call and calls EventsFunc, or the in-memory default if it
+// is nil.
+func (s *AdHocCommandService) Events(ctx context.Context, adHocCommandID int) ([]awx.AdHocCommandEvent, *awx.Response, error) {
+	s.fake.record("AdHocCommand.Events", adHocCommandID)
+	if s.EventsFunc != nil {
+		return s.EventsFunc(ctx, adHocCommandID)
+	}
+	return s.events(ctx, adHocCommandID)
+}
+
+// Stdout records the call and calls StdoutFunc, or the in-memory default if it
+// is nil.
+func (s *AdHocCommandService) Stdout(ctx context.Context, adHocCommandID int) (string, *awx.Response, error) {
+	s.fake.record("AdHocCommand.Stdout", adHocCommandID)
+	if s.StdoutFunc != nil {
+		return s.StdoutFunc(ctx, adHocCommandID)
+	}
+	return s.stdout(ctx, adHocCommandID)
+}
+
+// ExecutionEnvironmentService is a fake awx.ExecutionEnvironmentService.
+type ExecutionEnvironmentService struct {
+	ListFunc   func(ctx context.Context) ([]awx.ExecutionEnvironment, *awx.Response, error)
+	GetFunc    func(ctx context.Context, executionEnvironmentID int) (*awx.ExecutionEnvironment, *awx.Response, error)
+	CreateFunc func(ctx context.Context, createRequest *awx.ExecutionEnvironmentCreateRequest) (*awx.ExecutionEnvironment, *awx.Response, error)
+	UpdateFunc func(ctx context.Context, createRequest *awx.ExecutionEnvironmentCreateRequest, executionEnvironmentID int) (*awx.Response, error)
+	DeleteFunc func(ctx context.Context, executionEnvironmentID int) (*awx.Response, error)
+
+	fake *Fake
+}
+
+var _ awx.ExecutionEnvironmentService = &ExecutionEnvironmentService{}
+
+// List records the call and calls ListFunc, or the in-memory default if it
+// is nil.
+func (s *ExecutionEnvironmentService) List(ctx context.Context) ([]awx.ExecutionEnvironment, *awx.Response, error) {
+	s.fake.record("ExecutionEnvironment.List")
+	if s.ListFunc != nil {
+		return s.ListFunc(ctx)
+	}
+	return list[awx.ExecutionEnvironment](s.fake, "execution_environments")
+}
+
+// Get records the call and calls GetFunc, or the in-memory default if it
+// is nil.
+func (s *ExecutionEnvironmentService) Get(ctx context.Context, executionEnvironmentID int) (*awx.ExecutionEnvironment, *awx.Response, error) {
+	s.fake.record("ExecutionEnvironment.Get", executionEnvironmentID)
+	if s.GetFunc != nil {
+		return s.GetFunc(ctx, executionEnvironmentID)
+	}
+	return get[awx.ExecutionEnvironment](s.fake, "execution_environments", "executionEnvironmentID", executionEnvironmentID)
+}
+
+// Create records the call and calls CreateFunc, or the in-memory default if it
+// is nil.
+func (s *ExecutionEnvironmentService) Create(ctx context.Context, createRequest *awx.ExecutionEnvironmentCreateRequest) (*awx.ExecutionEnvironment, *awx.Response, error) {
+	s.fake.record("ExecutionEnvironment.Create", createRequest)
+	if s.CreateFunc != nil {
+		return s.CreateFunc(ctx, createRequest)
+	}
+	return create[awx.ExecutionEnvironment](s.fake, "execution_environments", "createRequest", createRequest)
+}
+
+// Update records the call and calls UpdateFunc, or the in-memory default if it
+// is nil.
+func (s *ExecutionEnvironmentService) Update(ctx context.Context, createRequest *awx.ExecutionEnvironmentCreateRequest, executionEnvironmentID int) (*awx.Response, error) {
+	s.fake.record("ExecutionEnvironment.Update", createRequest, executionEnvironmentID)
+	if s.UpdateFunc != nil {
+		return s.UpdateFunc(ctx, createRequest, executionEnvironmentID)
+	}
+	return update(s.fake, "execution_environments", "createRequest", createRequest, "executionEnvironmentID", executionEnvironmentID)
+}
+
+// Delete records the call and calls DeleteFunc, or the in-memory default if it
+// is nil.
+func (s *ExecutionEnvironmentService) Delete(ctx context.Context, executionEnvironmentID int) (*awx.Response, error) {
+	s.fake.record("ExecutionEnvironment.Delete", executionEnvironmentID)
+	if s.DeleteFunc != nil {
+		return s.DeleteFunc(ctx, executionEnvironmentID)
+	}
+	return remove(s.fake, "execution_environments", "executionEnvironmentID", executionEnvironmentID)
+}
+
+// InstanceGroupService is a fake awx.InstanceGroupService.
+type InstanceGroupService struct {
+	ListFunc                 func(ctx context.Context) ([]awx.InstanceGroup, *awx.Response, error)
+	GetFunc                  func(ctx context.Context, instanceGroupID int) (*awx.InstanceGroup, *awx.Response, error)
+	CreateFunc               func(ctx context.Context, createRequest *awx.InstanceGroupCreateRequest) (*awx.InstanceGroup, *awx.Response, error)
+	UpdateFunc               func(ctx context.Context, createRequest *awx.InstanceGroupCreateRequest, instanceGroupID int) (*awx.Response, error)
+	DeleteFunc               func(ctx context.Context, instanceGroupID int) (*awx.Response, error)
+	ListInstancesFunc        func(ctx context.Context, instanceGroupID int) ([]awx.Instance, *awx.Response, error)
+	AssociateInstanceFunc    func(ctx context.Context, instanceGroupID int, instanceID int) (*awx.Response, error)
+	DisassociateInstanceFunc func(ctx context.Context, instanceGroupID int, instanceID int) (*awx.Response, error)
+
+	fake *Fake
+}
+
+var _ awx.InstanceGroupService = &InstanceGroupService{}
+
+// List records the call and calls ListFunc, or the in-memory default if it
+// is nil.
+func (s *InstanceGroupService) List(ctx context.Context) ([]awx.InstanceGroup, *awx.Response, error) {
+	s.fake.record("InstanceGroup.List")
+	if s.ListFunc != nil {
+		return s.ListFunc(ctx)
+	}
+	return list[awx.InstanceGroup](s.fake, "instance_groups")
+}
+
+// Get records the call and calls GetFunc, or the in-memory default if it
+// is nil.
+func (s *InstanceGroupService) Get(ctx context.Context, instanceGroupID int) (*awx.InstanceGroup, *awx.Response, error) {
+	s.fake.record("InstanceGroup.Get", instanceGroupID)
+	if s.GetFunc != nil {
+		return s.GetFunc(ctx, instanceGroupID)
+	}
+	return get[awx.InstanceGroup](s.fake, "instance_groups", "instanceGroupID", instanceGroupID)
+}
+
+// Create records the call and calls CreateFunc, or the in-memory default if it
+// is nil.
+func (s *InstanceGroupService) Create(ctx context.Context, createRequest *awx.InstanceGroupCreateRequest) (*awx.InstanceGroup, *awx.Response, error) {
+	s.fake.record("InstanceGroup.Create", createRequest)
+	if s.CreateFunc != nil {
+		return s.CreateFunc(ctx, createRequest)
+	}
+	return create[awx.InstanceGroup](s.fake, "instance_groups", "createRequest", createRequest)
+}
+
+// Update records the call and calls UpdateFunc, or the in-memory default if it
+// is nil.
+func (s *InstanceGroupService) Update(ctx context.Context, createRequest *awx.InstanceGroupCreateRequest, instanceGroupID int) (*awx.Response, error) {
+	s.fake.record("InstanceGroup.Update", createRequest, instanceGroupID)
+	if s.UpdateFunc != nil {
+		return s.UpdateFunc(ctx, createRequest, instanceGroupID)
+	}
+	return update(s.fake, "instance_groups", "createRequest", createRequest, "instanceGroupID", instanceGroupID)
+}
+
+// Delete records the call and calls DeleteFunc, or the in-memory default if it
+// is nil.
+func (s *InstanceGroupService) Delete(ctx context.Context, instanceGroupID int) (*awx.Response, error) {
+	s.fake.record("InstanceGroup.Delete", instanceGroupID)
+	if s.DeleteFunc != nil {
+		return s.DeleteFunc(ctx, instanceGroupID)
+	}
+	return remove(s.fake, "instance_groups", "instanceGroupID", instanceGroupID)
+}
+
+// ListInstances records the call and calls ListInstancesFunc, or the in-memory default if it
+// is nil.
+func (s *InstanceGroupService) ListInstances(ctx context.Context, instanceGroupID int) ([]awx.Instance, *awx.Response, error) {
+	s.fake.record("InstanceGroup.ListInstances", instanceGroupID)
+	if s.ListInstancesFunc != nil {
+		return s.ListInstancesFunc(ctx, instanceGroupID)
+	}
+	return s.listInstances(ctx, instanceGroupID)
+}
+
+// AssociateInstance records the call and calls AssociateInstanceFunc, or the in-memory default if it
+// is nil.
+func (s *InstanceGroupService) AssociateInstance(ctx context.Context, instanceGroupID int, instanceID int) (*awx.Response, error) {
+	s.fake.record("InstanceGroup.AssociateInstance", instanceGroupID, instanceID)
+	if s.AssociateInstanceFunc != nil {
+		return s.AssociateInstanceFunc(ctx, instanceGroupID, instanceID)
+	}
+	return s.associateInstance(ctx, instanceGroupID, instanceID)
+}
+
+// DisassociateInstance records the call and calls DisassociateInstanceFunc, or the in-memory default if it
+// is nil.
+func (s *InstanceGroupService) DisassociateInstance(ctx context.Context, instanceGroupID int, instanceID int) (*awx.Response, error) {
+	s.fake.record("InstanceGroup.DisassociateInstance", instanceGroupID, instanceID)
+	if s.DisassociateInstanceFunc != nil {
+		return s.DisassociateInstanceFunc(ctx, instanceGroupID, instanceID)
+	}
+	return s.disassociateInstance(ctx, instanceGroupID, instanceID)
+}
+
+// InstanceService is a fake awx.InstanceService.
+type InstanceService struct {
+	ListFunc   func(ctx context.Context) ([]awx.Instance, *awx.Response, error)
+	GetFunc    func(ctx context.Context, instanceID int) (*awx.Instance, *awx.Response, error)
+	UpdateFunc func(ctx context.Context, updateRequest *awx.InstanceUpdateRequest, instanceID int) (*awx.Response, error)
+
+	fake *Fake
+}
+
+var _ awx.InstanceService = &InstanceService{}
+
+// List records the call and calls ListFunc, or the in-memory default if it
+// is nil.
+func (s *InstanceService) List(ctx context.Context) ([]awx.Instance, *awx.Response, error) {
+	s.fake.record("Instance.List")
+	if s.ListFunc != nil {
+		return s.ListFunc(ctx)
+	}
+	return list[awx.Instance](s.fake, "instances")
+}
+
+// Get records the call and calls GetFunc, or the in-memory default if it
+// is nil.
+func (s *InstanceService) Get(ctx context.Context, instanceID int) (*awx.Instance, *awx.Response, error) {
+	s.fake.record("Instance.Get", instanceID)
+	if s.GetFunc != nil {
+		return s.GetFunc(ctx, instanceID)
+	}
+	return get[awx.Instance](s.fake, "instances", "instanceID", instanceID)
+}
+
+// Update records the call and calls UpdateFunc, or the in-memory default if it
+// is nil.
+func (s *InstanceService) Update(ctx context.Context, updateRequest *awx.InstanceUpdateRequest, instanceID int) (*awx.Response, error) {
+	s.fake.record("Instance.Update", updateRequest, instanceID)
+	if s.UpdateFunc != nil {
+		return s.UpdateFunc(ctx, updateRequest, instanceID)
+	}
+	return update(s.fake, "instances", "updateRequest", updateRequest, "instanceID", instanceID)
+}
+
+// LabelService is a fake awx.LabelService.
+type LabelService struct {
+	ListFunc          func(ctx context.Context) ([]awx.Label, *awx.Response, error)
+	GetFunc           func(ctx context.Context, labelID int) (*awx.Label, *awx.Response, error)
+	CreateFunc        func(ctx context.Context, createRequest *awx.LabelCreateRequest) (*awx.Label, *awx.Response, error)
+	UpdateFunc        func(ctx context.Context, createRequest *awx.LabelCreateRequest, labelID int) (*awx.Response, error)
+	FindTemplatesFunc func(ctx context.Context, labelName string) (*awx.LabeledTemplates, *awx.Response, error)
+
+	fake *Fake
+}
+
+var _ awx.LabelService = &LabelService{}
+
+// List records the call and calls ListFunc, or the in-memory default if it
+// is nil.
+func (s *LabelService) List(ctx context.Context) ([]awx.Label, *awx.Response, error) {
+	s.fake.record("Label.List")
+	if s.ListFunc != nil {
+		return s.ListFunc(ctx)
+	}
+	return list[awx.Label](s.fake, "labels")
+}
+
+// Get records the call and calls GetFunc, or the in-memory default if it
+// is nil.
+func (s *LabelService) Get(ctx context.Context, labelID int) (*awx.Label, *awx.Response, error) {
+	s.fake.record("Label.Get", labelID)
+	if s.GetFunc != nil {
+		return s.GetFunc(ctx, labelID)
+	}
+	return get[awx.Label](s.fake, "labels", "labelID", labelID)
+}
+
+// Create records the call and calls CreateFunc, or the in-memory default if it
+// is nil.
+func (s *LabelService) Create(ctx context.Context, createRequest *awx.LabelCreateRequest) (*awx.Label, *awx.Response, error) {
+	s.fake.record("Label.Create", createRequest)
+	if s.CreateFunc != nil {
+		return s.CreateFunc(ctx, createRequest)
+	}
+	return create[awx.Label](s.fake, "labels", "createRequest", createRequest)
+}
+
+// Update records the call and calls UpdateFunc, or the in-memory default if it
+// is nil.
+func (s *LabelService) Update(ctx context.Context, createRequest *awx.LabelCreateRequest, labelID int) (*awx.Response, error) {
+	s.fake.record("Label.Update", createRequest, labelID)
+	if s.UpdateFunc != nil {
+		return s.UpdateFunc(ctx, createRequest, labelID)
+	}
+	return update(s.fake, "labels", "createRequest", createRequest, "labelID", labelID)
+}
+
+// FindTemplates records the call and calls FindTemplatesFunc, or the in-memory default if it
+// is nil.
+func (s *LabelService) FindTemplates(ctx context.Context, labelName string) (*awx.LabeledTemplates, *awx.Response, error) {
+	s.fake.record("Label.FindTemplates", labelName)
+	if s.FindTemplatesFunc != nil {
+		return s.FindTemplatesFunc(ctx, labelName)
+	}
+	return s.findTemplates(ctx, labelName)
+}
+
+// UnifiedJobService is a fake awx.UnifiedJobService.
+type UnifiedJobService struct {
+	ListFunc func(ctx context.Context, opts *awx.UnifiedJobListOptions) ([]awx.UnifiedJob, *awx.Response, error)
+
+	fake *Fake
+}
+
+var _ awx.UnifiedJobService = &UnifiedJobService{}
+
+// List records the call and calls ListFunc, or the in-memory default if it
+// is nil.
+func (s *UnifiedJobService) List(ctx context.Context, opts *awx.UnifiedJobListOptions) ([]awx.UnifiedJob, *awx.Response, error) {
+	s.fake.record("UnifiedJob.List", opts)
+	if s.ListFunc != nil {
+		return s.ListFunc(ctx, opts)
+	}
+	return s.list(ctx, opts)
+}
+
+// UnifiedJobTemplateService is a fake awx.UnifiedJobTemplateService.
+type UnifiedJobTemplateService struct {
+	ListFunc func(ctx context.Context, opts *awx.UnifiedJobTemplateListOptions) ([]awx.UnifiedJobTemplate, *awx.Response, error)
+
+	fake *Fake
+}
+
+var _ awx.UnifiedJobTemplateService = &UnifiedJobTemplateService{}
+
+// List records the call and calls ListFunc, or the in-memory default if it
+// is nil.
+func (s *UnifiedJobTemplateService) List(ctx context.Context, opts *awx.UnifiedJobTemplateListOptions) ([]awx.UnifiedJobTemplate, *awx.Response, error) {
+	s.fake.record("UnifiedJobTemplate.List", opts)
+	if s.ListFunc != nil {
+		return s.ListFunc(ctx, opts)
+	}
+	return s.list(ctx, opts)
+}
+
+// ActivityStreamService is a fake awx.ActivityStreamService.
+type ActivityStreamService struct {
+	ListFunc  func(ctx context.Context, opts *awx.ActivityStreamListOptions) ([]awx.ActivityStreamEntry, *awx.Response, error)
+	WatchFunc func(ctx context.Context, opts *awx.WatchOptions) (<-chan awx.ChangeEvent, error)
+
+	fake *Fake
+}
+
+var _ awx.ActivityStreamService = &ActivityStreamService{}
+
+// List records the call and calls ListFunc, or the in-memory default if it
+// is nil.
+func (s *ActivityStreamService) List(ctx context.Context, opts *awx.ActivityStreamListOptions) ([]awx.ActivityStreamEntry, *awx.Response, error) {
+	s.fake.record("ActivityStream.List", opts)
+	if s.ListFunc != nil {
+		return s.ListFunc(ctx, opts)
+	}
+	return s.list(ctx, opts)
+}
+
+// Watch records the call and calls WatchFunc, or the in-memory default if it
+// is nil.
+func (s *ActivityStreamService) Watch(ctx context.Context, opts *awx.WatchOptions) (<-chan awx.ChangeEvent, error) {
+	s.fake.record("ActivityStream.Watch", opts)
+	if s.WatchFunc != nil {
+		return s.WatchFunc(ctx, opts)
+	}
+	return s.watch(ctx, opts)
+}
+
+// PingService is a fake awx.PingService.
+type PingService struct {
+	GetFunc func(ctx context.Context) (*awx.Ping, *awx.Response, error)
+
+	fake *Fake
+}
+
+var _ awx.PingService = &PingService{}
+
+// Get records the call and calls GetFunc, or the in-memory default if it
+// is nil.
+func (s *PingService) Get(ctx context.Context) (*awx.Ping, *awx.Response, error) {
+	s.fake.record("Ping.Get")
+	if s.GetFunc != nil {
+		return s.GetFunc(ctx)
+	}
+	return s.get(ctx)
+}
+
+// ServerConfigService is a fake awx.ServerConfigService.
+type ServerConfigService struct {
+	GetFunc func(ctx context.Context) (*awx.ServerConfig, *awx.Response, error)
+
+	fake *Fake
+}
+
+var _ awx.ServerConfigService = &ServerConfigService{}
+
+// Get records the call and calls GetFunc, or the in-memory default if it
+// is nil.
+func (s *ServerConfigService) Get(ctx context.Context) (*awx.ServerConfig, *awx.Response, error) {
+	s.fake.record("ServerConfig.Get")
+	if s.GetFunc != nil {
+		return s.GetFunc(ctx)
+	}
+	return s.get(ctx)
+}
+
+// MetadataService is a fake awx.MetadataService.
+type MetadataService struct {
+	GetFunc      func(ctx context.Context, path string) (*awx.EndpointMetadata, *awx.Response, error)
+	ChoicesFunc  func(ctx context.Context, path string, field string) ([]awx.FieldChoice, error)
+	ValidateFunc func(ctx context.Context, method string, path string, body interface{}) error
+
+	fake *Fake
+}
+
+var _ awx.MetadataService = &MetadataService{}
+
+// Get records the call and calls GetFunc, or the in-memory default if it
+// is nil.
+func (s *MetadataService) Get(ctx context.Context, path string) (*awx.EndpointMetadata, *awx.Response, error) {
+	s.fake.record("Metadata.Get", path)
+	if s.GetFunc != nil {
+		return s.GetFunc(ctx, path)
+	}
+	return s.get(ctx, path)
+}
+
+// Choices records the call and calls ChoicesFunc, or the in-memory default if it
+// is nil.
+func (s *MetadataService) Choices(ctx context.Context, path string, field string) ([]awx.FieldChoice, error) {
+	s.fake.record("Metadata.Choices", path, field)
+	if s.ChoicesFunc != nil {
+		return s.ChoicesFunc(ctx, path, field)
+	}
+	return s.choices(ctx, path, field)
+}
+
+// Validate records the call and calls ValidateFunc, or the in-memory default if it
+// is nil.
+func (s *MetadataService) Validate(ctx context.Context, method string, path string, body interface{}) error {
+	s.fake.record("Metadata.Validate", method, path, body)
+	if s.ValidateFunc != nil {
+		return s.ValidateFunc(ctx, method, path, body)
+	}
+	return s.validate(ctx, method, path, body)
+}
+
+// RawService is a fake awx.RawService.
+type RawService struct {
+	GetFunc   func(ctx context.Context, path string) (map[string]interface{}, *awx.Response, error)
+	PatchFunc func(ctx context.Context, path string, fields map[string]interface{}) (map[string]interface{}, *awx.Response, error)
+
+	fake *Fake
+}
+
+var _ awx.RawService = &RawService{}
+
+// Get records the call and calls GetFunc, or the in-memory default if it
+// is nil.
+func (s *RawService) Get(ctx context.Context, path string) (map[string]interface{}, *awx.Response, error) {
+	s.fake.record("Raw.Get", path)
+	if s.GetFunc != nil {
+		return s.GetFunc(ctx, path)
+	}
+	return s.get(ctx, path)
+}
+
+// Patch records the call and calls PatchFunc, or the in-memory default if it
+// is nil.
+func (s *RawService) Patch(ctx context.Context, path string, fields map[string]interface{}) (map[string]interface{}, *awx.Response, error) {
+	s.fake.record("Raw.Patch", path, fields)
+	if s.PatchFunc != nil {
+		return s.PatchFunc(ctx, path, fields)
+	}
+	return s.patch(ctx, path, fields)
+}
@@ -0,0 +1,629 @@
+package awxfake
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sparkacus/awx-go-client/awx"
+)
+
+// version is the AWX version reported by the Ping and Config fakes.
+const version = "24.6.1"
+
+// The collections of unified jobs and unified job templates, which share an
+// ID sequence in AWX.
+var (
+	unifiedJobs         = []string{"jobs", "project_updates", "inventory_updates", "workflow_jobs", "ad_hoc_commands", "system_jobs"}
+	unifiedJobTemplates = []string{"job_templates", "projects", "inventory_sources", "workflow_job_templates", "system_job_templates"}
+)
+
+// types maps collections to the type of their objects.
+var types = map[string]string{
+	"activity_stream":        "activity_stream",
+	"ad_hoc_commands":        "ad_hoc_command",
+	"execution_environments": "execution_environment",
+	"instance_groups":        "instance_group",
+	"instances":              "instance",
+	"inventories":            "inventory",
+	"inventory_sources":      "inventory_source",
+	"inventory_updates":      "inventory_update",
+	"job_templates":          "job_template",
+	"jobs":                   "job",
+	"labels":                 "label",
+	"organizations":          "organization",
+	"project_updates":        "project_update",
+	"projects":               "project",
+	"system_job_templates":   "system_job_template",
+	"system_jobs":            "system_job",
+	"workflow_job_templates": "workflow_job_template",
+	"workflow_jobs":          "workflow_job",
+}
+
+// object is an object held by the fake, in its JSON form.
+type object map[string]interface{}
+
+func (o object) id() int {
+	return intValue(o["id"])
+}
+
+func intValue(v interface{}) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	}
+	return 0
+}
+
+// relation is a related collection of an object, such as the labels of a
+// job template, holding the IDs of its members in order.
+type relation struct {
+	collection string
+	ids        []int
+}
+
+// state is the in-memory state of a Fake: the calls made to it and the
+// objects created through it or added with Add.
+type state struct {
+	mu sync.Mutex
+
+	calls    []Call
+	objects  map[string]map[int]object
+	seqs     map[string]int
+	related  map[string]*relation
+	surveys  map[string]object
+	activity []object
+
+	// changed is closed and replaced whenever an entry is added to the
+	// activity stream, to wake up watchers.
+	changed chan struct{}
+}
+
+func newState() *state {
+	return &state{
+		objects: make(map[string]map[int]object),
+		seqs:    make(map[string]int),
+		related: make(map[string]*relation),
+		surveys: make(map[string]object),
+		changed: make(chan struct{}),
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// sequence returns the ID sequence of a collection.
+func sequence(collection string) string {
+	switch {
+	case contains(unifiedJobs, collection):
+		return "unified_jobs"
+	case contains(unifiedJobTemplates, collection):
+		return "unified_job_templates"
+	}
+	return collection
+}
+
+// path returns the path of an object relative to the API root.
+func path(collection string, id int) string {
+	return fmt.Sprintf("%s/%d/", collection, id)
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
+
+// encode converts v to its JSON form.
+func encode(v interface{}) (object, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var obj object
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("awxfake: %T does not encode to a JSON object", v)
+	}
+	return obj, nil
+}
+
+// decode fills v from an object. Fields whose JSON form does not fit the
+// type of the matching field of v, such as those set by a create request of
+// a different shape, are left unset.
+func decode(obj interface{}, v interface{}) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(data, v)
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return nil
+	}
+	return err
+}
+
+// response returns a response as AWX would send it for a request.
+func response(method, p string, status int) *awx.Response {
+	req := &http.Request{
+		Method: method,
+		URL:    &url.URL{Scheme: "http", Host: "localhost", Path: "/api/v2/" + p},
+		Header: make(http.Header),
+	}
+	return &awx.Response{Response: &http.Response{
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode: status,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       http.NoBody,
+		Request:    req,
+	}}
+}
+
+// errorResponse returns the response and error AWX would answer a request
+// with.
+func errorResponse(method, p string, status int, message string) (*awx.Response, error) {
+	resp := response(method, p, status)
+	return resp, &awx.ErrorResponse{Response: resp.Response, Message: message}
+}
+
+func notFound(method, p string) (*awx.Response, error) {
+	return errorResponse(method, p, http.StatusNotFound, "Not found.")
+}
+
+func checkID(arg string, id int) error {
+	if id < 1 {
+		return awx.NewArgError(arg, "cannot be less than 1")
+	}
+	return nil
+}
+
+// nextID returns the next ID of a collection, after any added explicitly.
+func (s *state) nextID(collection string) int {
+	seq := sequence(collection)
+	s.seqs[seq]++
+	return s.seqs[seq]
+}
+
+// insert adds an object to a collection, filling in the fields AWX sets on
+// create. It must be called with s.mu held.
+func (s *state) insert(collection string, obj object) object {
+	id := obj.id()
+	if id > 0 {
+		if seq := sequence(collection); id > s.seqs[seq] {
+			s.seqs[seq] = id
+		}
+	} else {
+		id = s.nextID(collection)
+	}
+
+	obj["id"] = id
+	obj["type"] = types[collection]
+	obj["url"] = "/api/v2/" + path(collection, id)
+	created := now()
+	if _, ok := obj["created"]; !ok {
+		obj["created"] = created
+	}
+	obj["modified"] = created
+
+	if contains(unifiedJobs, collection) {
+		if _, ok := obj["status"]; !ok {
+			obj["status"] = "pending"
+		}
+		if _, ok := obj["launch_type"]; !ok {
+			obj["launch_type"] = "manual"
+		}
+		summary, _ := obj["summary_fields"].(map[string]interface{})
+		if summary == nil {
+			summary = make(map[string]interface{})
+			obj["summary_fields"] = summary
+		}
+		if _, ok := summary["created_by"]; !ok {
+			summary["created_by"] = map[string]interface{}{"id": 1, "username": "admin"}
+		}
+	}
+
+	if s.objects[collection] == nil {
+		s.objects[collection] = make(map[int]object)
+	}
+	s.objects[collection][id] = obj
+	return obj
+}
+
+// sorted returns the objects of a collection in order of ID.
+func (s *state) sorted(collection string) []object {
+	objs := make([]object, 0, len(s.objects[collection]))
+	for _, obj := range s.objects[collection] {
+		objs = append(objs, obj)
+	}
+	sort.Slice(objs, func(i, j int) bool { return objs[i].id() < objs[j].id() })
+	return objs
+}
+
+// summarize returns the short form of an object used in summary fields.
+func summarize(obj object) map[string]interface{} {
+	summary := map[string]interface{}{"id": obj.id()}
+	for _, name := range []string{"name", "description"} {
+		if v, ok := obj[name]; ok {
+			summary[name] = v
+		}
+	}
+	return summary
+}
+
+// track records a change to obj in the activity stream; other is the object
+// it was associated with or disassociated from, if any. It must be called
+// with s.mu held.
+func (s *state) track(operation string, obj object, changes map[string]interface{}, other object, association string) {
+	typ, _ := obj["type"].(string)
+	summary := map[string]interface{}{
+		typ:     []interface{}{summarize(obj)},
+		"actor": map[string]interface{}{"id": 1, "username": "admin"},
+	}
+	entry := object{
+		"timestamp":          now(),
+		"operation":          operation,
+		"changes":            changes,
+		"object1":            typ,
+		"object2":            "",
+		"object_association": association,
+		"summary_fields":     summary,
+	}
+	if other != nil {
+		otherType, _ := other["type"].(string)
+		entry["object2"] = otherType
+		summary[otherType] = []interface{}{summarize(other)}
+	}
+
+	id := s.nextID("activity_stream")
+	entry["id"] = id
+	entry["type"] = "activity_stream"
+	entry["url"] = "/api/v2/" + path("activity_stream", id)
+	s.activity = append(s.activity, entry)
+
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// changes returns the fields of an object as recorded for a create or delete
+// in the activity stream.
+func changes(obj object) map[string]interface{} {
+	c := make(map[string]interface{})
+	for name, v := range obj {
+		switch name {
+		case "url", "related", "summary_fields", "created", "modified":
+			continue
+		}
+		c[name] = v
+	}
+	return c
+}
+
+// lookup returns an object of a collection, or the response and error AWX
+// would answer a request for it with. It must be called with s.mu held.
+func (s *state) lookup(method, collection string, id int) (object, *awx.Response, error) {
+	obj := s.objects[collection][id]
+	if obj == nil {
+		resp, err := notFound(method, path(collection, id))
+		return nil, resp, err
+	}
+	return obj, nil, nil
+}
+
+func list[T any](f *Fake, collection string) ([]T, *awx.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	out, err := decodeAll[T](f.sorted(collection))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return out, response(http.MethodGet, collection+"/", http.StatusOK), nil
+}
+
+func decodeAll[T any](objs []object) ([]T, error) {
+	out := make([]T, 0, len(objs))
+	for _, obj := range objs {
+		var v T
+		if err := decode(obj, &v); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func get[T any](f *Fake, collection, arg string, id int) (*T, *awx.Response, error) {
+	if err := checkID(arg, id); err != nil {
+		return nil, nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	obj, resp, err := f.lookup(http.MethodGet, collection, id)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	out := new(T)
+	if err := decode(obj, out); err != nil {
+		return nil, nil, err
+	}
+
+	return out, response(http.MethodGet, path(collection, id), http.StatusOK), nil
+}
+
+func create[T any, R any](f *Fake, collection, arg string, createRequest *R) (*T, *awx.Response, error) {
+	if createRequest == nil {
+		return nil, nil, awx.NewArgError(arg, "cannot be nil")
+	}
+	values, err := encode(createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+	delete(values, "id")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	obj := f.insert(collection, values)
+	f.track(awx.ActivityCreate, obj, changes(obj), nil, "")
+
+	out := new(T)
+	if err := decode(obj, out); err != nil {
+		return nil, nil, err
+	}
+
+	return out, response(http.MethodPost, collection+"/", http.StatusCreated), nil
+}
+
+func update[R any](f *Fake, collection, arg string, createRequest *R, idArg string, id int) (*awx.Response, error) {
+	if err := checkID(idArg, id); err != nil {
+		return nil, err
+	}
+	if createRequest == nil {
+		return nil, awx.NewArgError(arg, "cannot be nil")
+	}
+	values, err := encode(createRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	obj, resp, err := f.lookup(http.MethodPatch, collection, id)
+	if err != nil {
+		return resp, err
+	}
+	f.patch(obj, values)
+
+	return response(http.MethodPatch, path(collection, id), http.StatusOK), nil
+}
+
+// patch sets the fields of obj to values, except those AWX sets itself, and
+// records the change in the activity stream. It must be called with s.mu
+// held.
+func (s *state) patch(obj object, values object) {
+	changed := make(map[string]interface{})
+	for name, v := range values {
+		switch name {
+		case "id", "type", "url", "created", "modified":
+			continue
+		}
+		if old, ok := obj[name]; !ok || !reflect.DeepEqual(old, v) {
+			changed[name] = []interface{}{old, v}
+		}
+		obj[name] = v
+	}
+	obj["modified"] = now()
+	if len(changed) > 0 {
+		s.track(awx.ActivityUpdate, obj, changed, nil, "")
+	}
+}
+
+func remove(f *Fake, collection, arg string, id int) (*awx.Response, error) {
+	if err := checkID(arg, id); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	obj, resp, err := f.lookup(http.MethodDelete, collection, id)
+	if err != nil {
+		return resp, err
+	}
+
+	delete(f.objects[collection], id)
+	delete(f.surveys, path(collection, id))
+	for key, rel := range f.related {
+		if strings.HasPrefix(key, path(collection, id)) {
+			delete(f.related, key)
+		} else if rel.collection == collection {
+			rel.ids = without(rel.ids, id)
+		}
+	}
+	f.track(awx.ActivityDelete, obj, changes(obj), nil, "")
+
+	return response(http.MethodDelete, path(collection, id), http.StatusNoContent), nil
+}
+
+func without(ids []int, id int) []int {
+	out := ids[:0]
+	for _, other := range ids {
+		if other != id {
+			out = append(out, other)
+		}
+	}
+	return out
+}
+
+// members returns the objects related to the object with the given ID, such
+// as the labels of a job template, in order. It must be called with s.mu
+// held.
+func (s *state) members(collection string, id int, name string) []object {
+	rel := s.related[path(collection, id)+name+"/"]
+	if rel == nil {
+		return nil
+	}
+	var objs []object
+	for _, memberID := range rel.ids {
+		if obj := s.objects[rel.collection][memberID]; obj != nil {
+			objs = append(objs, obj)
+		}
+	}
+	return objs
+}
+
+func listRelated[T any](f *Fake, collection, arg string, id int, name string) ([]T, *awx.Response, error) {
+	if err := checkID(arg, id); err != nil {
+		return nil, nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, resp, err := f.lookup(http.MethodGet, collection, id); err != nil {
+		return nil, resp, err
+	}
+
+	out, err := decodeAll[T](f.members(collection, id, name))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return out, response(http.MethodGet, path(collection, id)+name+"/", http.StatusOK), nil
+}
+
+// associate adds the object memberID of collection name, e.g. a label, to
+// the related collection of that name of an object. It must be called with
+// s.mu held.
+func (s *state) associate(collection string, id int, name string, memberID int) (*awx.Response, error) {
+	p := path(collection, id) + name + "/"
+
+	obj, resp, err := s.lookup(http.MethodPost, collection, id)
+	if err != nil {
+		return resp, err
+	}
+	member := s.objects[name][memberID]
+	if member == nil {
+		return errorResponse(http.MethodPost, p, http.StatusBadRequest, fmt.Sprintf("Object with id %d does not exist.", memberID))
+	}
+
+	rel := s.related[p]
+	if rel == nil {
+		rel = &relation{collection: name}
+		s.related[p] = rel
+	}
+	for _, other := range rel.ids {
+		if other == memberID {
+			return response(http.MethodPost, p, http.StatusNoContent), nil
+		}
+	}
+	rel.ids = append(rel.ids, memberID)
+	s.track(awx.ActivityAssociate, obj, nil, member, name)
+
+	return response(http.MethodPost, p, http.StatusNoContent), nil
+}
+
+// disassociate removes the object memberID from the related collection name
+// of an object. It must be called with s.mu held.
+func (s *state) disassociate(collection string, id int, name string, memberID int) (*awx.Response, error) {
+	p := path(collection, id) + name + "/"
+
+	obj, resp, err := s.lookup(http.MethodPost, collection, id)
+	if err != nil {
+		return resp, err
+	}
+
+	rel := s.related[p]
+	if rel == nil {
+		return response(http.MethodPost, p, http.StatusNoContent), nil
+	}
+	before := len(rel.ids)
+	rel.ids = without(rel.ids, memberID)
+	if len(rel.ids) < before {
+		s.track(awx.ActivityDisassociate, obj, nil, s.objects[name][memberID], name)
+	}
+
+	return response(http.MethodPost, p, http.StatusNoContent), nil
+}
+
+func associate(f *Fake, collection, arg string, id int, name, memberArg string, memberID int) (*awx.Response, error) {
+	if err := checkID(arg, id); err != nil {
+		return nil, err
+	}
+	if err := checkID(memberArg, memberID); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.associate(collection, id, name, memberID)
+}
+
+func disassociate(f *Fake, collection, arg string, id int, name, memberArg string, memberID int) (*awx.Response, error) {
+	if err := checkID(arg, id); err != nil {
+		return nil, err
+	}
+	if err := checkID(memberArg, memberID); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.disassociate(collection, id, name, memberID)
+}
+
+// setRelated replaces the members of a related collection with ids, in
+// order.
+func setRelated(f *Fake, collection, arg string, id int, name string, ids []int) (*awx.Response, error) {
+	if err := checkID(arg, id); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, resp, err := f.lookup(http.MethodPost, collection, id); err != nil {
+		return resp, err
+	}
+
+	for _, member := range f.members(collection, id, name) {
+		if resp, err := f.disassociate(collection, id, name, member.id()); err != nil {
+			return resp, err
+		}
+	}
+	var resp *awx.Response
+	for _, memberID := range ids {
+		var err error
+		if resp, err = f.associate(collection, id, name, memberID); err != nil {
+			return resp, err
+		}
+	}
+	if resp == nil {
+		resp = response(http.MethodPost, path(collection, id)+name+"/", http.StatusNoContent)
+	}
+
+	return resp, nil
+}
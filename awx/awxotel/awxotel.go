@@ -0,0 +1,298 @@
+// Package awxotel provides OpenTelemetry tracing and metrics for the AWX
+// client as awx.Middleware.
+//
+//	mw, err := awxotel.Middleware()
+//	if err != nil {
+//		return err
+//	}
+//	client, err := awx.New(httpClient, awx.WithMiddleware(mw))
+//
+// Every request gets a client span named after its method and route
+// template, e.g. "GET api/v2/job_templates/{id}/", and is counted and timed.
+// Requests that start a job, such as a job template launch or an inventory
+// source update, are remembered so the spans of later requests polling that
+// job link back to the span that launched it.
+package awxotel
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sparkacus/awx-go-client/awx"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/sparkacus/awx-go-client/awx/awxotel"
+
+// maxLaunches bounds the number of launched jobs remembered for span links.
+const maxLaunches = 1024
+
+// jobCollections maps the type of a job to the API collection it lives in.
+var jobCollections = map[string]string{
+	"job":              "jobs",
+	"project_update":   "project_updates",
+	"inventory_update": "inventory_updates",
+	"workflow_job":     "workflow_jobs",
+	"ad_hoc_command":   "ad_hoc_commands",
+	"system_job":       "system_jobs",
+}
+
+// Option configures the Middleware.
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithTracerProvider sets the TracerProvider spans are created with. It
+// defaults to the global TracerProvider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the MeterProvider instruments are created with. It
+// defaults to the global MeterProvider.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+type instrumentation struct {
+	tracer   trace.Tracer
+	requests metric.Int64Counter
+	duration metric.Float64Histogram
+
+	mu       sync.Mutex
+	launches map[string]trace.SpanContext
+	order    []string
+}
+
+// Middleware returns awx.Middleware that traces and measures every request.
+func Middleware(opts ...Option) (awx.Middleware, error) {
+	cfg := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	meter := cfg.meterProvider.Meter(instrumentationName)
+	requests, err := meter.Int64Counter("awx.client.requests",
+		metric.WithDescription("Number of requests sent to the AWX API."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+	duration, err := meter.Float64Histogram("awx.client.request.duration",
+		metric.WithDescription("Duration of requests sent to the AWX API."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	inst := &instrumentation{
+		tracer:   cfg.tracerProvider.Tracer(instrumentationName),
+		requests: requests,
+		duration: duration,
+		launches: make(map[string]trace.SpanContext),
+	}
+
+	return inst.middleware, nil
+}
+
+func (inst *instrumentation) middleware(next awx.RoundTripFunc) awx.RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		route := Route(req.URL.Path)
+		attrs := []attribute.KeyValue{
+			attribute.String("http.request.method", req.Method),
+			attribute.String("http.route", route),
+			attribute.String("server.address", req.URL.Hostname()),
+		}
+
+		startOpts := []trace.SpanStartOption{
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attrs...),
+		}
+		if launch, ok := inst.launchFor(req.URL.Path); ok {
+			startOpts = append(startOpts, trace.WithLinks(trace.Link{
+				SpanContext: launch,
+				Attributes:  []attribute.KeyValue{attribute.String("awx.link", "launch")},
+			}))
+		}
+
+		ctx, span := inst.tracer.Start(req.Context(), req.Method+" "+route, startOpts...)
+		defer span.End()
+
+		start := time.Now()
+		resp, err := next(req.WithContext(ctx))
+		elapsed := time.Since(start).Seconds()
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			attrs = append(attrs, attribute.String("error.type", fmt.Sprintf("%T", err)))
+		} else {
+			status := attribute.Int("http.response.status_code", resp.StatusCode)
+			span.SetAttributes(status)
+			attrs = append(attrs, status)
+			if resp.StatusCode >= 400 {
+				span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+				attrs = append(attrs, attribute.String("error.type", strconv.Itoa(resp.StatusCode)))
+			} else if req.Method == http.MethodPost {
+				inst.rememberLaunch(span, resp)
+			}
+		}
+
+		set := metric.WithAttributes(attrs...)
+		inst.requests.Add(ctx, 1, set)
+		inst.duration.Record(ctx, elapsed, set)
+
+		return resp, err
+	}
+}
+
+// rememberLaunch records the span of a request that started a job, so that
+// spans of later requests for the job can link to it.
+func (inst *instrumentation) rememberLaunch(span trace.Span, resp *http.Response) {
+	if resp.Body == nil || !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		return
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+
+	var job struct {
+		ID   int    `json:"id"`
+		Type string `json:"type"`
+	}
+	if json.Unmarshal(body, &job) != nil || job.ID == 0 {
+		return
+	}
+	collection, ok := jobCollections[job.Type]
+	if !ok {
+		return
+	}
+
+	key := fmt.Sprintf("%s/%d", collection, job.ID)
+	span.SetAttributes(attribute.String("awx.job", key))
+
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+
+	if _, ok := inst.launches[key]; !ok {
+		inst.order = append(inst.order, key)
+	}
+	inst.launches[key] = span.SpanContext()
+	for len(inst.order) > maxLaunches {
+		delete(inst.launches, inst.order[0])
+		inst.order = inst.order[1:]
+	}
+}
+
+// launchFor returns the span that launched the job a request path refers to.
+func (inst *instrumentation) launchFor(path string) (trace.SpanContext, bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if _, err := strconv.Atoi(segments[i+1]); err != nil {
+			continue
+		}
+
+		inst.mu.Lock()
+		launch, ok := inst.launches[segments[i]+"/"+segments[i+1]]
+		inst.mu.Unlock()
+		if ok {
+			return launch, true
+		}
+	}
+	return trace.SpanContext{}, false
+}
+
+// collections are the API collections whose objects are addressed by ID or
+// named URL, as top-level endpoints or as related collections.
+var collections = map[string]bool{
+	"activity_stream":             true,
+	"ad_hoc_commands":             true,
+	"applications":                true,
+	"credential_input_sources":    true,
+	"credential_types":            true,
+	"credentials":                 true,
+	"execution_environments":      true,
+	"groups":                      true,
+	"hosts":                       true,
+	"instance_groups":             true,
+	"instances":                   true,
+	"inventories":                 true,
+	"inventory_sources":           true,
+	"inventory_updates":           true,
+	"job_events":                  true,
+	"job_host_summaries":          true,
+	"job_templates":               true,
+	"jobs":                        true,
+	"labels":                      true,
+	"notification_templates":      true,
+	"notifications":               true,
+	"organizations":               true,
+	"project_updates":             true,
+	"projects":                    true,
+	"roles":                       true,
+	"schedules":                   true,
+	"system_job_templates":        true,
+	"system_jobs":                 true,
+	"teams":                       true,
+	"tokens":                      true,
+	"unified_job_templates":       true,
+	"unified_jobs":                true,
+	"users":                       true,
+	"workflow_approvals":          true,
+	"workflow_job_nodes":          true,
+	"workflow_job_template_nodes": true,
+	"workflow_job_templates":      true,
+	"workflow_jobs":               true,
+}
+
+// Route returns the route template of an AWX API path, with the segment
+// after a collection name, such as a numeric ID or a named URL like
+// "deploy++Default" or "Default", replaced by "{id}", e.g.
+// "api/v2/job_templates/{id}/launch/". Numeric IDs and named URLs in other
+// places are replaced as well, so routes stay few whatever the path.
+func Route(path string) string {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		if (i > 0 && collections[segments[i-1]]) || isObjectID(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// isObjectID reports whether a path segment identifies an object, by its
+// numeric ID or by a named URL, whose parts are joined with "++".
+func isObjectID(segment string) bool {
+	if _, err := strconv.Atoi(segment); err == nil {
+		return true
+	}
+	return strings.Contains(segment, "++")
+}
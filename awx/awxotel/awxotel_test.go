@@ -0,0 +1,276 @@
+package awxotel
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sparkacus/awx-go-client/awx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRoute(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/api/v2/job_templates/", want: "api/v2/job_templates/"},
+		{path: "/api/v2/job_templates/12/launch/", want: "api/v2/job_templates/{id}/launch/"},
+		{path: "/api/v2/job_templates/deploy++Default/launch/", want: "api/v2/job_templates/{id}/launch/"},
+		{path: "/api/v2/hosts/web01++prod++Default/", want: "api/v2/hosts/{id}/"},
+		{path: "/api/v2/jobs/7/job_events/", want: "api/v2/jobs/{id}/job_events/"},
+		{path: "/api/v2/organizations/Default/", want: "api/v2/organizations/{id}/"},
+		{path: "/api/v2/inventories/web hosts++Default/", want: "api/v2/inventories/{id}/"},
+		{path: "/api/v2/job_templates/deploy/launch/", want: "api/v2/job_templates/{id}/launch/"},
+		{path: "/api/v2/inventories/2/hosts/", want: "api/v2/inventories/{id}/hosts/"},
+		{path: "/api/v2/settings/jobs/", want: "api/v2/settings/jobs/"},
+		{path: "/api/v2/ping/", want: "api/v2/ping/"},
+	}
+
+	for _, tt := range tests {
+		if got := Route(tt.path); got != tt.want {
+			t.Errorf("Route(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+// instrumentedClient returns a Client sending requests to mux through the
+// Middleware, and the span recorder and metric reader it reports to.
+func instrumentedClient(t *testing.T, mux *http.ServeMux) (*awx.Client, *tracetest.SpanRecorder, *sdkmetric.ManualReader, *httptest.Server) {
+	t.Helper()
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	mw, err := Middleware(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	if err != nil {
+		t.Fatalf("Middleware: %v", err)
+	}
+
+	client, err := awx.New(server.Client(), awx.WithMiddleware(mw))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	return client, spans, reader, server
+}
+
+func writeJSON(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	io.WriteString(w, body)
+}
+
+func spanAttrs(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestSpanAttributes(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/organizations/Default/", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{"id": 1, "name": "Default"}`)
+	})
+	client, spans, _, server := instrumentedClient(t, mux)
+
+	ctx := context.Background()
+	req, err := client.NewRequest(ctx, http.MethodGet, "organizations/Default/", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(ended))
+	}
+	span := ended[0]
+	if span.Name() != "GET api/v2/organizations/{id}/" {
+		t.Errorf("span name = %q, want the route template", span.Name())
+	}
+	if span.SpanKind() != trace.SpanKindClient {
+		t.Errorf("span kind = %v, want client", span.SpanKind())
+	}
+	serverURL, _ := url.Parse(server.URL)
+	attrs := spanAttrs(span)
+	want := map[attribute.Key]attribute.Value{
+		"http.request.method":       attribute.StringValue("GET"),
+		"http.route":                attribute.StringValue("api/v2/organizations/{id}/"),
+		"server.address":            attribute.StringValue(serverURL.Hostname()),
+		"http.response.status_code": attribute.IntValue(http.StatusOK),
+	}
+	for key, value := range want {
+		if attrs[key] != value {
+			t.Errorf("attribute %s = %v, want %v", key, attrs[key].Emit(), value.Emit())
+		}
+	}
+	if span.Status().Code != codes.Unset {
+		t.Errorf("status = %v, want unset", span.Status())
+	}
+}
+
+func TestSpanStatus(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/job_templates/8/", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, `{"detail": "Not found."}`)
+	})
+	client, spans, _, server := instrumentedClient(t, mux)
+	ctx := context.Background()
+
+	if _, _, err := client.JobTemplate.Get(ctx, 8); err == nil {
+		t.Fatalf("Get(8) succeeded, want the 404")
+	}
+	server.Close()
+	if _, _, err := client.JobTemplate.Get(ctx, 9); err == nil {
+		t.Fatalf("Get(9) succeeded, want a transport error")
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("recorded %d spans, want 2", len(ended))
+	}
+	notFound, failed := ended[0], ended[1]
+	if notFound.Status().Code != codes.Error || notFound.Status().Description != "Not Found" {
+		t.Errorf("404 status = %+v, want an error", notFound.Status())
+	}
+	if len(notFound.Events()) != 0 {
+		t.Errorf("404 recorded %d events, want none", len(notFound.Events()))
+	}
+	if failed.Status().Code != codes.Error {
+		t.Errorf("transport error status = %+v, want an error", failed.Status())
+	}
+	if events := failed.Events(); len(events) != 1 || events[0].Name != "exception" {
+		t.Errorf("transport error events = %+v, want the recorded error", events)
+	}
+	if _, ok := spanAttrs(failed)["http.response.status_code"]; ok {
+		t.Errorf("transport error span has a status code")
+	}
+}
+
+func TestRequestMetrics(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/job_templates/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/8/") {
+			writeJSON(w, http.StatusNotFound, `{"detail": "Not found."}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"id": 7, "name": "deploy"}`)
+	})
+	client, _, reader, _ := instrumentedClient(t, mux)
+	ctx := context.Background()
+
+	for _, id := range []int{7, 7, 8} {
+		client.JobTemplate.Get(ctx, id)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	counts := make(map[string]map[string]uint64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			counts[m.Name] = make(map[string]uint64)
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					counts[m.Name][statusOf(dp.Attributes)] += uint64(dp.Value)
+				}
+			case metricdata.Histogram[float64]:
+				if m.Unit != "s" {
+					t.Errorf("%s unit = %q, want s", m.Name, m.Unit)
+				}
+				for _, dp := range data.DataPoints {
+					counts[m.Name][statusOf(dp.Attributes)] += dp.Count
+					if dp.Sum <= 0 {
+						t.Errorf("%s sum = %v, want the time spent", m.Name, dp.Sum)
+					}
+				}
+			}
+		}
+	}
+
+	want := map[string]uint64{"200": 2, "404": 1}
+	for _, name := range []string{"awx.client.requests", "awx.client.request.duration"} {
+		if !reflect.DeepEqual(counts[name], want) {
+			t.Errorf("%s by status = %v, want %v", name, counts[name], want)
+		}
+	}
+}
+
+func statusOf(attrs attribute.Set) string {
+	route, _ := attrs.Value("http.route")
+	if route.AsString() != "api/v2/job_templates/{id}/" {
+		return "route " + route.AsString()
+	}
+	status, _ := attrs.Value("http.response.status_code")
+	return status.Emit()
+}
+
+func TestLaunchLinks(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/inventory_sources/3/update/", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusAccepted, `{"id": 42, "type": "inventory_update", "status": "pending"}`)
+	})
+	mux.HandleFunc("/api/v2/inventory_updates/42/", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{"id": 42, "type": "inventory_update", "status": "running"}`)
+	})
+	mux.HandleFunc("/api/v2/inventory_updates/43/", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{"id": 43, "type": "inventory_update", "status": "running"}`)
+	})
+	client, spans, _, _ := instrumentedClient(t, mux)
+	ctx := context.Background()
+
+	update, _, err := client.InventorySource.Sync(ctx, 3)
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if update.ID != 42 {
+		t.Errorf("Sync = update %d, want the body left readable", update.ID)
+	}
+	for _, id := range []int{42, 43} {
+		if _, _, err := client.InventoryUpdate.Get(ctx, id); err != nil {
+			t.Fatalf("Get(%d): %v", id, err)
+		}
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 3 {
+		t.Fatalf("recorded %d spans, want 3", len(ended))
+	}
+	launch, poll, other := ended[0], ended[1], ended[2]
+	if got := spanAttrs(launch)["awx.job"]; got.AsString() != "inventory_updates/42" {
+		t.Errorf("launch awx.job = %q, want inventory_updates/42", got.AsString())
+	}
+	links := poll.Links()
+	if len(links) != 1 || links[0].SpanContext.SpanID() != launch.SpanContext().SpanID() {
+		t.Fatalf("poll links = %+v, want the launch span", links)
+	}
+	if attrs := links[0].Attributes; len(attrs) != 1 || attrs[0] != attribute.String("awx.link", "launch") {
+		t.Errorf("link attributes = %v, want awx.link=launch", attrs)
+	}
+	if len(other.Links()) != 0 {
+		t.Errorf("span of another job has links %+v, want none", other.Links())
+	}
+}
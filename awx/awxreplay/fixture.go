@@ -0,0 +1,238 @@
+// Package awxreplay records requests to a real AWX into fixture files and
+// replays them offline, for regression tests of code built on awx.Client.
+//
+// Both the Recorder and the Replayer are http.RoundTrippers, used through
+// the *http.Client passed to awx.New:
+//
+//	rec := awxreplay.NewRecorder(nil)
+//	client, _ := awx.New(&http.Client{Transport: rec}, awx.WithAPIRoot("api/v2/"))
+//	... // make requests
+//	err := rec.Save("testdata/job_templates.json")
+//
+// and later, without access to AWX:
+//
+//	replay, err := awxreplay.Load("testdata/job_templates.json")
+//	client, _ := awx.New(&http.Client{Transport: replay})
+//
+// Secrets are scrubbed before they are recorded: credentials and cookies are
+// removed from headers, and the values of passwords, keys, tokens and other
+// secret fields are replaced in bodies as awx.Redact does. The scheme and host
+// of AWX are not recorded either, so fixtures replay against any base URL.
+package awxreplay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sparkacus/awx-go-client/awx"
+)
+
+// versionHeader is the header AWX reports its version in.
+const versionHeader = "X-API-Product-Version"
+
+const redacted = "REDACTED"
+
+// secretHeaders are the headers whose values are never recorded.
+var secretHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Csrftoken",
+}
+
+// Fixture is the content of a fixture file.
+type Fixture struct {
+	// Version is the version of AWX the interactions were recorded from,
+	// if it reported one.
+	Version string `json:"version,omitempty"`
+	// Recorded is the time the fixture was saved.
+	Recorded     time.Time     `json:"recorded"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and the response AWX sent to it.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. URL holds the path and query only.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is a recorded request or response body. In fixture files it is an
+// object with the kind of the body and its data: JSON objects and arrays are
+// kept as JSON, of kind "json", so they can be read and diffed; anything
+// else, such as the plain text output of a job, is kept as a string, of kind
+// "text".
+type Body []byte
+
+// Kinds of recorded bodies.
+const (
+	bodyJSON = "json"
+	bodyText = "text"
+)
+
+// encodedBody is a Body as it is stored in fixture files.
+type encodedBody struct {
+	Kind string          `json:"kind"`
+	Data json.RawMessage `json:"data"`
+}
+
+// MarshalJSON encodes the body with its kind.
+func (b Body) MarshalJSON() ([]byte, error) {
+	if isJSONDocument(b) {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, b); err != nil {
+			return nil, err
+		}
+		return json.Marshal(encodedBody{Kind: bodyJSON, Data: buf.Bytes()})
+	}
+
+	data, err := json.Marshal(string(b))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(encodedBody{Kind: bodyText, Data: data})
+}
+
+// UnmarshalJSON decodes a body encoded by MarshalJSON.
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var encoded encodedBody
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return fmt.Errorf("awxreplay: invalid body: %w", err)
+	}
+
+	switch encoded.Kind {
+	case bodyJSON:
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, encoded.Data); err != nil {
+			return fmt.Errorf("awxreplay: invalid JSON body: %w", err)
+		}
+		*b = append((*b)[:0], buf.Bytes()...)
+	case bodyText:
+		var s string
+		if err := json.Unmarshal(encoded.Data, &s); err != nil {
+			return fmt.Errorf("awxreplay: invalid text body: %w", err)
+		}
+		*b = Body(s)
+	default:
+		return fmt.Errorf("awxreplay: unknown body kind %q", encoded.Kind)
+	}
+	return nil
+}
+
+// isJSONDocument reports whether a body is a JSON object or array. Other
+// JSON values, such as a quoted string, are kept as text so that they are
+// replayed byte for byte.
+func isJSONDocument(b []byte) bool {
+	trimmed := bytes.TrimSpace(b)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return false
+	}
+	return json.Valid(trimmed)
+}
+
+// ReadFixture reads a fixture file.
+func ReadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fixture := new(Fixture)
+	if err := json.Unmarshal(data, fixture); err != nil {
+		return nil, fmt.Errorf("awxreplay: %s: %w", path, err)
+	}
+	return fixture, nil
+}
+
+// WriteFile writes the fixture to path, creating its directory if needed.
+func (f *Fixture) WriteFile(path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// scrubHeader returns a copy of h without secrets and without the headers
+// that change on every request.
+func scrubHeader(h http.Header) http.Header {
+	scrubbed := make(http.Header, len(h))
+	for name, values := range h {
+		switch http.CanonicalHeaderKey(name) {
+		case "Date", "Content-Length", "User-Agent", "Accept-Encoding":
+			continue
+		}
+		scrubbed[name] = append([]string(nil), values...)
+	}
+	for _, name := range secretHeaders {
+		if scrubbed.Get(name) != "" {
+			scrubbed.Set(name, redacted)
+		}
+	}
+	if len(scrubbed) == 0 {
+		return nil
+	}
+	return scrubbed
+}
+
+// scrubBody returns a body with the values of secret fields replaced. Text
+// bodies are recorded as they are.
+func scrubBody(body []byte) Body {
+	if len(body) == 0 {
+		return nil
+	}
+	if !isJSONDocument(body) {
+		return Body(body)
+	}
+	return Body(awx.Redact(body))
+}
+
+// requestURL returns the path and query of a request URL.
+func requestURL(req *http.Request) string {
+	u := req.URL.EscapedPath()
+	if req.URL.RawQuery != "" {
+		u += "?" + req.URL.RawQuery
+	}
+	return u
+}
+
+// sameBody reports whether two bodies are equal, ignoring the formatting and
+// key order of JSON bodies.
+func sameBody(a, b []byte) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return strings.TrimSpace(string(a)) == strings.TrimSpace(string(b))
+	}
+	ca, _ := json.Marshal(va)
+	cb, _ := json.Marshal(vb)
+	return bytes.Equal(ca, cb)
+}
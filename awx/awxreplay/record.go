@@ -0,0 +1,118 @@
+package awxreplay
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Recorder is an http.RoundTripper that sends requests to AWX and records
+// each request with its response, scrubbed of secrets. It is safe for
+// concurrent use.
+type Recorder struct {
+	transport http.RoundTripper
+	scrub     []func(*Interaction)
+
+	mu           sync.Mutex
+	version      string
+	interactions []Interaction
+}
+
+// RecorderOption configures a Recorder.
+type RecorderOption func(*Recorder)
+
+// WithScrubber adds a function that scrubs each interaction before it is
+// recorded, after the built-in scrubbing, e.g. to replace host names or
+// usernames that should not end up in fixture files.
+func WithScrubber(scrub func(*Interaction)) RecorderOption {
+	return func(r *Recorder) {
+		r.scrub = append(r.scrub, scrub)
+	}
+}
+
+// NewRecorder returns a Recorder sending requests with transport, or with
+// http.DefaultTransport if it is nil.
+func NewRecorder(transport http.RoundTripper, opts ...RecorderOption) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	r := &Recorder{transport: transport}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// RoundTrip sends req and records it with its response. Requests that fail
+// without a response are not recorded.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	if err != nil {
+		return resp, err
+	}
+
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    requestURL(req),
+			Header: scrubHeader(req.Header),
+			Body:   scrubBody(reqBody),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header),
+			Body:       scrubBody(respBody),
+		},
+	}
+	for _, scrub := range r.scrub {
+		scrub(&interaction)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if v := resp.Header.Get(versionHeader); v != "" && r.version == "" {
+		r.version = v
+	}
+	r.interactions = append(r.interactions, interaction)
+
+	return resp, nil
+}
+
+// Fixture returns the interactions recorded so far, in order.
+func (r *Recorder) Fixture() *Fixture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &Fixture{
+		Version:      r.version,
+		Recorded:     time.Now().UTC().Truncate(time.Second),
+		Interactions: append([]Interaction(nil), r.interactions...),
+	}
+}
+
+// Save writes the interactions recorded so far to a fixture file at path.
+func (r *Recorder) Save(path string) error {
+	return r.Fixture().WriteFile(path)
+}
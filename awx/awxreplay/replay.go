@@ -0,0 +1,116 @@
+package awxreplay
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Replayer is an http.RoundTripper that answers requests with the responses
+// of a Fixture instead of sending them. Each request is answered with the
+// first interaction not yet replayed that has the same method, path, query
+// and body, so a request made several times, such as a poll of a running
+// job, gets the responses recorded for it in order. It is safe for
+// concurrent use.
+type Replayer struct {
+	fixture *Fixture
+
+	mu   sync.Mutex
+	used []bool
+}
+
+// NewReplayer returns a Replayer answering requests from fixture.
+func NewReplayer(fixture *Fixture) *Replayer {
+	return &Replayer{fixture: fixture, used: make([]bool, len(fixture.Interactions))}
+}
+
+// Load returns a Replayer answering requests from the fixture file at path.
+func Load(path string) (*Replayer, error) {
+	fixture, err := ReadFixture(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplayer(fixture), nil
+}
+
+// Version returns the version of AWX the fixture was recorded from.
+func (r *Replayer) Version() string {
+	return r.fixture.Version
+}
+
+// RoundTrip answers req with the matching recorded response. It returns an
+// error if there is none left.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+	body = scrubBody(body)
+	u := requestURL(req)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.fixture.Interactions {
+		if r.used[i] || interaction.Request.Method != req.Method || interaction.Request.URL != u {
+			continue
+		}
+		if !sameBody(interaction.Request.Body, body) {
+			continue
+		}
+
+		r.used[i] = true
+		return newResponse(req, interaction.Response), nil
+	}
+
+	return nil, fmt.Errorf("awxreplay: no recorded response left for %s %s", req.Method, u)
+}
+
+func newResponse(req *http.Request, recorded Response) *http.Response {
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+}
+
+// Unused returns the interactions that have not been replayed yet, so tests
+// can check that every recorded request was made.
+func (r *Replayer) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, interaction := range r.fixture.Interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// Reset makes every interaction available to be replayed again.
+func (r *Replayer) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.used {
+		r.used[i] = false
+	}
+}
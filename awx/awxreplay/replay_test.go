@@ -0,0 +1,192 @@
+package awxreplay_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sparkacus/awx-go-client/awx"
+	"github.com/sparkacus/awx-go-client/awx/awxreplay"
+)
+
+func TestReplayFixture(t *testing.T) {
+	replay, err := awxreplay.Load("testdata/awx-24.6.1.json")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if replay.Version() != "24.6.1" {
+		t.Errorf("Version = %q, want 24.6.1", replay.Version())
+	}
+	client, err := awx.New(&http.Client{Transport: replay})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+
+	jt, _, err := client.JobTemplate.Get(ctx, 7)
+	if err != nil {
+		t.Fatalf("JobTemplate.Get: %v", err)
+	}
+	if jt.Name != "deploy" || jt.Inventory == nil || *jt.Inventory != 2 {
+		t.Errorf("JobTemplate = %q with inventory %v, want deploy with inventory 2", jt.Name, jt.Inventory)
+	}
+	if jt.ExecutionEnvironment != nil || jt.LastJobRun != nil {
+		t.Errorf("JobTemplate: execution_environment %v, last_job_run %v, want both null", jt.ExecutionEnvironment, jt.LastJobRun)
+	}
+	if password, _ := jt.ExtraVars.Get("db_password"); password != "REDACTED" {
+		t.Errorf("JobTemplate: db_password %v, want it scrubbed", password)
+	}
+	if _, ok := jt.Extra["ask_execution_environment_on_launch"]; !ok {
+		t.Errorf("JobTemplate: newer fields not kept in Extra")
+	}
+
+	project, _, err := client.Project.Get(ctx, 3)
+	if err != nil {
+		t.Fatalf("Project.Get: %v", err)
+	}
+	if project.SummaryFields.LastJob == nil || project.SummaryFields.LastJob.ID != 40 {
+		t.Errorf("Project: last_job %+v, want project update 40", project.SummaryFields.LastJob)
+	}
+
+	inventory, _, err := client.Inventory.Get(ctx, 2)
+	if err != nil {
+		t.Fatalf("Inventory.Get: %v", err)
+	}
+	if password, _ := inventory.Variables.Get("ansible_become_password"); password != "REDACTED" {
+		t.Errorf("Inventory: ansible_become_password %v, want it scrubbed", password)
+	}
+
+	stdout, _, err := client.AdHocCommand.Stdout(ctx, 45)
+	if err != nil {
+		t.Fatalf("AdHocCommand.Stdout: %v", err)
+	}
+	if want := "\"ok\"\nweb01 | SUCCESS => {\n    \"ping\": \"pong\"\n}\n"; stdout != want {
+		t.Errorf("Stdout = %q, want %q", stdout, want)
+	}
+
+	_, _, err = client.JobTemplate.Get(ctx, 8)
+	var errResp *awx.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusNotFound {
+		t.Errorf("JobTemplate.Get(8): %v, want the recorded 404", err)
+	}
+
+	if unused := replay.Unused(); len(unused) != 0 {
+		t.Errorf("%d interactions not replayed", len(unused))
+	}
+	if _, _, err := client.JobTemplate.Get(ctx, 7); err == nil {
+		t.Errorf("JobTemplate.Get replayed twice, want an error once the interaction is used")
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	bodies := map[string]string{
+		"/text":   "hello\n",
+		"/quoted": `"x"`,
+		"/number": "1.50",
+		"/json":   `{"name": "deploy", "password": "hunter2"}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "sessionid=secret")
+		io.WriteString(w, bodies[r.URL.Path])
+	}))
+	t.Cleanup(server.Close)
+
+	rec := awxreplay.NewRecorder(server.Client().Transport)
+	recorded := make(map[string]string)
+	for path := range bodies {
+		recorded[path] = get(t, &http.Client{Transport: rec}, server.URL+path)
+	}
+
+	fixturePath := filepath.Join(t.TempDir(), "fixture.json")
+	if err := rec.Save(fixturePath); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	data, err := os.ReadFile(fixturePath)
+	if err != nil {
+		t.Fatalf("reading fixture: %v", err)
+	}
+	for _, secret := range []string{"hunter2", "sessionid"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("fixture contains %q", secret)
+		}
+	}
+
+	replay, err := awxreplay.Load(fixturePath)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	for path, body := range bodies {
+		got := get(t, &http.Client{Transport: replay}, "http://replay.example.com"+path)
+		if path == "/json" {
+			if !strings.Contains(got, `"password":"REDACTED"`) {
+				t.Errorf("replayed %s = %s, want the scrubbed body", path, got)
+			}
+			continue
+		}
+		if got != body || got != recorded[path] {
+			t.Errorf("replayed %s = %q, want %q", path, got, body)
+		}
+	}
+}
+
+func get(t *testing.T, client *http.Client, rawURL string) string {
+	t.Helper()
+
+	resp, err := client.Get(rawURL)
+	if err != nil {
+		t.Fatalf("GET %s: %v", rawURL, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading %s: %v", rawURL, err)
+	}
+	return string(body)
+}
+
+func TestBodyJSON(t *testing.T) {
+	tests := []struct {
+		body    string
+		encoded string
+	}{
+		{body: `{"a": [1, 2]}`, encoded: `{"kind":"json","data":{"a":[1,2]}}`},
+		{body: `[]`, encoded: `{"kind":"json","data":[]}`},
+		{body: `"x"`, encoded: `{"kind":"text","data":"\"x\""}`},
+		{body: `42`, encoded: `{"kind":"text","data":"42"}`},
+		{body: "plain\ntext", encoded: `{"kind":"text","data":"plain\ntext"}`},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(awxreplay.Body(tt.body))
+		if err != nil {
+			t.Fatalf("Marshal(%q): %v", tt.body, err)
+		}
+		if string(data) != tt.encoded {
+			t.Errorf("Marshal(%q) = %s, want %s", tt.body, data, tt.encoded)
+		}
+
+		var body awxreplay.Body
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", data, err)
+		}
+		want := tt.body
+		if strings.HasPrefix(tt.encoded, `{"kind":"json"`) {
+			want = strings.NewReplacer(" ", "").Replace(tt.body)
+		}
+		if string(body) != want {
+			t.Errorf("Unmarshal(%s) = %q, want %q", data, body, want)
+		}
+	}
+
+	var body awxreplay.Body
+	if err := json.Unmarshal([]byte(`{"kind":"binary","data":""}`), &body); err == nil {
+		t.Errorf("Unmarshal of an unknown kind succeeded")
+	}
+}
@@ -0,0 +1,360 @@
+package awxtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// finished reports whether a job status is final.
+func finished(status interface{}) bool {
+	switch status {
+	case "successful", "failed", "error", "canceled":
+		return true
+	}
+	return false
+}
+
+// SetJobStatus sets the status of a job in a collection such as "jobs" or
+// "project_updates", e.g. to hold it "running" or fail it, filling in the
+// times it started and finished.
+func (s *Server) SetJobStatus(collection string, id int, status string) error {
+	r, ok := resourcesByName[collection]
+	if !ok || !r.job {
+		return fmt.Errorf("awxtest: %q is not a job collection", collection)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj := s.get(r, id)
+	if obj == nil {
+		return fmt.Errorf("awxtest: %s %d not found", collection, id)
+	}
+	f, _ := r.field("status")
+	if _, msg := s.cleanValue(f, status); msg != "" {
+		return fmt.Errorf("awxtest: %s", msg)
+	}
+
+	s.setStatus(obj, status)
+	return nil
+}
+
+// advance moves a job one step through its lifecycle: pending jobs start
+// running, running jobs finish with the status set with WithJobStatus.
+func (s *Server) advance(r *resource, obj object) {
+	switch {
+	case finished(obj["status"]):
+	case obj["status"] == "running":
+		s.setStatus(obj, s.jobStatus)
+	default:
+		s.setStatus(obj, "running")
+	}
+}
+
+func (s *Server) setStatus(obj object, status string) {
+	now := time.Now().UTC()
+	obj["status"] = status
+	obj["modified"] = now.Format(time.RFC3339Nano)
+
+	if status == "running" && obj["started"] == nil {
+		obj["started"] = now.Format(time.RFC3339Nano)
+	}
+	if !finished(status) {
+		return
+	}
+
+	if obj["started"] == nil {
+		obj["started"] = now.Format(time.RFC3339Nano)
+	}
+	obj["finished"] = now.Format(time.RFC3339Nano)
+	if started, err := time.Parse(time.RFC3339Nano, obj["started"].(string)); err == nil {
+		obj["elapsed"] = now.Sub(started).Seconds()
+	}
+	obj["failed"] = status != "successful"
+	if status == "canceled" {
+		obj["canceled_on"] = obj["finished"]
+	}
+
+	// The template of a job reports the status of its last job.
+	if ujt, ok := obj["unified_job_template"].(int); ok {
+		for _, name := range unifiedResources[unifiedJobTemplates] {
+			if tmpl := s.objects[name][ujt]; tmpl != nil {
+				if _, ok := resourcesByName[name].field("status"); ok {
+					tmpl["status"] = status
+				}
+			}
+		}
+	}
+}
+
+// launch starts a job of the template obj, with the fields of the template
+// overridden by those given on launch.
+func (s *Server) launch(r *resource, tmpl object, overrides map[string]interface{}, launchType string) (*resource, object, fieldErrors) {
+	jobs := resourcesByName[r.launchJob]
+
+	values := map[string]interface{}{}
+	for _, f := range jobs.fields {
+		if f.readOnly {
+			continue
+		}
+		if v, ok := tmpl[f.name]; ok && v != nil {
+			values[f.name] = v
+		}
+	}
+	values[r.typ] = tmpl.id()
+	values["unified_job_template"] = tmpl.id()
+	values["launch_type"] = launchType
+	values["status"] = "pending"
+	if r.name == "inventory_sources" {
+		values["inventory"] = tmpl["inventory"]
+	}
+
+	for _, name := range []string{"extra_vars", "limit", "inventory", "job_type", "verbosity", "scm_branch"} {
+		v, ok := overrides[name]
+		if !ok {
+			continue
+		}
+		if _, accepted := jobs.field(name); !accepted {
+			continue
+		}
+		if name == "extra_vars" {
+			if _, isString := v.(string); !isString {
+				data, _ := json.Marshal(v)
+				v = string(data)
+			}
+		}
+		values[name] = v
+	}
+
+	job, errs := s.create(jobs, values, true)
+	if len(errs) > 0 {
+		return nil, nil, errs
+	}
+	job["_created_by__username"] = "admin"
+	s.record("create", jobs, job, changes(jobs, nil, job), nil, nil, "")
+
+	return jobs, job, nil
+}
+
+func (s *Server) serveLaunch(w http.ResponseWriter, req *http.Request, r *resource, tmpl object) {
+	switch req.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"can_start_without_user_input": true,
+			"can_update":                   true,
+			"passwords_needed_to_start":    []string{},
+			"variables_needed_to_start":    []string{},
+			"survey_enabled":               tmpl["survey_enabled"] == true,
+			"ask_variables_on_launch":      tmpl["ask_variables_on_launch"] == true,
+		})
+	case http.MethodPost:
+		overrides, ok := readBody(w, req)
+		if !ok {
+			return
+		}
+		jobs, job, errs := s.launch(r, tmpl, overrides, "manual")
+		if len(errs) > 0 {
+			writeJSON(w, http.StatusBadRequest, errs)
+			return
+		}
+
+		out := s.render(jobs, job)
+		out[jobs.typ] = job.id()
+		out["ignored_fields"] = map[string]interface{}{}
+
+		status := http.StatusCreated
+		if r.launch == "update" {
+			status = http.StatusAccepted
+		}
+		writeJSON(w, status, out)
+	default:
+		methodNotAllowed(w, req)
+	}
+}
+
+func (s *Server) serveInventorySync(w http.ResponseWriter, req *http.Request, inventory object) {
+	sources := resourcesByName["inventory_sources"]
+
+	var inventorySources []object
+	for _, src := range s.sorted(sources) {
+		if src["inventory"] == inventory.id() {
+			inventorySources = append(inventorySources, src)
+		}
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		result := []interface{}{}
+		for _, src := range inventorySources {
+			result = append(result, map[string]interface{}{"inventory_source": src.id(), "can_update": true})
+		}
+		writeJSON(w, http.StatusOK, result)
+	case http.MethodPost:
+		if len(inventorySources) == 0 {
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{"detail": "No inventory sources to update."})
+			return
+		}
+		result := []interface{}{}
+		for _, src := range inventorySources {
+			_, update, errs := s.launch(sources, src, nil, "manual")
+			if len(errs) > 0 {
+				writeJSON(w, http.StatusBadRequest, errs)
+				return
+			}
+			result = append(result, map[string]interface{}{
+				"inventory_source": src.id(),
+				"inventory_update": update.id(),
+				"status":           "started",
+			})
+		}
+		writeJSON(w, http.StatusAccepted, result)
+	default:
+		methodNotAllowed(w, req)
+	}
+}
+
+func (s *Server) serveCancel(w http.ResponseWriter, req *http.Request, r *resource, job object) {
+	canCancel := !finished(job["status"])
+
+	switch req.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"can_cancel": canCancel})
+	case http.MethodPost:
+		if !canCancel {
+			methodNotAllowed(w, req)
+			return
+		}
+		s.setStatus(job, "canceled")
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		methodNotAllowed(w, req)
+	}
+}
+
+func (s *Server) serveRelaunch(w http.ResponseWriter, req *http.Request, r *resource, job object) {
+	switch req.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"passwords_needed_to_start": []string{}, "retry_counts": map[string]interface{}{}})
+	case http.MethodPost:
+		values := map[string]interface{}{}
+		for _, f := range r.fields {
+			if !f.readOnly && job[f.name] != nil {
+				values[f.name] = job[f.name]
+			}
+		}
+		for _, name := range []string{"unified_job_template", "name", "description"} {
+			values[name] = job[name]
+		}
+		values["launch_type"] = "relaunch"
+		values["status"] = "pending"
+
+		relaunched, errs := s.create(r, values, true)
+		if len(errs) > 0 {
+			writeJSON(w, http.StatusBadRequest, errs)
+			return
+		}
+		relaunched["_created_by__username"] = "admin"
+		s.record("create", r, relaunched, changes(r, nil, relaunched), nil, nil, "")
+
+		writeJSON(w, http.StatusCreated, s.render(r, relaunched))
+	default:
+		methodNotAllowed(w, req)
+	}
+}
+
+// events returns the events a job has emitted so far.
+func (s *Server) events(r *resource, job object) []map[string]interface{} {
+	status, _ := job["status"].(string)
+	if status != "running" && !finished(status) {
+		return nil
+	}
+
+	eventType := r.typ + "_event"
+	event := func(counter int, name, stdout string, failed bool) map[string]interface{} {
+		return map[string]interface{}{
+			"id":        job.id()*1000 + counter,
+			"type":      eventType,
+			"url":       fmt.Sprintf("%s%ss/%d/", apiRoot, eventType, job.id()*1000+counter),
+			"created":   job["started"],
+			"modified":  job["started"],
+			"event":     name,
+			"counter":   counter,
+			"host_name": "localhost",
+			"failed":    failed,
+			"changed":   false,
+			"stdout":    stdout,
+			r.typ:       job.id(),
+		}
+	}
+
+	events := []map[string]interface{}{
+		event(1, "playbook_on_start", "", false),
+		event(2, "playbook_on_play_start", "\r\nPLAY [all] *********************************************************************", false),
+	}
+	if !finished(status) {
+		return events
+	}
+
+	failed := status != "successful"
+	if failed {
+		events = append(events, event(3, "runner_on_failed", "fatal: [localhost]: FAILED! => {\"changed\": false}", true))
+	} else {
+		events = append(events, event(3, "runner_on_ok", "ok: [localhost]", false))
+	}
+
+	failedCount := 0
+	if failed {
+		failedCount = 1
+	}
+	recap := fmt.Sprintf("\r\nPLAY RECAP *********************************************************************\r\n"+
+		"localhost                  : ok=%d    changed=0    unreachable=0    failed=%d", 1-failedCount, failedCount)
+	events = append(events, event(4, "playbook_on_stats", recap, false))
+
+	return events
+}
+
+func (s *Server) serveStdout(w http.ResponseWriter, req *http.Request, r *resource, job object) {
+	if req.Method != http.MethodGet {
+		methodNotAllowed(w, req)
+		return
+	}
+
+	var lines []string
+	for _, e := range s.events(r, job) {
+		if stdout := e["stdout"].(string); stdout != "" {
+			lines = append(lines, strings.TrimPrefix(stdout, "\r\n"))
+		}
+	}
+	content := strings.Join(lines, "\n")
+	if content != "" {
+		content += "\n"
+	}
+
+	if req.URL.Query().Get("format") == "json" {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"range":   map[string]interface{}{"start": 0, "end": len(lines), "absolute_end": len(lines)},
+			"content": content,
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, content)
+}
+
+func (s *Server) serveEvents(w http.ResponseWriter, req *http.Request, r *resource, job object) {
+	if req.Method != http.MethodGet {
+		methodNotAllowed(w, req)
+		return
+	}
+
+	results := []interface{}{}
+	for _, e := range s.events(r, job) {
+		results = append(results, e)
+	}
+	s.writePage(w, req, results)
+}
@@ -0,0 +1,402 @@
+package awxtest
+
+import "strings"
+
+// Field types, as reported in OPTIONS metadata.
+const (
+	typeString   = "string"
+	typeInteger  = "integer"
+	typeFloat    = "float"
+	typeBoolean  = "boolean"
+	typeChoice   = "choice"
+	typeID       = "id"
+	typeDateTime = "datetime"
+)
+
+// Shared ID sequences. AWX numbers every kind of job, and every kind of job
+// template, from a single sequence.
+const (
+	unifiedJobs         = "unified_jobs"
+	unifiedJobTemplates = "unified_job_templates"
+)
+
+// field describes a field of a resource.
+type field struct {
+	name      string
+	typ       string
+	required  bool
+	readOnly  bool
+	maxLength int
+	choices   []interface{}
+	def       interface{}
+	// ref is the resource an id field refers to, if the fake serves it.
+	ref string
+}
+
+func (f field) req() field {
+	f.required = true
+	return f
+}
+
+func (f field) ro() field {
+	f.readOnly = true
+	return f
+}
+
+func stringField(name string, maxLength int) field {
+	return field{name: name, typ: typeString, maxLength: maxLength, def: ""}
+}
+
+func textField(name string) field {
+	return field{name: name, typ: typeString, def: ""}
+}
+
+func intField(name string, def int) field {
+	return field{name: name, typ: typeInteger, def: def}
+}
+
+func boolField(name string, def bool) field {
+	return field{name: name, typ: typeBoolean, def: def}
+}
+
+func idField(name, ref string) field {
+	return field{name: name, typ: typeID, ref: ref}
+}
+
+func choiceField(name string, def interface{}, choices ...interface{}) field {
+	return field{name: name, typ: typeChoice, def: def, choices: choices}
+}
+
+func timeField(name string) field {
+	return field{name: name, typ: typeDateTime, readOnly: true}
+}
+
+// resource describes a collection served by the fake.
+type resource struct {
+	// name is the collection of the resource, e.g. "job_templates".
+	name string
+	// typ is the type of its objects, e.g. "job_template".
+	typ string
+	// sequence is the ID sequence of the resource, if shared with others.
+	sequence string
+	fields   []field
+	// named is the field objects are looked up by in named URLs.
+	named string
+	// unique are the fields whose values together identify an object; the
+	// last is the named field, any before it the scope it is unique in.
+	unique []string
+	// readOnly resources cannot be created through the API.
+	readOnly bool
+	// job resources run through the simulated job lifecycle.
+	job bool
+	// subs maps the association sublists of the resource to the resource of
+	// their members.
+	subs map[string]string
+	// launch is the action that starts a job of this resource and the job
+	// resource it creates.
+	launch    string
+	launchJob string
+}
+
+func (r *resource) field(name string) (field, bool) {
+	for _, f := range r.fields {
+		if f.name == name {
+			return f, true
+		}
+	}
+	return field{}, false
+}
+
+// title is the name AWX uses for the resource in messages.
+func (r *resource) title() string {
+	words := strings.Split(r.typ, "_")
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+var jobStatuses = []interface{}{"new", "pending", "waiting", "running", "successful", "failed", "error", "canceled"}
+
+// jobFields are the fields every kind of job has.
+func jobFields(fields ...field) []field {
+	return append([]field{
+		stringField("name", 512),
+		textField("description"),
+		choiceField("status", "pending", jobStatuses...).ro(),
+		boolField("failed", false).ro(),
+		timeField("started"),
+		timeField("finished"),
+		timeField("canceled_on"),
+		field{name: "elapsed", typ: typeFloat, def: 0.0, readOnly: true},
+		choiceField("launch_type", "manual", "manual", "relaunch", "callback", "scheduled", "dependency", "workflow", "webhook", "sync", "scm").ro(),
+		textField("job_explanation").ro(),
+		textField("execution_node").ro(),
+		idField("unified_job_template", "").ro(),
+		textField("extra_vars"),
+	}, fields...)
+}
+
+var verbosity = []interface{}{0, 1, 2, 3, 4, 5}
+
+var resources = []*resource{
+	{
+		name: "organizations", typ: "organization", named: "name", unique: []string{"name"},
+		fields: []field{
+			stringField("name", 512).req(),
+			textField("description"),
+			intField("max_hosts", 0),
+			idField("default_environment", "execution_environments"),
+		},
+		subs: map[string]string{"instance_groups": "instance_groups"},
+	},
+	{
+		name: "inventories", typ: "inventory", named: "name", unique: []string{"organization", "name"},
+		fields: []field{
+			stringField("name", 512).req(),
+			textField("description"),
+			idField("organization", "organizations").req(),
+			choiceField("kind", "", "", "smart", "constructed"),
+			textField("host_filter"),
+			textField("variables"),
+		},
+		subs: map[string]string{"instance_groups": "instance_groups"},
+	},
+	{
+		name: "inventory_sources", typ: "inventory_source", sequence: unifiedJobTemplates, named: "name", unique: []string{"inventory", "name"},
+		fields: []field{
+			stringField("name", 512).req(),
+			textField("description"),
+			idField("inventory", "inventories").req(),
+			choiceField("source", "", "", "file", "constructed", "scm", "ec2", "gce", "azure_rm", "vmware", "satellite6", "openstack", "rhv", "controller", "insights"),
+			textField("source_path"),
+			textField("source_vars"),
+			boolField("overwrite", false),
+			boolField("overwrite_vars", false),
+			boolField("update_on_launch", false),
+			intField("update_cache_timeout", 0),
+			intField("timeout", 0),
+			choiceField("verbosity", 1, 0, 1, 2),
+			idField("execution_environment", "execution_environments"),
+			choiceField("status", "never updated", "new", "pending", "waiting", "running", "successful", "failed", "error", "canceled", "never updated", "none").ro(),
+		},
+		launch: "update", launchJob: "inventory_updates",
+	},
+	{
+		name: "projects", typ: "project", sequence: unifiedJobTemplates, named: "name", unique: []string{"organization", "name"},
+		fields: []field{
+			stringField("name", 512).req(),
+			textField("description"),
+			idField("organization", "organizations"),
+			choiceField("scm_type", "", "", "git", "svn", "insights", "archive"),
+			stringField("scm_url", 1024),
+			stringField("scm_branch", 256),
+			stringField("scm_refspec", 1024),
+			boolField("scm_clean", false),
+			boolField("scm_delete_on_update", false),
+			boolField("scm_update_on_launch", false),
+			stringField("local_path", 1024),
+			idField("credential", ""),
+			idField("default_environment", "execution_environments"),
+			choiceField("status", "never updated", "new", "pending", "waiting", "running", "successful", "failed", "error", "canceled", "never updated", "ok", "missing").ro(),
+		},
+		launch: "update", launchJob: "project_updates",
+	},
+	{
+		name: "job_templates", typ: "job_template", sequence: unifiedJobTemplates, named: "name", unique: []string{"organization", "name"},
+		fields: []field{
+			stringField("name", 512).req(),
+			textField("description"),
+			choiceField("job_type", "run", "run", "check"),
+			idField("inventory", "inventories"),
+			idField("project", "projects"),
+			stringField("playbook", 1024),
+			stringField("scm_branch", 1024),
+			intField("forks", 0),
+			stringField("limit", 1024),
+			choiceField("verbosity", 0, verbosity...),
+			textField("extra_vars"),
+			stringField("job_tags", 1024),
+			stringField("skip_tags", 1024),
+			intField("timeout", 0),
+			boolField("ask_variables_on_launch", false),
+			boolField("ask_inventory_on_launch", false),
+			boolField("ask_limit_on_launch", false),
+			boolField("survey_enabled", false),
+			boolField("become_enabled", false),
+			boolField("allow_simultaneous", false),
+			idField("execution_environment", "execution_environments"),
+			idField("organization", "organizations").ro(),
+			choiceField("status", "never updated", "new", "pending", "waiting", "running", "successful", "failed", "error", "canceled", "never updated").ro(),
+		},
+		subs:   map[string]string{"labels": "labels", "instance_groups": "instance_groups"},
+		launch: "launch", launchJob: "jobs",
+	},
+	{
+		name: "workflow_job_templates", typ: "workflow_job_template", sequence: unifiedJobTemplates, named: "name", unique: []string{"organization", "name"},
+		fields: []field{
+			stringField("name", 512).req(),
+			textField("description"),
+			idField("organization", "organizations"),
+			idField("inventory", "inventories"),
+			stringField("limit", 1024),
+			stringField("scm_branch", 1024),
+			textField("extra_vars"),
+			boolField("survey_enabled", false),
+			boolField("allow_simultaneous", false),
+			boolField("ask_variables_on_launch", false),
+			choiceField("status", "never updated", "new", "pending", "waiting", "running", "successful", "failed", "error", "canceled", "never updated").ro(),
+		},
+		subs:   map[string]string{"labels": "labels"},
+		launch: "launch", launchJob: "workflow_jobs",
+	},
+	{
+		name: "system_job_templates", typ: "system_job_template", sequence: unifiedJobTemplates, named: "name", unique: []string{"name"}, readOnly: true,
+		fields: []field{
+			stringField("name", 512).req(),
+			textField("description"),
+			choiceField("job_type", "", "", "cleanup_jobs", "cleanup_activitystream", "cleanup_sessions", "cleanup_tokens"),
+		},
+		launch: "launch", launchJob: "system_jobs",
+	},
+	{
+		name: "execution_environments", typ: "execution_environment", named: "name",
+		fields: []field{
+			stringField("name", 512).req(),
+			textField("description"),
+			stringField("image", 1024).req(),
+			idField("organization", "organizations"),
+			idField("credential", ""),
+			choiceField("pull", "", "", "always", "missing", "never"),
+			boolField("managed", false).ro(),
+		},
+	},
+	{
+		name: "instance_groups", typ: "instance_group", named: "name", unique: []string{"name"},
+		fields: []field{
+			stringField("name", 250).req(),
+			intField("policy_instance_percentage", 0),
+			intField("policy_instance_minimum", 0),
+			boolField("is_container_group", false),
+			intField("max_concurrent_jobs", 0),
+			intField("max_forks", 0),
+			textField("pod_spec_override"),
+		},
+		subs: map[string]string{"instances": "instances"},
+	},
+	{
+		name: "instances", typ: "instance", named: "hostname", unique: []string{"hostname"},
+		fields: []field{
+			stringField("hostname", 250).req(),
+			choiceField("node_type", "execution", "control", "execution", "hybrid", "hop"),
+			boolField("enabled", true),
+			boolField("managed_by_policy", true),
+			stringField("capacity_adjustment", 8),
+			intField("capacity", 100).ro(),
+			choiceField("node_state", "ready", "provisioning", "provision-fail", "installed", "ready", "unavailable", "deprovisioning", "deprovision-fail").ro(),
+			textField("version").ro(),
+		},
+	},
+	{
+		name: "labels", typ: "label", named: "name", unique: []string{"organization", "name"},
+		fields: []field{
+			stringField("name", 512).req(),
+			idField("organization", "organizations").req(),
+		},
+	},
+	{
+		name: "ad_hoc_commands", typ: "ad_hoc_command", sequence: unifiedJobs, job: true,
+		fields: jobFields(
+			choiceField("job_type", "run", "run", "check"),
+			idField("inventory", "inventories").req(),
+			stringField("limit", 1024),
+			idField("credential", ""),
+			stringField("module_name", 1024),
+			textField("module_args"),
+			intField("forks", 0),
+			choiceField("verbosity", 0, verbosity...),
+			boolField("become_enabled", false),
+			boolField("diff_mode", false),
+			idField("execution_environment", "execution_environments"),
+		),
+	},
+	{
+		name: "jobs", typ: "job", sequence: unifiedJobs, job: true, readOnly: true,
+		fields: jobFields(
+			choiceField("job_type", "run", "run", "check", "scan"),
+			idField("job_template", "job_templates"),
+			idField("inventory", "inventories"),
+			idField("project", "projects"),
+			stringField("playbook", 1024),
+			stringField("limit", 1024),
+			intField("forks", 0),
+			choiceField("verbosity", 0, verbosity...),
+			idField("execution_environment", "execution_environments"),
+			idField("organization", "organizations"),
+		),
+	},
+	{
+		name: "project_updates", typ: "project_update", sequence: unifiedJobs, job: true, readOnly: true,
+		fields: jobFields(
+			idField("project", "projects"),
+			choiceField("job_type", "check", "run", "check"),
+			textField("scm_type"),
+			textField("scm_url"),
+			textField("scm_branch"),
+		),
+	},
+	{
+		name: "inventory_updates", typ: "inventory_update", sequence: unifiedJobs, job: true, readOnly: true,
+		fields: jobFields(
+			idField("inventory_source", "inventory_sources"),
+			idField("inventory", "inventories"),
+			textField("source"),
+			textField("source_path"),
+			textField("source_vars"),
+			boolField("overwrite", false),
+			boolField("overwrite_vars", false),
+		),
+	},
+	{
+		name: "workflow_jobs", typ: "workflow_job", sequence: unifiedJobs, job: true, readOnly: true,
+		fields: jobFields(
+			idField("workflow_job_template", "workflow_job_templates"),
+			idField("inventory", "inventories"),
+			stringField("limit", 1024),
+		),
+	},
+	{
+		name: "system_jobs", typ: "system_job", sequence: unifiedJobs, job: true, readOnly: true,
+		fields: jobFields(
+			idField("system_job_template", "system_job_templates"),
+			textField("job_type"),
+		),
+	},
+	{
+		name: "activity_stream", typ: "activity_stream", readOnly: true,
+		fields: []field{
+			timeField("timestamp"),
+			textField("operation").ro(),
+			textField("object1").ro(),
+			textField("object2").ro(),
+			textField("object_association").ro(),
+			textField("action_node").ro(),
+			field{name: "changes", typ: "json", readOnly: true},
+		},
+	},
+}
+
+// unifiedResources are the resources listed by the unified job and unified
+// job template endpoints.
+var unifiedResources = map[string][]string{
+	unifiedJobs:         {"jobs", "project_updates", "inventory_updates", "workflow_jobs", "ad_hoc_commands", "system_jobs"},
+	unifiedJobTemplates: {"job_templates", "workflow_job_templates", "projects", "inventory_sources", "system_job_templates"},
+}
+
+var resourcesByName = func() map[string]*resource {
+	m := make(map[string]*resource, len(resources))
+	for _, r := range resources {
+		m[r.name] = r
+	}
+	return m
+}()
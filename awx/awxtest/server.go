@@ -0,0 +1,777 @@
+// Package awxtest provides a fake AWX server for hermetic tests of code that
+// uses the awx package.
+//
+//	srv := awxtest.NewServer()
+//	defer srv.Close()
+//
+//	client, err := srv.Client()
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	org, _, err := client.Organization.Create(ctx, &awx.OrganizationCreateRequest{Name: "Default"})
+//
+// The Server emulates the v2 API in memory for the resources the awx package
+// supports: objects can be created, listed, fetched by ID or named URL,
+// updated and deleted; lists are paginated with real next URLs and can be
+// filtered with the common Django field lookups; invalid requests are
+// rejected with the field errors AWX returns; and launched jobs move from
+// pending to running to a final status each time they are fetched.
+package awxtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sparkacus/awx-go-client/awx"
+)
+
+// apiRoot is the root of the API served by the fake.
+const apiRoot = "/api/v2/"
+
+const (
+	defaultPageSize = 25
+	maxPageSize     = 200
+	defaultVersion  = "24.6.1"
+)
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithPageSize sets the number of objects per page of list responses when
+// the request does not ask for a page size. It defaults to 25.
+func WithPageSize(n int) Option {
+	return func(s *Server) {
+		if n > 0 {
+			s.pageSize = n
+		}
+	}
+}
+
+// WithVersion sets the AWX version the Server reports. It defaults to
+// "24.6.1".
+func WithVersion(version string) Option {
+	return func(s *Server) {
+		s.version = version
+	}
+}
+
+// WithJobStatus sets the status launched jobs finish with: "successful",
+// "failed" or "error". It defaults to "successful".
+func WithJobStatus(status string) Option {
+	return func(s *Server) {
+		s.jobStatus = status
+	}
+}
+
+// Server is a fake AWX server. It is safe for concurrent use.
+type Server struct {
+	// URL is the base URL of the Server, e.g. "http://127.0.0.1:36789".
+	URL string
+
+	srv       *httptest.Server
+	pageSize  int
+	version   string
+	jobStatus string
+
+	mu           sync.Mutex
+	objects      map[string]map[int]object
+	sequences    map[string]int
+	associations map[assocKey][]int
+	surveys      map[string]json.RawMessage
+	files        map[int]projectFiles
+}
+
+type projectFiles struct {
+	playbooks      []string
+	inventoryFiles []string
+}
+
+// NewServer starts and returns a new Server. Call Close when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		pageSize:     defaultPageSize,
+		version:      defaultVersion,
+		jobStatus:    "successful",
+		objects:      make(map[string]map[int]object),
+		sequences:    make(map[string]int),
+		associations: make(map[assocKey][]int),
+		surveys:      make(map[string]json.RawMessage),
+		files:        make(map[int]projectFiles),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	for _, r := range resources {
+		s.objects[r.name] = make(map[int]object)
+	}
+
+	s.srv = httptest.NewServer(s)
+	s.URL = s.srv.URL
+
+	return s
+}
+
+// Close shuts down the Server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns an awx.Client for the Server. Options are applied before
+// the base URL is pointed at the Server.
+func (s *Server) Client(opts ...awx.ClientOpt) (*awx.Client, error) {
+	c, err := awx.New(s.srv.Client(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL, err := url.Parse(s.URL + "/")
+	if err != nil {
+		return nil, err
+	}
+	c.BaseURL = baseURL
+
+	return c, nil
+}
+
+// Add stores a new object in a collection, e.g. "job_templates", as if it
+// had been created through the API, and returns its ID. Unlike the API, Add
+// accepts read-only fields such as the status of a job, and creates objects
+// in read-only collections such as "jobs". Invalid fields are reported with
+// a *ValidationError.
+func (s *Server) Add(collection string, fields map[string]interface{}) (int, error) {
+	r, ok := resourcesByName[collection]
+	if !ok {
+		return 0, fmt.Errorf("awxtest: unknown collection %q", collection)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, errs := s.create(r, normalize(fields), true)
+	if len(errs) > 0 {
+		return 0, &ValidationError{Fields: errs}
+	}
+
+	return obj.id(), nil
+}
+
+// Object returns the object with the given ID in a collection as the API
+// would return it.
+func (s *Server) Object(collection string, id int) (map[string]interface{}, bool) {
+	r, ok := resourcesByName[collection]
+	if !ok {
+		return nil, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj := s.get(r, id)
+	if obj == nil {
+		return nil, false
+	}
+
+	return copyObject(s.render(r, obj)), true
+}
+
+// Associate adds the object with ID memberID to an association sublist of
+// an object, e.g. a label to the "labels" of a job template.
+func (s *Server) Associate(collection string, id int, sublist string, memberID int) error {
+	r, ok := resourcesByName[collection]
+	if !ok {
+		return fmt.Errorf("awxtest: unknown collection %q", collection)
+	}
+	memberCollection, ok := r.subs[sublist]
+	if !ok {
+		return fmt.Errorf("awxtest: %s have no %s", collection, sublist)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj := s.get(r, id)
+	if obj == nil {
+		return fmt.Errorf("awxtest: %s %d not found", collection, id)
+	}
+	member := s.get(resourcesByName[memberCollection], memberID)
+	if member == nil {
+		return fmt.Errorf("awxtest: %s %d not found", memberCollection, memberID)
+	}
+	s.associate(r, obj, sublist, member)
+
+	return nil
+}
+
+// SetProjectFiles sets the playbooks and inventory files a project reports.
+func (s *Server) SetProjectFiles(projectID int, playbooks, inventoryFiles []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.get(resourcesByName["projects"], projectID) == nil {
+		return fmt.Errorf("awxtest: projects %d not found", projectID)
+	}
+	s.files[projectID] = projectFiles{playbooks: playbooks, inventoryFiles: inventoryFiles}
+
+	return nil
+}
+
+// normalize converts the values of fields given to Add to the types decoded
+// from JSON request bodies.
+func normalize(fields map[string]interface{}) map[string]interface{} {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return fields
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return fields
+	}
+	return out
+}
+
+// ServeHTTP serves the fake API.
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch req.URL.Path {
+	case "/api/":
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"description":        "AWX REST API",
+			"current_version":    apiRoot,
+			"available_versions": map[string]interface{}{"v2": apiRoot},
+		})
+		return
+	case apiRoot:
+		index := map[string]interface{}{"ping": apiRoot + "ping/", "config": apiRoot + "config/"}
+		for _, r := range resources {
+			index[r.name] = apiRoot + r.name + "/"
+		}
+		writeJSON(w, http.StatusOK, index)
+		return
+	}
+
+	if !strings.HasPrefix(req.URL.Path, apiRoot) {
+		notFound(w)
+		return
+	}
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(req.URL.Path, apiRoot), "/"), "/")
+
+	switch segments[0] {
+	case "ping":
+		s.servePing(w, req)
+		return
+	case "config":
+		s.serveConfig(w, req)
+		return
+	case unifiedJobs, unifiedJobTemplates:
+		if len(segments) != 1 || req.Method != http.MethodGet {
+			notFound(w)
+			return
+		}
+		s.serveList(w, req, unifiedResources[segments[0]]...)
+		return
+	}
+
+	r, ok := resourcesByName[segments[0]]
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	if len(segments) == 1 {
+		s.serveCollection(w, req, r)
+		return
+	}
+
+	obj := s.find(r, segments[1])
+	if obj == nil {
+		notFound(w)
+		return
+	}
+
+	switch len(segments) {
+	case 2:
+		s.serveObject(w, req, r, obj)
+	case 3:
+		s.serveAction(w, req, r, obj, segments[2])
+	default:
+		notFound(w)
+	}
+}
+
+func (s *Server) servePing(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		methodNotAllowed(w, req)
+		return
+	}
+
+	instances := []interface{}{}
+	for _, obj := range s.sorted(resourcesByName["instances"]) {
+		instances = append(instances, map[string]interface{}{
+			"node":      obj["hostname"],
+			"node_type": obj["node_type"],
+			"uuid":      fmt.Sprintf("00000000-0000-0000-0000-%012d", obj.id()),
+			"heartbeat": obj["modified"],
+			"capacity":  obj["capacity"],
+			"version":   s.version,
+		})
+	}
+	groups := []interface{}{}
+	groupResource := resourcesByName["instance_groups"]
+	for _, obj := range s.sorted(groupResource) {
+		names := []string{}
+		for _, instance := range s.members(groupResource, obj, "instances") {
+			names = append(names, instance["hostname"].(string))
+		}
+		groups = append(groups, map[string]interface{}{"name": obj["name"], "capacity": 100 * len(names), "instances": names})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"ha":              len(instances) > 1,
+		"version":         s.version,
+		"active_node":     "awx-1",
+		"install_uuid":    "00000000-0000-0000-0000-000000000000",
+		"instances":       instances,
+		"instance_groups": groups,
+	})
+}
+
+func (s *Server) serveConfig(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		methodNotAllowed(w, req)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"time_zone":           "UTC",
+		"version":             s.version,
+		"eula":                "",
+		"analytics_status":    "off",
+		"license_info":        map[string]interface{}{"license_type": "open", "valid_key": true, "compliant": true},
+		"become_methods":      [][]string{{"sudo", "Sudo"}, {"su", "Su"}},
+		"project_base_dir":    "/var/lib/awx/projects",
+		"project_local_paths": []string{},
+	})
+}
+
+func (s *Server) serveCollection(w http.ResponseWriter, req *http.Request, r *resource) {
+	switch req.Method {
+	case http.MethodGet:
+		s.serveList(w, req, r.name)
+	case http.MethodPost:
+		if r.readOnly {
+			methodNotAllowed(w, req)
+			return
+		}
+		values, ok := readBody(w, req)
+		if !ok {
+			return
+		}
+		obj, errs := s.create(r, values, false)
+		if len(errs) > 0 {
+			writeJSON(w, http.StatusBadRequest, errs)
+			return
+		}
+		writeJSON(w, http.StatusCreated, s.render(r, obj))
+	case http.MethodOptions:
+		s.serveOptions(w, r, false)
+	default:
+		methodNotAllowed(w, req)
+	}
+}
+
+func (s *Server) serveObject(w http.ResponseWriter, req *http.Request, r *resource, obj object) {
+	switch req.Method {
+	case http.MethodGet:
+		if r.job {
+			s.advance(r, obj)
+		}
+		writeJSON(w, http.StatusOK, s.render(r, obj))
+	case http.MethodPatch, http.MethodPut:
+		if r.readOnly || r.job {
+			methodNotAllowed(w, req)
+			return
+		}
+		values, ok := readBody(w, req)
+		if !ok {
+			return
+		}
+		if errs := s.update(r, obj, values, req.Method == http.MethodPatch); len(errs) > 0 {
+			writeJSON(w, http.StatusBadRequest, errs)
+			return
+		}
+		writeJSON(w, http.StatusOK, s.render(r, obj))
+	case http.MethodDelete:
+		if r.name == "activity_stream" || r.name == "instances" {
+			methodNotAllowed(w, req)
+			return
+		}
+		s.delete(r, obj)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodOptions:
+		s.serveOptions(w, r, true)
+	default:
+		methodNotAllowed(w, req)
+	}
+}
+
+func (s *Server) serveAction(w http.ResponseWriter, req *http.Request, r *resource, obj object, action string) {
+	if _, ok := r.subs[action]; ok {
+		s.serveSublist(w, req, r, obj, action)
+		return
+	}
+
+	switch {
+	case action == r.launch:
+		s.serveLaunch(w, req, r, obj)
+	case action == "update_inventory_sources" && r.name == "inventories":
+		s.serveInventorySync(w, req, obj)
+	case action == "survey_spec" && (r.name == "job_templates" || r.name == "workflow_job_templates"):
+		s.serveSurvey(w, req, r, obj)
+	case (action == "playbooks" || action == "inventory_files") && r.name == "projects":
+		if req.Method != http.MethodGet {
+			methodNotAllowed(w, req)
+			return
+		}
+		files := s.files[obj.id()]
+		list := files.playbooks
+		if action == "inventory_files" {
+			list = files.inventoryFiles
+		}
+		if list == nil {
+			list = []string{}
+		}
+		writeJSON(w, http.StatusOK, list)
+	case r.job && action == "cancel":
+		s.serveCancel(w, req, r, obj)
+	case r.job && action == "relaunch":
+		s.serveRelaunch(w, req, r, obj)
+	case r.job && action == "stdout":
+		s.serveStdout(w, req, r, obj)
+	case r.job && (action == "events" || action == "job_events"):
+		s.serveEvents(w, req, r, obj)
+	default:
+		notFound(w)
+	}
+}
+
+func (s *Server) serveSublist(w http.ResponseWriter, req *http.Request, r *resource, obj object, sub string) {
+	member := resourcesByName[r.subs[sub]]
+
+	switch req.Method {
+	case http.MethodGet:
+		results := []interface{}{}
+		for _, m := range s.members(r, obj, sub) {
+			results = append(results, s.render(member, m))
+		}
+		s.writePage(w, req, results)
+	case http.MethodPost:
+		values, ok := readBody(w, req)
+		if !ok {
+			return
+		}
+
+		rawID, hasID := values["id"]
+		if !hasID {
+			// Without an ID the member is created, then associated.
+			if member.readOnly {
+				methodNotAllowed(w, req)
+				return
+			}
+			created, errs := s.create(member, values, false)
+			if len(errs) > 0 {
+				writeJSON(w, http.StatusBadRequest, errs)
+				return
+			}
+			s.associate(r, obj, sub, created)
+			writeJSON(w, http.StatusCreated, s.render(member, created))
+			return
+		}
+
+		id, ok := wholeNumber(rawID)
+		target := s.get(member, id)
+		if !ok || target == nil {
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{"msg": fmt.Sprintf("Object with id %v not found", rawID)})
+			return
+		}
+		if disassociate, _ := values["disassociate"].(bool); disassociate {
+			s.disassociate(r, obj, sub, target)
+		} else {
+			s.associate(r, obj, sub, target)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w, req)
+	}
+}
+
+func (s *Server) serveSurvey(w http.ResponseWriter, req *http.Request, r *resource, obj object) {
+	key := path(r, obj.id())
+
+	switch req.Method {
+	case http.MethodGet:
+		spec, ok := s.surveys[key]
+		if !ok {
+			spec = json.RawMessage("{}")
+		}
+		writeJSON(w, http.StatusOK, spec)
+	case http.MethodPost:
+		var spec struct {
+			Name        *string           `json:"name"`
+			Description *string           `json:"description"`
+			Spec        []json.RawMessage `json:"spec"`
+		}
+		var raw json.RawMessage
+		if err := json.NewDecoder(req.Body).Decode(&raw); err != nil || json.Unmarshal(raw, &spec) != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "Invalid JSON"})
+			return
+		}
+		switch {
+		case spec.Name == nil:
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "'name' missing from survey spec."})
+		case spec.Description == nil:
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "'description' missing from survey spec."})
+		case spec.Spec == nil:
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "'spec' missing from survey spec."})
+		default:
+			s.surveys[key] = raw
+			w.WriteHeader(http.StatusOK)
+		}
+	case http.MethodDelete:
+		delete(s.surveys, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w, req)
+	}
+}
+
+// serveList writes a page of the objects of the given collections matching
+// the query of req.
+func (s *Server) serveList(w http.ResponseWriter, req *http.Request, collections ...string) {
+	var items []item
+	for _, name := range collections {
+		r := resourcesByName[name]
+		for _, obj := range s.sorted(r) {
+			items = append(items, item{r, obj})
+		}
+	}
+
+	query := req.URL.Query()
+	filtered := items[:0]
+	for _, it := range items {
+		keep := true
+		for key, args := range query {
+			switch key {
+			case "page", "page_size", "order_by", "format":
+				continue
+			}
+			if !s.matches(it, key, args[len(args)-1]) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			filtered = append(filtered, it)
+		}
+	}
+
+	s.order(filtered, query.Get("order_by"))
+
+	results := make([]interface{}, len(filtered))
+	for i, it := range filtered {
+		results[i] = s.render(it.r, it.obj)
+	}
+	s.writePage(w, req, results)
+}
+
+// writePage writes the page of results requested by req, with next and
+// previous URLs that keep the rest of the query.
+func (s *Server) writePage(w http.ResponseWriter, req *http.Request, results []interface{}) {
+	query := req.URL.Query()
+
+	pageSize := s.pageSize
+	if n, ok := atoi(query.Get("page_size")); ok && n > 0 {
+		pageSize = n
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	pageNum := 1
+	if n, ok := atoi(query.Get("page")); ok {
+		pageNum = n
+	}
+
+	start := (pageNum - 1) * pageSize
+	if pageNum < 1 || (start >= len(results) && pageNum != 1) {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"detail": "Invalid page."})
+		return
+	}
+	end := start + pageSize
+	if end > len(results) {
+		end = len(results)
+	}
+
+	pageURL := func(n int) interface{} {
+		q := req.URL.Query()
+		q.Set("page", fmt.Sprint(n))
+		return req.URL.Path + "?" + q.Encode()
+	}
+	var next, previous interface{}
+	if end < len(results) {
+		next = pageURL(pageNum + 1)
+	}
+	if pageNum > 1 {
+		previous = pageURL(pageNum - 1)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"count":    len(results),
+		"next":     next,
+		"previous": previous,
+		"results":  results[start:end],
+	})
+}
+
+// order sorts items by a field, prefixed with "-" to reverse it, and then by
+// ID.
+func (s *Server) order(items []item, orderBy string) {
+	desc := strings.HasPrefix(orderBy, "-")
+	name := strings.TrimPrefix(orderBy, "-")
+	if name == "" {
+		name = "id"
+	}
+
+	value := func(it item) interface{} {
+		if vs, ok := s.lookup(it, name); ok && len(vs) > 0 {
+			return vs[0]
+		}
+		return nil
+	}
+	less := func(i, j int) bool {
+		c := 0
+		if a, b := value(items[i]), value(items[j]); a != nil && b != nil {
+			c = compare(a, fmt.Sprint(b))
+		}
+		if c == 0 {
+			c = items[i].obj.id() - items[j].obj.id()
+		}
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	}
+	sort.SliceStable(items, less)
+}
+
+// serveOptions writes the metadata of a collection or of one of its objects.
+func (s *Server) serveOptions(w http.ResponseWriter, r *resource, detail bool) {
+	describe := func(f field, readOnly bool) map[string]interface{} {
+		meta := map[string]interface{}{
+			"type":       f.typ,
+			"label":      strings.ToUpper(f.name[:1]) + strings.ReplaceAll(f.name[1:], "_", " "),
+			"filterable": true,
+		}
+		if readOnly {
+			meta["read_only"] = true
+			return meta
+		}
+		meta["required"] = f.required
+		if f.maxLength > 0 {
+			meta["max_length"] = f.maxLength
+		}
+		if f.def != nil {
+			meta["default"] = f.def
+		}
+		if len(f.choices) > 0 {
+			choices := make([][]interface{}, len(f.choices))
+			for i, c := range f.choices {
+				choices[i] = []interface{}{c, fmt.Sprint(c)}
+			}
+			meta["choices"] = choices
+		}
+		return meta
+	}
+
+	get := map[string]interface{}{
+		"id":       map[string]interface{}{"type": typeInteger, "label": "ID", "filterable": true},
+		"type":     map[string]interface{}{"type": typeChoice, "label": "Type", "choices": [][]string{{r.typ, r.title()}}},
+		"url":      map[string]interface{}{"type": typeString, "label": "Url"},
+		"created":  map[string]interface{}{"type": typeDateTime, "label": "Created", "filterable": true},
+		"modified": map[string]interface{}{"type": typeDateTime, "label": "Modified", "filterable": true},
+	}
+	write := map[string]interface{}{}
+	for _, f := range r.fields {
+		get[f.name] = describe(f, true)
+		if !f.readOnly {
+			write[f.name] = describe(f, false)
+		}
+	}
+
+	actions := map[string]interface{}{"GET": get}
+	switch {
+	case r.readOnly:
+	case !detail:
+		actions["POST"] = write
+	case !r.job:
+		actions["PUT"] = write
+	}
+
+	name := r.title()
+	if detail {
+		name += " Detail"
+	} else {
+		name += " List"
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"name":        name,
+		"description": "",
+		"renders":     []string{"application/json"},
+		"parses":      []string{"application/json"},
+		"actions":     actions,
+		"types":       []string{r.typ},
+	})
+}
+
+func readBody(w http.ResponseWriter, req *http.Request) (map[string]interface{}, bool) {
+	values := map[string]interface{}{}
+	if req.ContentLength == 0 {
+		return values, true
+	}
+	if err := json.NewDecoder(req.Body).Decode(&values); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"detail": "JSON parse error - " + err.Error()})
+		return nil, false
+	}
+	return values, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-API-Request-Id", fmt.Sprintf("%016x", atomic.AddUint64(&requestID, 1)))
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// requestID numbers the responses of every Server.
+var requestID uint64
+
+func atoi(s string) (int, bool) {
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
+
+func notFound(w http.ResponseWriter) {
+	writeJSON(w, http.StatusNotFound, map[string]interface{}{"detail": "Not found."})
+}
+
+func methodNotAllowed(w http.ResponseWriter, req *http.Request) {
+	writeJSON(w, http.StatusMethodNotAllowed, map[string]interface{}{"detail": fmt.Sprintf("Method %q not allowed.", req.Method)})
+}
@@ -0,0 +1,255 @@
+package awxtest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/sparkacus/awx-go-client/awx"
+	"github.com/sparkacus/awx-go-client/awx/awxtest"
+)
+
+func newClient(t *testing.T, opts ...awxtest.Option) (*awxtest.Server, *awx.Client) {
+	t.Helper()
+
+	srv := awxtest.NewServer(opts...)
+	t.Cleanup(srv.Close)
+
+	client, err := srv.Client()
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+	return srv, client
+}
+
+func add(t *testing.T, srv *awxtest.Server, collection string, fields map[string]interface{}) int {
+	t.Helper()
+
+	id, err := srv.Add(collection, fields)
+	if err != nil {
+		t.Fatalf("Add(%s): %v", collection, err)
+	}
+	return id
+}
+
+func TestOrganizationLifecycle(t *testing.T) {
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	created, _, err := client.Organization.Create(ctx, &awx.OrganizationCreateRequest{Name: "Default", Description: "first"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.ID == 0 || created.Name != "Default" {
+		t.Fatalf("Create = %+v, want an ID and the name", created)
+	}
+
+	if _, err := client.Organization.Update(ctx, &awx.OrganizationCreateRequest{Name: "Default", Description: "second"}, created.ID); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got, _, err := client.Organization.Get(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Description != "second" {
+		t.Errorf("Get: description %q, want second", got.Description)
+	}
+
+	list, _, err := client.Organization.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(list) != 1 || list[0].ID != created.ID {
+		t.Errorf("List = %+v, want the created organization", list)
+	}
+
+	if _, err := client.Organization.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	_, _, err = client.Organization.Get(ctx, created.ID)
+	var errResp *awx.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusNotFound {
+		t.Errorf("Get after Delete: %v, want a 404 *awx.ErrorResponse", err)
+	}
+}
+
+func TestProjectCreate(t *testing.T) {
+	_, client := newClient(t)
+
+	project, _, err := client.Project.Create(context.Background(), &awx.ProjectCreateRequest{Name: "playbooks", ScmType: "git", ScmURL: "https://example.com/playbooks.git"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if project == nil || project.ID == 0 || project.Name != "playbooks" || project.ScmType != "git" {
+		t.Errorf("Create = %+v, want the created project", project)
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	srv, client := newClient(t)
+	ctx := context.Background()
+	add(t, srv, "organizations", map[string]interface{}{"name": "Default"})
+
+	tests := []struct {
+		name   string
+		create func() error
+		field  string
+	}{
+		{
+			name: "required",
+			create: func() error {
+				_, _, err := client.Inventory.Create(ctx, &awx.InventoryCreateRequest{Name: "hosts"})
+				return err
+			},
+			field: "organization",
+		},
+		{
+			name: "unique",
+			create: func() error {
+				_, _, err := client.Organization.Create(ctx, &awx.OrganizationCreateRequest{Name: "Default"})
+				return err
+			},
+			field: "name",
+		},
+		{
+			name: "unknown reference",
+			create: func() error {
+				_, _, err := client.Inventory.Create(ctx, &awx.InventoryCreateRequest{Name: "hosts", Organization: 99})
+				return err
+			},
+			field: "organization",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.create()
+			var errResp *awx.ErrorResponse
+			if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusBadRequest {
+				t.Fatalf("Create: %v, want a 400 *awx.ErrorResponse", err)
+			}
+			if len(errResp.Fields[tt.field]) == 0 {
+				t.Errorf("Create: field errors %v, want one for %s", errResp.Fields, tt.field)
+			}
+		})
+	}
+}
+
+func TestPagination(t *testing.T) {
+	srv, client := newClient(t, awxtest.WithPageSize(2))
+	ctx := context.Background()
+
+	org := add(t, srv, "organizations", map[string]interface{}{"name": "Default"})
+	var want []int
+	for i := 0; i < 5; i++ {
+		group := add(t, srv, "instance_groups", map[string]interface{}{"name": fmt.Sprintf("group-%d", i)})
+		if err := srv.Associate("organizations", org, "instance_groups", group); err != nil {
+			t.Fatalf("Associate: %v", err)
+		}
+		want = append(want, group)
+	}
+
+	groups, _, err := client.Organization.ListInstanceGroups(ctx, org)
+	if err != nil {
+		t.Fatalf("ListInstanceGroups: %v", err)
+	}
+	var got []int
+	for _, g := range groups {
+		got = append(got, g.ID)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListInstanceGroups = %v, want %v from every page", got, want)
+	}
+
+	page, _, err := client.Raw.Get(ctx, "instance_groups/?page=2")
+	if err != nil {
+		t.Fatalf("Get page 2: %v", err)
+	}
+	if page["count"] != float64(5) || page["next"] != "/api/v2/instance_groups/?page=3" || page["previous"] != "/api/v2/instance_groups/?page=1" {
+		t.Errorf("page 2 = count %v, next %v, previous %v", page["count"], page["next"], page["previous"])
+	}
+}
+
+func TestFiltersAndNamedURLs(t *testing.T) {
+	srv, client := newClient(t)
+	ctx := context.Background()
+
+	org := add(t, srv, "organizations", map[string]interface{}{"name": "Default"})
+	for _, name := range []string{"deploy-web", "deploy-db", "backup"} {
+		add(t, srv, "inventories", map[string]interface{}{"name": name, "organization": org})
+	}
+
+	page, _, err := client.Raw.Get(ctx, "inventories/?name__startswith=deploy&order_by=name")
+	if err != nil {
+		t.Fatalf("Get filtered: %v", err)
+	}
+	var names []interface{}
+	for _, obj := range page["results"].([]interface{}) {
+		names = append(names, obj.(map[string]interface{})["name"])
+	}
+	if want := []interface{}{"deploy-db", "deploy-web"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("filtered names = %v, want %v", names, want)
+	}
+
+	obj, _, err := client.Raw.Get(ctx, "inventories/backup++Default/")
+	if err != nil {
+		t.Fatalf("Get by named URL: %v", err)
+	}
+	if obj["name"] != "backup" {
+		t.Errorf("Get by named URL = %v, want the backup inventory", obj["name"])
+	}
+}
+
+func TestJobLifecycle(t *testing.T) {
+	for _, final := range []string{"successful", "failed"} {
+		t.Run(final, func(t *testing.T) {
+			srv, client := newClient(t, awxtest.WithJobStatus(final))
+			ctx := context.Background()
+
+			org := add(t, srv, "organizations", map[string]interface{}{"name": "Default"})
+			inventory := add(t, srv, "inventories", map[string]interface{}{"name": "hosts", "organization": org})
+			source := add(t, srv, "inventory_sources", map[string]interface{}{"name": "cloud", "inventory": inventory, "source": "ec2"})
+
+			update, _, err := client.InventorySource.Sync(ctx, source)
+			if err != nil {
+				t.Fatalf("Sync: %v", err)
+			}
+
+			var statuses []string
+			for i := 0; i < 3; i++ {
+				got, _, err := client.InventoryUpdate.Get(ctx, update.ID)
+				if err != nil {
+					t.Fatalf("Get: %v", err)
+				}
+				statuses = append(statuses, got.Status)
+			}
+			if want := []string{"running", final, final}; !reflect.DeepEqual(statuses, want) {
+				t.Errorf("statuses = %v, want %v", statuses, want)
+			}
+
+			source2, _, err := client.InventorySource.Get(ctx, source)
+			if err != nil {
+				t.Fatalf("Get source: %v", err)
+			}
+			if source2.Status != final {
+				t.Errorf("source status = %q, want %q", source2.Status, final)
+			}
+		})
+	}
+}
+
+func TestVersionNegotiation(t *testing.T) {
+	srv := awxtest.NewServer(awxtest.WithVersion("17.1.0"))
+	t.Cleanup(srv.Close)
+	client, err := srv.Client(awx.WithVersionNegotiation())
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+
+	if _, _, err := client.ExecutionEnvironment.List(context.Background()); !errors.Is(err, awx.ErrNotSupported) {
+		t.Errorf("List: %v, want awx.ErrNotSupported", err)
+	}
+}
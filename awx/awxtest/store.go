@@ -0,0 +1,699 @@
+package awxtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// object is a stored AWX object. Keys starting with "_" hold state that is
+// never rendered, such as values only used for filtering.
+type object map[string]interface{}
+
+func (o object) id() int {
+	return o["id"].(int)
+}
+
+// fieldErrors maps fields to their errors, as AWX returns them for a 400 Bad
+// Request.
+type fieldErrors map[string][]string
+
+func (e fieldErrors) add(field, format string, args ...interface{}) {
+	e[field] = append(e[field], fmt.Sprintf(format, args...))
+}
+
+// ValidationError is returned when an object added to the Server is invalid.
+// Fields holds the errors the API would have returned.
+type ValidationError struct {
+	Fields map[string][]string
+}
+
+func (e *ValidationError) Error() string {
+	names := make([]string, 0, len(e.Fields))
+	for name := range e.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	msgs := make([]string, len(names))
+	for i, name := range names {
+		msgs[i] = fmt.Sprintf("%s: %s", name, strings.Join(e.Fields[name], " "))
+	}
+	return "awxtest: invalid object: " + strings.Join(msgs, "; ")
+}
+
+func (s *Server) now() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
+
+func (s *Server) nextID(r *resource) int {
+	sequence := r.sequence
+	if sequence == "" {
+		sequence = r.name
+	}
+	s.sequences[sequence]++
+	return s.sequences[sequence]
+}
+
+func (s *Server) get(r *resource, id int) object {
+	return s.objects[r.name][id]
+}
+
+// find looks an object up by ID or, for resources with named URLs, by name.
+// Names of objects unique within an organization or inventory are written
+// "name++scope", e.g. "Deploy++Default".
+func (s *Server) find(r *resource, ident string) object {
+	if id, err := strconv.Atoi(ident); err == nil {
+		return s.get(r, id)
+	}
+	if r.named == "" {
+		return nil
+	}
+
+	parts := strings.Split(ident, "++")
+	for _, obj := range s.sorted(r) {
+		if obj[r.named] != parts[0] {
+			continue
+		}
+		if len(parts) > 1 && len(r.unique) > 1 {
+			scope, _ := r.field(r.unique[0])
+			ref, ok := obj[scope.name].(int)
+			if !ok {
+				continue
+			}
+			target := s.objects[scope.ref][ref]
+			if target == nil || target["name"] != parts[1] {
+				continue
+			}
+		}
+		return obj
+	}
+	return nil
+}
+
+// sorted returns the objects of a resource in ID order.
+func (s *Server) sorted(r *resource) []object {
+	objs := make([]object, 0, len(s.objects[r.name]))
+	for _, obj := range s.objects[r.name] {
+		objs = append(objs, obj)
+	}
+	sort.Slice(objs, func(i, j int) bool { return objs[i].id() < objs[j].id() })
+	return objs
+}
+
+// clean validates the values of a create or update request against the
+// fields of r, returning the values to store. Read-only fields are ignored
+// unless seed is set, as for objects added with Server.Add.
+func (s *Server) clean(r *resource, values map[string]interface{}, partial, seed bool) (object, fieldErrors) {
+	errs := fieldErrors{}
+	cleaned := object{}
+
+	for _, f := range r.fields {
+		if f.readOnly && !seed {
+			continue
+		}
+
+		value, ok := values[f.name]
+		if !ok {
+			if f.required && !partial {
+				errs.add(f.name, "This field is required.")
+			}
+			continue
+		}
+
+		v, msg := s.cleanValue(f, value)
+		if msg != "" {
+			errs.add(f.name, "%s", msg)
+			continue
+		}
+		cleaned[f.name] = v
+	}
+
+	return cleaned, errs
+}
+
+func (s *Server) cleanValue(f field, value interface{}) (interface{}, string) {
+	if value == nil {
+		if f.required || f.typ == typeString || f.typ == typeBoolean {
+			return nil, "This field may not be null."
+		}
+		return nil, ""
+	}
+
+	switch f.typ {
+	case typeString:
+		str, ok := value.(string)
+		if !ok {
+			return nil, "Not a valid string."
+		}
+		if str == "" && f.required {
+			return nil, "This field may not be blank."
+		}
+		if f.maxLength > 0 && len([]rune(str)) > f.maxLength {
+			return nil, fmt.Sprintf("Ensure this field has no more than %d characters.", f.maxLength)
+		}
+		return str, ""
+	case typeInteger:
+		n, ok := wholeNumber(value)
+		if !ok {
+			return nil, "A valid integer is required."
+		}
+		return n, ""
+	case typeFloat:
+		n, ok := value.(float64)
+		if !ok {
+			return nil, "A valid number is required."
+		}
+		return n, ""
+	case typeBoolean:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, "Must be a valid boolean."
+		}
+		return b, ""
+	case typeChoice:
+		for _, choice := range f.choices {
+			if fmt.Sprint(choice) == fmt.Sprint(value) {
+				return choice, ""
+			}
+		}
+		return nil, fmt.Sprintf("%q is not a valid choice.", fmt.Sprint(value))
+	case typeID:
+		n, ok := wholeNumber(value)
+		if !ok {
+			return nil, fmt.Sprintf("Incorrect type. Expected pk value, received %T.", value)
+		}
+		if f.ref != "" && s.objects[f.ref][n] == nil {
+			return nil, fmt.Sprintf("Invalid pk \"%d\" - object does not exist.", n)
+		}
+		return n, ""
+	}
+
+	return value, ""
+}
+
+func wholeNumber(value interface{}) (int, bool) {
+	switch n := value.(type) {
+	case int:
+		return n, true
+	case float64:
+		if n == float64(int(n)) {
+			return int(n), true
+		}
+	}
+	return 0, false
+}
+
+// checkUnique reports an error if another object of r has the same unique
+// values as obj.
+func (s *Server) checkUnique(r *resource, obj object, errs fieldErrors) {
+	if len(r.unique) == 0 {
+		return
+	}
+
+	for _, other := range s.objects[r.name] {
+		if other["id"] == obj["id"] {
+			continue
+		}
+		same := true
+		for _, name := range r.unique {
+			if fmt.Sprint(other[name]) != fmt.Sprint(obj[name]) {
+				same = false
+				break
+			}
+		}
+		if !same {
+			continue
+		}
+
+		labels := make([]string, len(r.unique))
+		for i, name := range r.unique {
+			labels[i] = strings.ToUpper(name[:1]) + strings.ReplaceAll(name[1:], "_", " ")
+		}
+		if len(labels) == 1 {
+			errs.add(r.unique[0], "%s with this %s already exists.", r.title(), labels[0])
+		} else {
+			errs.add("__all__", "%s with this (%s) combination already exists.", r.title(), strings.Join(labels, ", "))
+		}
+		return
+	}
+}
+
+// derive sets the fields AWX computes from others.
+func (s *Server) derive(r *resource, obj object) {
+	if r.name == "job_templates" {
+		obj["organization"] = nil
+		if project := s.objects["projects"][intValue(obj["project"])]; project != nil {
+			obj["organization"] = project["organization"]
+		}
+	}
+}
+
+func intValue(v interface{}) int {
+	n, _ := v.(int)
+	return n
+}
+
+// create validates values and stores a new object of r.
+func (s *Server) create(r *resource, values map[string]interface{}, seed bool) (object, fieldErrors) {
+	cleaned, errs := s.clean(r, values, false, seed)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	obj := object{"type": r.typ, "created": s.now()}
+	obj["modified"] = obj["created"]
+	for _, f := range r.fields {
+		if v, ok := cleaned[f.name]; ok {
+			obj[f.name] = v
+		} else {
+			obj[f.name] = f.def
+		}
+	}
+	s.derive(r, obj)
+
+	s.checkUnique(r, obj, errs)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	obj["id"] = s.nextID(r)
+	s.objects[r.name][obj.id()] = obj
+	if !seed {
+		s.record("create", r, obj, changes(r, nil, obj), nil, nil, "")
+	}
+
+	return obj, nil
+}
+
+// update validates values and applies them to obj.
+func (s *Server) update(r *resource, obj object, values map[string]interface{}, partial bool) fieldErrors {
+	cleaned, errs := s.clean(r, values, partial, false)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	updated := object{}
+	for k, v := range obj {
+		updated[k] = v
+	}
+	for k, v := range cleaned {
+		updated[k] = v
+	}
+	s.derive(r, updated)
+
+	s.checkUnique(r, updated, errs)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	diff := changes(r, obj, updated)
+	for k, v := range updated {
+		obj[k] = v
+	}
+	if len(diff) > 0 {
+		obj["modified"] = s.now()
+		s.record("update", r, obj, diff, nil, nil, "")
+	}
+
+	return nil
+}
+
+func (s *Server) delete(r *resource, obj object) {
+	delete(s.objects[r.name], obj.id())
+	for key, members := range s.associations {
+		if key.resource == r.name && key.id == obj.id() {
+			delete(s.associations, key)
+			continue
+		}
+		if r.name == resourcesByName[key.resource].subs[key.sub] {
+			s.associations[key] = without(members, obj.id())
+		}
+	}
+	s.record("delete", r, obj, changes(r, nil, obj), nil, nil, "")
+}
+
+// changes returns the fields that differ between before and after, as the
+// activity stream records them: old and new values for updates, and the
+// values alone for creates and deletes.
+func changes(r *resource, before, after object) map[string]interface{} {
+	diff := make(map[string]interface{})
+	for _, f := range r.fields {
+		if before == nil {
+			diff[f.name] = after[f.name]
+			continue
+		}
+		if fmt.Sprint(before[f.name]) != fmt.Sprint(after[f.name]) {
+			diff[f.name] = []interface{}{before[f.name], after[f.name]}
+		}
+	}
+	return diff
+}
+
+// assocKey identifies an association sublist, e.g. the labels of a job
+// template.
+type assocKey struct {
+	resource string
+	id       int
+	sub      string
+}
+
+func (s *Server) associate(r *resource, obj object, sub string, member object) {
+	key := assocKey{r.name, obj.id(), sub}
+	for _, id := range s.associations[key] {
+		if id == member.id() {
+			return
+		}
+	}
+	s.associations[key] = append(s.associations[key], member.id())
+	s.record("associate", r, obj, nil, resourcesByName[r.subs[sub]], member, sub)
+}
+
+func (s *Server) disassociate(r *resource, obj object, sub string, member object) {
+	key := assocKey{r.name, obj.id(), sub}
+	s.associations[key] = without(s.associations[key], member.id())
+	s.record("disassociate", r, obj, nil, resourcesByName[r.subs[sub]], member, sub)
+}
+
+func (s *Server) members(r *resource, obj object, sub string) []object {
+	member := resourcesByName[r.subs[sub]]
+	var objs []object
+	for _, id := range s.associations[assocKey{r.name, obj.id(), sub}] {
+		if m := s.get(member, id); m != nil {
+			objs = append(objs, m)
+		}
+	}
+	return objs
+}
+
+func without(ids []int, id int) []int {
+	out := ids[:0:0]
+	for _, i := range ids {
+		if i != id {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// record adds an entry to the activity stream.
+func (s *Server) record(operation string, r *resource, obj object, changes map[string]interface{}, r2 *resource, obj2 object, association string) {
+	if r.name == "activity_stream" {
+		return
+	}
+
+	stream := resourcesByName["activity_stream"]
+	if changes == nil {
+		changes = map[string]interface{}{}
+	}
+	summary := map[string]interface{}{
+		r.typ:   []interface{}{s.summarize(r, obj)},
+		"actor": map[string]interface{}{"id": 1, "username": "admin", "first_name": "", "last_name": ""},
+	}
+	entry := object{
+		"id":                 s.nextID(stream),
+		"type":               stream.typ,
+		"timestamp":          s.now(),
+		"operation":          operation,
+		"changes":            changes,
+		"object1":            r.typ,
+		"object2":            "",
+		"object_association": association,
+		"action_node":        "awx-1",
+		"_actor__username":   "admin",
+		"_" + r.typ + "__id": obj.id(),
+		"_summary_fields":    summary,
+		"_related":           map[string]interface{}{"actor": "/api/v2/users/1/"},
+	}
+	if r2 != nil {
+		entry["object2"] = r2.typ
+		entry["_"+r2.typ+"__id"] = obj2.id()
+		summary[r2.typ] = []interface{}{s.summarize(r2, obj2)}
+	}
+	s.objects[stream.name][entry.id()] = entry
+}
+
+// path returns the URL path of an object.
+func path(r *resource, id int) string {
+	return fmt.Sprintf("%s%s/%d/", apiRoot, r.name, id)
+}
+
+// summarize returns the short form of an object used in summary fields.
+func (s *Server) summarize(r *resource, obj object) map[string]interface{} {
+	summary := map[string]interface{}{"id": obj["id"]}
+	for _, name := range []string{"name", "hostname", "description", "status", "scm_type", "kind", "failed"} {
+		if v, ok := obj[name]; ok {
+			summary[name] = v
+		}
+	}
+	if r.name == "job_templates" || r.name == "workflow_job_templates" || r.name == "projects" || r.name == "inventory_sources" {
+		summary["unified_job_type"] = r.launchJob
+	}
+	return summary
+}
+
+// render returns the representation of an object the API returns.
+func (s *Server) render(r *resource, obj object) map[string]interface{} {
+	out := make(map[string]interface{}, len(obj)+3)
+	for k, v := range obj {
+		if !strings.HasPrefix(k, "_") {
+			out[k] = v
+		}
+	}
+
+	if r.name == "activity_stream" {
+		out["url"] = path(r, obj.id())
+		out["related"] = obj["_related"]
+		out["summary_fields"] = obj["_summary_fields"]
+		return out
+	}
+
+	self := path(r, obj.id())
+	out["url"] = self
+	related := map[string]interface{}{}
+	summary := map[string]interface{}{
+		"user_capabilities": map[string]interface{}{"edit": true, "delete": true, "start": true, "copy": true},
+	}
+
+	for _, f := range r.fields {
+		if f.typ != typeID || f.ref == "" {
+			continue
+		}
+		id, ok := obj[f.name].(int)
+		if !ok {
+			continue
+		}
+		ref := resourcesByName[f.ref]
+		related[f.name] = path(ref, id)
+		if target := s.get(ref, id); target != nil {
+			summary[f.name] = s.summarize(ref, target)
+		}
+	}
+
+	for sub := range r.subs {
+		related[sub] = self + sub + "/"
+	}
+	if _, ok := r.subs["labels"]; ok {
+		labels := []interface{}{}
+		for _, label := range s.members(r, obj, "labels") {
+			labels = append(labels, map[string]interface{}{"id": label["id"], "name": label["name"]})
+		}
+		summary["labels"] = map[string]interface{}{"count": len(labels), "results": labels}
+	}
+	if r.launch != "" {
+		related[r.launch] = self + r.launch + "/"
+	}
+	if r.name == "job_templates" || r.name == "workflow_job_templates" {
+		related["survey_spec"] = self + "survey_spec/"
+	}
+	if r.job {
+		for _, action := range []string{"stdout", "cancel", "relaunch", "events"} {
+			related[action] = self + action + "/"
+		}
+		summary["created_by"] = map[string]interface{}{"id": 1, "username": "admin", "first_name": "", "last_name": ""}
+	}
+
+	out["related"] = related
+	out["summary_fields"] = summary
+	return out
+}
+
+// item is an object along with its resource, as listed by the unified
+// endpoints.
+type item struct {
+	r   *resource
+	obj object
+}
+
+// lookup returns the values of a field of an object for filtering. Fields
+// of related objects and association members are written "field__name",
+// e.g. "labels__name" or "organization__name".
+func (s *Server) lookup(it item, name string) ([]interface{}, bool) {
+	if v, ok := it.obj[name]; ok {
+		return []interface{}{v}, true
+	}
+	if v, ok := it.obj["_"+name]; ok {
+		return []interface{}{v}, true
+	}
+
+	parts := strings.SplitN(name, "__", 2)
+	if len(parts) != 2 {
+		return nil, false
+	}
+
+	if _, ok := it.r.subs[parts[0]]; ok {
+		member := resourcesByName[it.r.subs[parts[0]]]
+		var values []interface{}
+		for _, m := range s.members(it.r, it.obj, parts[0]) {
+			if vs, ok := s.lookup(item{member, m}, parts[1]); ok {
+				values = append(values, vs...)
+			}
+		}
+		return values, true
+	}
+
+	if f, ok := it.r.field(parts[0]); ok && f.typ == typeID && f.ref != "" {
+		ref := resourcesByName[f.ref]
+		target := s.get(ref, intValue(it.obj[f.name]))
+		if target == nil {
+			return []interface{}{nil}, true
+		}
+		return s.lookup(item{ref, target}, parts[1])
+	}
+
+	return nil, false
+}
+
+// filterOps are the Django field lookups supported in list queries.
+var filterOps = map[string]bool{
+	"exact": true, "iexact": true, "contains": true, "icontains": true,
+	"startswith": true, "istartswith": true, "in": true, "isnull": true,
+	"gt": true, "gte": true, "lt": true, "lte": true,
+}
+
+// matches reports whether an object satisfies a query parameter.
+func (s *Server) matches(it item, key, arg string) bool {
+	switch key {
+	case "type":
+		for _, t := range strings.Split(arg, ",") {
+			if t == it.r.typ {
+				return true
+			}
+		}
+		return false
+	case "search":
+		for _, name := range []string{"name", "description", "hostname"} {
+			if v, ok := it.obj[name].(string); ok && strings.Contains(strings.ToLower(v), strings.ToLower(arg)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	negate := false
+	if strings.HasPrefix(key, "not__") {
+		negate = true
+		key = strings.TrimPrefix(key, "not__")
+	}
+
+	name, op := key, "exact"
+	if i := strings.LastIndex(key, "__"); i >= 0 && filterOps[key[i+2:]] {
+		name, op = key[:i], key[i+2:]
+	}
+
+	values, ok := s.lookup(it, name)
+	if !ok {
+		return false
+	}
+
+	matched := false
+	for _, v := range values {
+		if matchValue(v, op, arg) {
+			matched = true
+			break
+		}
+	}
+	return matched != negate
+}
+
+func matchValue(v interface{}, op, arg string) bool {
+	if op == "isnull" {
+		return (v == nil) == (strings.ToLower(arg) == "true")
+	}
+	if v == nil {
+		return false
+	}
+
+	str := fmt.Sprint(v)
+	switch op {
+	case "exact":
+		if b, ok := v.(bool); ok {
+			return strconv.FormatBool(b) == strings.ToLower(arg)
+		}
+		return str == arg
+	case "iexact":
+		return strings.EqualFold(str, arg)
+	case "contains":
+		return strings.Contains(str, arg)
+	case "icontains":
+		return strings.Contains(strings.ToLower(str), strings.ToLower(arg))
+	case "startswith":
+		return strings.HasPrefix(str, arg)
+	case "istartswith":
+		return strings.HasPrefix(strings.ToLower(str), strings.ToLower(arg))
+	case "in":
+		for _, option := range strings.Split(arg, ",") {
+			if str == option {
+				return true
+			}
+		}
+		return false
+	case "gt":
+		return compare(v, arg) > 0
+	case "gte":
+		return compare(v, arg) >= 0
+	case "lt":
+		return compare(v, arg) < 0
+	case "lte":
+		return compare(v, arg) <= 0
+	}
+	return false
+}
+
+// compare orders a stored value and a query argument as numbers, times or
+// strings, whichever both parse as.
+func compare(v interface{}, arg string) int {
+	str := fmt.Sprint(v)
+
+	a, aerr := strconv.ParseFloat(str, 64)
+	b, berr := strconv.ParseFloat(arg, 64)
+	if aerr == nil && berr == nil {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	}
+
+	ta, aerr := time.Parse(time.RFC3339Nano, str)
+	tb, berr := time.Parse(time.RFC3339Nano, arg)
+	if aerr == nil && berr == nil {
+		return ta.Compare(tb)
+	}
+
+	return strings.Compare(str, arg)
+}
+
+// copyObject returns a deep copy of a rendered object, so callers of the
+// Server methods cannot change stored state.
+func copyObject(v map[string]interface{}) map[string]interface{} {
+	data, _ := json.Marshal(v)
+	var out map[string]interface{}
+	_ = json.Unmarshal(data, &out)
+	return out
+}
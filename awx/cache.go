@@ -0,0 +1,419 @@
+package awx
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCacheTTL        = time.Minute
+	defaultCacheMaxEntries = 1024
+)
+
+// CacheOptions configures a Cache.
+type CacheOptions struct {
+	// TTL is how long a cached object is served without contacting AWX. It
+	// defaults to one minute.
+	TTL time.Duration
+	// MaxEntries bounds the number of cached objects; the least recently used
+	// object is evicted first. It defaults to 1024.
+	MaxEntries int
+}
+
+// CacheStats reports the activity of a Cache.
+type CacheStats struct {
+	Hits          uint64
+	Misses        uint64
+	Revalidations uint64
+	Evictions     uint64
+	Invalidations uint64
+	Entries       int
+}
+
+// Cache is a read-through cache for the Get methods of the Client services.
+// Objects changed or deleted through the same Client are invalidated
+// immediately, and a Get in flight at the time is not cached; changes made
+// elsewhere become visible once the TTL expires.
+// When AWX returned an ETag or Last-Modified header for an object, expired
+// entries are revalidated with a conditional request instead of being
+// fetched again. A Cache is safe for concurrent use.
+type Cache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	stats   CacheStats
+	// fills tracks the keys being fetched, so that a response fetched before
+	// the object was invalidated is not stored after it.
+	fills map[string]*cacheFill
+}
+
+// cacheFill counts the fetches in flight for a key and the invalidations of
+// the key since the first of them started.
+type cacheFill struct {
+	pending int
+	version uint64
+}
+
+type cacheEntry struct {
+	key string
+	// data is the object encoded to JSON, which is decoded into a new value
+	// for every caller so none of them share maps, slices or pointers.
+	data         []byte
+	expires      time.Time
+	header       http.Header
+	etag         string
+	lastModified string
+}
+
+// NewCache returns a new Cache. Pass it to New with WithCache.
+func NewCache(opts *CacheOptions) *Cache {
+	c := &Cache{
+		ttl:        defaultCacheTTL,
+		maxEntries: defaultCacheMaxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		fills:      make(map[string]*cacheFill),
+	}
+	if opts != nil {
+		if opts.TTL > 0 {
+			c.ttl = opts.TTL
+		}
+		if opts.MaxEntries > 0 {
+			c.maxEntries = opts.MaxEntries
+		}
+	}
+	return c
+}
+
+// WithCache is a client option that serves the Get methods of the
+// Organization, Inventory, InventorySource, Project, JobTemplate,
+// WorkflowJobTemplate, ExecutionEnvironment, InstanceGroup, Instance and
+// Label services from cache. Jobs are never cached. Objects changed through
+// the Raw service are removed from the cache; a Patch of a named URL that
+// AWX rejects removes the whole collection, as its object is unknown.
+func WithCache(cache *Cache) ClientOpt {
+	return func(c *Client) error {
+		if cache == nil {
+			return NewArgError("cache", "cannot be nil")
+		}
+
+		c.Organization = &cachedOrganizationService{OrganizationService: c.Organization, cache: cache}
+		c.Inventory = &cachedInventoryService{InventoryService: c.Inventory, cache: cache}
+		c.InventorySource = &cachedInventorySourceService{InventorySourceService: c.InventorySource, cache: cache}
+		c.Project = &cachedProjectService{ProjectService: c.Project, cache: cache}
+		c.JobTemplate = &cachedJobTemplateService{JobTemplateService: c.JobTemplate, cache: cache}
+		c.WorkflowJobTemplate = &cachedWorkflowJobTemplateService{WorkflowJobTemplateService: c.WorkflowJobTemplate, cache: cache}
+		c.ExecutionEnvironment = &cachedExecutionEnvironmentService{ExecutionEnvironmentService: c.ExecutionEnvironment, cache: cache}
+		c.InstanceGroup = &cachedInstanceGroupService{InstanceGroupService: c.InstanceGroup, cache: cache}
+		c.Instance = &cachedInstanceService{InstanceService: c.Instance, cache: cache}
+		c.Label = &cachedLabelService{LabelService: c.Label, cache: cache}
+		c.Raw = &cachedRawService{RawService: c.Raw, cache: cache}
+
+		return nil
+	}
+}
+
+// Stats returns a snapshot of the cache counters.
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+	return stats
+}
+
+// Purge removes every cached object.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stats.Invalidations += uint64(c.lru.Len())
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+	for _, fill := range c.fills {
+		fill.version++
+	}
+}
+
+// invalidate removes the object with the given ID fetched from basePath.
+func (c *Cache) invalidate(basePath string, id int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := cacheKey(basePath, id)
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+		c.stats.Invalidations++
+	}
+	if fill, ok := c.fills[key]; ok {
+		fill.version++
+	}
+}
+
+// invalidatePath removes the object changed through path, which may be
+// relative to the APIRoot or absolute, and may be the path of the object, of
+// one of its sub-resources, or a named URL. The object AWX returned, changed,
+// is removed by its ID and URL when it has them, and the object of path by
+// the last numeric ID in path. If neither identifies an object, as for a
+// named URL that AWX rejected, every cached object of the collection in path
+// is removed.
+func (c *Cache) invalidatePath(path string, changed map[string]interface{}) {
+	resolved := false
+	if id, ok := changed["id"].(float64); ok {
+		if u, ok := changed["url"].(string); ok {
+			if basePath, _, ok := objectPath(u); ok {
+				c.invalidate(basePath, int(id))
+				resolved = true
+			}
+		}
+	}
+
+	basePath, ident, ok := objectPath(path)
+	if !ok {
+		return
+	}
+	if id, err := strconv.Atoi(ident); err == nil {
+		c.invalidate(basePath, id)
+		return
+	}
+	if !resolved {
+		c.invalidateCollection(basePath)
+	}
+}
+
+// objectPath splits the path of an object, or of one of its sub-resources,
+// into the base path of its collection and the identifier of the object:
+// the last numeric ID in path, or else a named URL such as
+// "deploy++Default", or else the last segment.
+func objectPath(path string) (basePath, ident string, ok bool) {
+	path, _, _ = strings.Cut(path, "?")
+	if u, err := url.Parse(path); err == nil {
+		path = u.Path
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i := len(segments) - 1; i > 0; i-- {
+		if _, err := strconv.Atoi(segments[i]); err == nil {
+			return segments[i-1] + "/", segments[i], true
+		}
+	}
+	for i := len(segments) - 1; i > 0; i-- {
+		if strings.Contains(segments[i], "++") {
+			return segments[i-1] + "/", segments[i], true
+		}
+	}
+	if len(segments) < 2 {
+		return "", "", false
+	}
+	return segments[len(segments)-2] + "/", segments[len(segments)-1], true
+}
+
+// invalidateCollection removes every object fetched from basePath.
+func (c *Cache) invalidateCollection(basePath string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, el := range c.entries {
+		if strings.HasPrefix(key, basePath) {
+			c.remove(el)
+			c.stats.Invalidations++
+		}
+	}
+	for key, fill := range c.fills {
+		if strings.HasPrefix(key, basePath) {
+			fill.version++
+		}
+	}
+}
+
+func (c *Cache) lookup(key string) (*cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(el)
+
+	entry := *el.Value.(*cacheEntry)
+	return &entry, true
+}
+
+// startFill records that the object at key is being fetched, and returns the
+// version to pass to store or endFill once it has been.
+func (c *Cache) startFill(key string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fill, ok := c.fills[key]
+	if !ok {
+		fill = &cacheFill{}
+		c.fills[key] = fill
+	}
+	fill.pending++
+	return fill.version
+}
+
+// endFill records that a fetch of the object at key started at version is
+// done, and reports whether the object was not invalidated in the meantime.
+// c.mu must be held.
+func (c *Cache) endFill(key string, version uint64) bool {
+	fill, ok := c.fills[key]
+	if !ok {
+		return false
+	}
+	fill.pending--
+	if fill.pending == 0 {
+		delete(c.fills, key)
+	}
+	return fill.version == version
+}
+
+// abandonFill records that a fetch of the object at key started at version
+// is done without storing it.
+func (c *Cache) abandonFill(key string, version uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.endFill(key, version)
+}
+
+// store caches the object at key fetched since version, unless it was
+// invalidated while it was being fetched.
+func (c *Cache) store(key string, version uint64, data []byte, resp *Response) {
+	entry := &cacheEntry{
+		key:     key,
+		data:    data,
+		expires: time.Now().Add(c.ttl),
+	}
+	if resp != nil && resp.Response != nil {
+		entry.header = resp.Header.Clone()
+		entry.etag = resp.Header.Get("ETag")
+		entry.lastModified = resp.Header.Get("Last-Modified")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.endFill(key, version) {
+		return
+	}
+	if el, ok := c.entries[key]; ok {
+		el.Value = entry
+		c.lru.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+func (c *Cache) refresh(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*cacheEntry).expires = time.Now().Add(c.ttl)
+	}
+}
+
+func (c *Cache) remove(el *list.Element) {
+	c.lru.Remove(el)
+	delete(c.entries, el.Value.(*cacheEntry).key)
+}
+
+func (c *Cache) count(counter *uint64) {
+	c.mu.Lock()
+	*counter++
+	c.mu.Unlock()
+}
+
+// response builds the Response returned for an object served from cache.
+func (e *cacheEntry) response() *Response {
+	return newResponse(&http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     e.header.Clone(),
+	})
+}
+
+func cacheKey(basePath string, id int) string {
+	return fmt.Sprintf("%s%d/", basePath, id)
+}
+
+// conditionalKey is the context key under which cachedGet passes the
+// validators of a stale entry to NewRequest.
+type conditionalKey struct{}
+
+type conditionalHeaders struct {
+	etag         string
+	lastModified string
+}
+
+// cachedGet serves the object with the given ID from cache, calling fetch on
+// a miss or to revalidate an expired entry. Callers receive their own deep
+// copy of the cached object and, on a hit, a synthetic 200 Response carrying
+// the headers of the response the object was cached from.
+func cachedGet[T any](ctx context.Context, c *Cache, basePath string, id int, fetch func(context.Context) (*T, *Response, error)) (*T, *Response, error) {
+	key := cacheKey(basePath, id)
+
+	entry, ok := c.lookup(key)
+	if ok && time.Now().Before(entry.expires) {
+		if v, err := decodeCached[T](entry.data); err == nil {
+			c.count(&c.stats.Hits)
+			return v, entry.response(), nil
+		}
+	}
+
+	if ok && (entry.etag != "" || entry.lastModified != "") {
+		ctx = context.WithValue(ctx, conditionalKey{}, conditionalHeaders{etag: entry.etag, lastModified: entry.lastModified})
+	}
+
+	version := c.startFill(key)
+	obj, resp, err := fetch(ctx)
+	if err != nil {
+		c.abandonFill(key, version)
+		if errResp, isErrResp := err.(*ErrorResponse); ok && isErrResp && errResp.Response.StatusCode == http.StatusNotModified {
+			if v, err := decodeCached[T](entry.data); err == nil {
+				c.count(&c.stats.Revalidations)
+				c.refresh(key)
+				return v, resp, nil
+			}
+		}
+		return nil, resp, err
+	}
+
+	c.count(&c.stats.Misses)
+	if data, err := json.Marshal(obj); err == nil {
+		c.store(key, version, data, resp)
+	} else {
+		c.abandonFill(key, version)
+	}
+
+	return obj, resp, nil
+}
+
+// decodeCached decodes a cached object into a new value.
+func decodeCached[T any](data []byte) (*T, error) {
+	v := new(T)
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
@@ -0,0 +1,302 @@
+package awx
+
+import "context"
+
+// The cached services wrap the services of a Client when WithCache is used.
+// Get is served from the Cache; methods that change an object invalidate it.
+// Every other method is passed through to the wrapped service.
+
+type cachedOrganizationService struct {
+	OrganizationService
+	cache *Cache
+}
+
+func (s *cachedOrganizationService) Get(ctx context.Context, organizationID int) (*Organization, *Response, error) {
+	if organizationID < 1 {
+		return s.OrganizationService.Get(ctx, organizationID)
+	}
+	return cachedGet(ctx, s.cache, organizationBasePath, organizationID, func(ctx context.Context) (*Organization, *Response, error) {
+		return s.OrganizationService.Get(ctx, organizationID)
+	})
+}
+
+func (s *cachedOrganizationService) Update(ctx context.Context, createRequest *OrganizationCreateRequest, organizationID int) (*Response, error) {
+	defer s.cache.invalidate(organizationBasePath, organizationID)
+	return s.OrganizationService.Update(ctx, createRequest, organizationID)
+}
+
+func (s *cachedOrganizationService) Delete(ctx context.Context, organizationID int) (*Response, error) {
+	defer s.cache.invalidate(organizationBasePath, organizationID)
+	return s.OrganizationService.Delete(ctx, organizationID)
+}
+
+func (s *cachedOrganizationService) SetInstanceGroups(ctx context.Context, organizationID int, instanceGroupIDs []int) (*Response, error) {
+	defer s.cache.invalidate(organizationBasePath, organizationID)
+	return s.OrganizationService.SetInstanceGroups(ctx, organizationID, instanceGroupIDs)
+}
+
+type cachedInventoryService struct {
+	InventoryService
+	cache *Cache
+}
+
+func (s *cachedInventoryService) Get(ctx context.Context, inventoryID int) (*Inventory, *Response, error) {
+	if inventoryID < 1 {
+		return s.InventoryService.Get(ctx, inventoryID)
+	}
+	return cachedGet(ctx, s.cache, inventoryBasePath, inventoryID, func(ctx context.Context) (*Inventory, *Response, error) {
+		return s.InventoryService.Get(ctx, inventoryID)
+	})
+}
+
+func (s *cachedInventoryService) Update(ctx context.Context, createRequest *InventoryCreateRequest, inventoryID int) (*Response, error) {
+	defer s.cache.invalidate(inventoryBasePath, inventoryID)
+	return s.InventoryService.Update(ctx, createRequest, inventoryID)
+}
+
+func (s *cachedInventoryService) Delete(ctx context.Context, inventoryID int) (*Response, error) {
+	defer s.cache.invalidate(inventoryBasePath, inventoryID)
+	return s.InventoryService.Delete(ctx, inventoryID)
+}
+
+func (s *cachedInventoryService) SetInstanceGroups(ctx context.Context, inventoryID int, instanceGroupIDs []int) (*Response, error) {
+	defer s.cache.invalidate(inventoryBasePath, inventoryID)
+	return s.InventoryService.SetInstanceGroups(ctx, inventoryID, instanceGroupIDs)
+}
+
+func (s *cachedInventoryService) SyncAllSources(ctx context.Context, inventoryID int) ([]InventorySourceSync, *Response, error) {
+	defer s.cache.invalidate(inventoryBasePath, inventoryID)
+	return s.InventoryService.SyncAllSources(ctx, inventoryID)
+}
+
+type cachedInventorySourceService struct {
+	InventorySourceService
+	cache *Cache
+}
+
+func (s *cachedInventorySourceService) Get(ctx context.Context, inventorySourceID int) (*InventorySource, *Response, error) {
+	if inventorySourceID < 1 {
+		return s.InventorySourceService.Get(ctx, inventorySourceID)
+	}
+	return cachedGet(ctx, s.cache, inventorySourceBasePath, inventorySourceID, func(ctx context.Context) (*InventorySource, *Response, error) {
+		return s.InventorySourceService.Get(ctx, inventorySourceID)
+	})
+}
+
+func (s *cachedInventorySourceService) Update(ctx context.Context, createRequest *InventorySourceCreateRequest, inventorySourceID int) (*Response, error) {
+	defer s.cache.invalidate(inventorySourceBasePath, inventorySourceID)
+	return s.InventorySourceService.Update(ctx, createRequest, inventorySourceID)
+}
+
+func (s *cachedInventorySourceService) Delete(ctx context.Context, inventorySourceID int) (*Response, error) {
+	defer s.cache.invalidate(inventorySourceBasePath, inventorySourceID)
+	return s.InventorySourceService.Delete(ctx, inventorySourceID)
+}
+
+func (s *cachedInventorySourceService) Sync(ctx context.Context, inventorySourceID int) (*InventoryUpdate, *Response, error) {
+	defer s.cache.invalidate(inventorySourceBasePath, inventorySourceID)
+	return s.InventorySourceService.Sync(ctx, inventorySourceID)
+}
+
+type cachedProjectService struct {
+	ProjectService
+	cache *Cache
+}
+
+func (s *cachedProjectService) Get(ctx context.Context, projectID int) (*Project, *Response, error) {
+	if projectID < 1 {
+		return s.ProjectService.Get(ctx, projectID)
+	}
+	return cachedGet(ctx, s.cache, projectBasePath, projectID, func(ctx context.Context) (*Project, *Response, error) {
+		return s.ProjectService.Get(ctx, projectID)
+	})
+}
+
+func (s *cachedProjectService) Update(ctx context.Context, createRequest *ProjectCreateRequest, projectID int) (*Response, error) {
+	defer s.cache.invalidate(projectBasePath, projectID)
+	return s.ProjectService.Update(ctx, createRequest, projectID)
+}
+
+func (s *cachedProjectService) Delete(ctx context.Context, projectID int) (*Response, error) {
+	defer s.cache.invalidate(projectBasePath, projectID)
+	return s.ProjectService.Delete(ctx, projectID)
+}
+
+type cachedJobTemplateService struct {
+	JobTemplateService
+	cache *Cache
+}
+
+func (s *cachedJobTemplateService) Get(ctx context.Context, jobTemplateID int) (*JobTemplate, *Response, error) {
+	if jobTemplateID < 1 {
+		return s.JobTemplateService.Get(ctx, jobTemplateID)
+	}
+	return cachedGet(ctx, s.cache, jobTemplateBasePath, jobTemplateID, func(ctx context.Context) (*JobTemplate, *Response, error) {
+		return s.JobTemplateService.Get(ctx, jobTemplateID)
+	})
+}
+
+func (s *cachedJobTemplateService) Update(ctx context.Context, createRequest *JobTemplateCreateRequest, jobTemplateID int) (*Response, error) {
+	defer s.cache.invalidate(jobTemplateBasePath, jobTemplateID)
+	return s.JobTemplateService.Update(ctx, createRequest, jobTemplateID)
+}
+
+func (s *cachedJobTemplateService) Delete(ctx context.Context, jobTemplateID int) (*Response, error) {
+	defer s.cache.invalidate(jobTemplateBasePath, jobTemplateID)
+	return s.JobTemplateService.Delete(ctx, jobTemplateID)
+}
+
+func (s *cachedJobTemplateService) SetInstanceGroups(ctx context.Context, jobTemplateID int, instanceGroupIDs []int) (*Response, error) {
+	defer s.cache.invalidate(jobTemplateBasePath, jobTemplateID)
+	return s.JobTemplateService.SetInstanceGroups(ctx, jobTemplateID, instanceGroupIDs)
+}
+
+func (s *cachedJobTemplateService) AssociateLabel(ctx context.Context, jobTemplateID, labelID int) (*Response, error) {
+	defer s.cache.invalidate(jobTemplateBasePath, jobTemplateID)
+	return s.JobTemplateService.AssociateLabel(ctx, jobTemplateID, labelID)
+}
+
+func (s *cachedJobTemplateService) DisassociateLabel(ctx context.Context, jobTemplateID, labelID int) (*Response, error) {
+	defer s.cache.invalidate(jobTemplateBasePath, jobTemplateID)
+	return s.JobTemplateService.DisassociateLabel(ctx, jobTemplateID, labelID)
+}
+
+type cachedWorkflowJobTemplateService struct {
+	WorkflowJobTemplateService
+	cache *Cache
+}
+
+func (s *cachedWorkflowJobTemplateService) Get(ctx context.Context, workflowJobTemplateID int) (*WorkflowJobTemplate, *Response, error) {
+	if workflowJobTemplateID < 1 {
+		return s.WorkflowJobTemplateService.Get(ctx, workflowJobTemplateID)
+	}
+	return cachedGet(ctx, s.cache, workflowJobTemplateBasePath, workflowJobTemplateID, func(ctx context.Context) (*WorkflowJobTemplate, *Response, error) {
+		return s.WorkflowJobTemplateService.Get(ctx, workflowJobTemplateID)
+	})
+}
+
+func (s *cachedWorkflowJobTemplateService) Update(ctx context.Context, createRequest *WorkflowJobTemplateCreateRequest, workflowJobTemplateID int) (*Response, error) {
+	defer s.cache.invalidate(workflowJobTemplateBasePath, workflowJobTemplateID)
+	return s.WorkflowJobTemplateService.Update(ctx, createRequest, workflowJobTemplateID)
+}
+
+func (s *cachedWorkflowJobTemplateService) Delete(ctx context.Context, workflowJobTemplateID int) (*Response, error) {
+	defer s.cache.invalidate(workflowJobTemplateBasePath, workflowJobTemplateID)
+	return s.WorkflowJobTemplateService.Delete(ctx, workflowJobTemplateID)
+}
+
+func (s *cachedWorkflowJobTemplateService) AssociateLabel(ctx context.Context, workflowJobTemplateID, labelID int) (*Response, error) {
+	defer s.cache.invalidate(workflowJobTemplateBasePath, workflowJobTemplateID)
+	return s.WorkflowJobTemplateService.AssociateLabel(ctx, workflowJobTemplateID, labelID)
+}
+
+func (s *cachedWorkflowJobTemplateService) DisassociateLabel(ctx context.Context, workflowJobTemplateID, labelID int) (*Response, error) {
+	defer s.cache.invalidate(workflowJobTemplateBasePath, workflowJobTemplateID)
+	return s.WorkflowJobTemplateService.DisassociateLabel(ctx, workflowJobTemplateID, labelID)
+}
+
+type cachedExecutionEnvironmentService struct {
+	ExecutionEnvironmentService
+	cache *Cache
+}
+
+func (s *cachedExecutionEnvironmentService) Get(ctx context.Context, executionEnvironmentID int) (*ExecutionEnvironment, *Response, error) {
+	if executionEnvironmentID < 1 {
+		return s.ExecutionEnvironmentService.Get(ctx, executionEnvironmentID)
+	}
+	return cachedGet(ctx, s.cache, executionEnvironmentBasePath, executionEnvironmentID, func(ctx context.Context) (*ExecutionEnvironment, *Response, error) {
+		return s.ExecutionEnvironmentService.Get(ctx, executionEnvironmentID)
+	})
+}
+
+func (s *cachedExecutionEnvironmentService) Update(ctx context.Context, createRequest *ExecutionEnvironmentCreateRequest, executionEnvironmentID int) (*Response, error) {
+	defer s.cache.invalidate(executionEnvironmentBasePath, executionEnvironmentID)
+	return s.ExecutionEnvironmentService.Update(ctx, createRequest, executionEnvironmentID)
+}
+
+func (s *cachedExecutionEnvironmentService) Delete(ctx context.Context, executionEnvironmentID int) (*Response, error) {
+	defer s.cache.invalidate(executionEnvironmentBasePath, executionEnvironmentID)
+	return s.ExecutionEnvironmentService.Delete(ctx, executionEnvironmentID)
+}
+
+type cachedInstanceGroupService struct {
+	InstanceGroupService
+	cache *Cache
+}
+
+func (s *cachedInstanceGroupService) Get(ctx context.Context, instanceGroupID int) (*InstanceGroup, *Response, error) {
+	if instanceGroupID < 1 {
+		return s.InstanceGroupService.Get(ctx, instanceGroupID)
+	}
+	return cachedGet(ctx, s.cache, instanceGroupBasePath, instanceGroupID, func(ctx context.Context) (*InstanceGroup, *Response, error) {
+		return s.InstanceGroupService.Get(ctx, instanceGroupID)
+	})
+}
+
+func (s *cachedInstanceGroupService) Update(ctx context.Context, createRequest *InstanceGroupCreateRequest, instanceGroupID int) (*Response, error) {
+	defer s.cache.invalidate(instanceGroupBasePath, instanceGroupID)
+	return s.InstanceGroupService.Update(ctx, createRequest, instanceGroupID)
+}
+
+func (s *cachedInstanceGroupService) Delete(ctx context.Context, instanceGroupID int) (*Response, error) {
+	defer s.cache.invalidate(instanceGroupBasePath, instanceGroupID)
+	return s.InstanceGroupService.Delete(ctx, instanceGroupID)
+}
+
+func (s *cachedInstanceGroupService) AssociateInstance(ctx context.Context, instanceGroupID, instanceID int) (*Response, error) {
+	defer s.cache.invalidate(instanceGroupBasePath, instanceGroupID)
+	return s.InstanceGroupService.AssociateInstance(ctx, instanceGroupID, instanceID)
+}
+
+func (s *cachedInstanceGroupService) DisassociateInstance(ctx context.Context, instanceGroupID, instanceID int) (*Response, error) {
+	defer s.cache.invalidate(instanceGroupBasePath, instanceGroupID)
+	return s.InstanceGroupService.DisassociateInstance(ctx, instanceGroupID, instanceID)
+}
+
+type cachedInstanceService struct {
+	InstanceService
+	cache *Cache
+}
+
+func (s *cachedInstanceService) Get(ctx context.Context, instanceID int) (*Instance, *Response, error) {
+	if instanceID < 1 {
+		return s.InstanceService.Get(ctx, instanceID)
+	}
+	return cachedGet(ctx, s.cache, instanceBasePath, instanceID, func(ctx context.Context) (*Instance, *Response, error) {
+		return s.InstanceService.Get(ctx, instanceID)
+	})
+}
+
+func (s *cachedInstanceService) Update(ctx context.Context, updateRequest *InstanceUpdateRequest, instanceID int) (*Response, error) {
+	return s.InstanceService.Update(ctx, updateRequest, instanceID)
+}
+
+type cachedLabelService struct {
+	LabelService
+	cache *Cache
+}
+
+func (s *cachedLabelService) Get(ctx context.Context, labelID int) (*Label, *Response, error) {
+	if labelID < 1 {
+		return s.LabelService.Get(ctx, labelID)
+	}
+	return cachedGet(ctx, s.cache, labelBasePath, labelID, func(ctx context.Context) (*Label, *Response, error) {
+		return s.LabelService.Get(ctx, labelID)
+	})
+}
+
+func (s *cachedLabelService) Update(ctx context.Context, createRequest *LabelCreateRequest, labelID int) (*Response, error) {
+	defer s.cache.invalidate(labelBasePath, labelID)
+	return s.LabelService.Update(ctx, createRequest, labelID)
+}
+
+type cachedRawService struct {
+	RawService
+	cache *Cache
+}
+
+func (s *cachedRawService) Patch(ctx context.Context, path string, fields map[string]interface{}) (map[string]interface{}, *Response, error) {
+	changed, resp, err := s.RawService.Patch(ctx, path, fields)
+	s.cache.invalidatePath(path, changed)
+	return changed, resp, err
+}
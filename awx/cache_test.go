@@ -0,0 +1,270 @@
+package awx
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// cachedJobTemplateServer serves job template 1, also by the named URL
+// deploy++Default, and counts the GETs of it.
+func cachedJobTemplateServer(t *testing.T) (*Client, *int) {
+	t.Helper()
+
+	client, mux := setup(t)
+	gets := new(int)
+	mux.HandleFunc("/api/v2/job_templates/1/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			*gets++
+		}
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{
+			"id":                    1,
+			"name":                  "deploy",
+			"extra_vars":            `{"env": "prod"}`,
+			"execution_environment": 4,
+			"webhook_service":       "github",
+		})
+	})
+	mux.HandleFunc("/api/v2/job_templates/1/labels/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/api/v2/job_templates/1/instance_groups/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"count": 0, "results": []interface{}{}})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("/api/v2/job_templates/deploy++Default/", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{"id": 1, "url": "/api/v2/job_templates/1/", "name": "deploy"})
+	})
+	mux.HandleFunc("/api/v2/job_templates/missing++Default/", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusNotFound, map[string]interface{}{"detail": "Not found."})
+	})
+
+	if err := WithCache(NewCache(nil))(client); err != nil {
+		t.Fatalf("WithCache: %v", err)
+	}
+	return client, gets
+}
+
+func TestCacheReturnsDeepCopies(t *testing.T) {
+	client, gets := cachedJobTemplateServer(t)
+	ctx := context.Background()
+
+	first, _, err := client.JobTemplate.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	first.ExtraVars.Set("env", "dev")
+	*first.ExecutionEnvironment = 5
+	first.Extra["webhook_service"] = []byte(`"gitlab"`)
+
+	second, _, err := client.JobTemplate.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if *gets != 1 {
+		t.Fatalf("fetched the job template %d times, want it served from cache", *gets)
+	}
+	if env, _ := second.ExtraVars.Get("env"); env != "prod" {
+		t.Errorf("cached extra_vars env = %v, want prod", env)
+	}
+	if *second.ExecutionEnvironment != 4 {
+		t.Errorf("cached execution_environment = %d, want 4", *second.ExecutionEnvironment)
+	}
+	if got := string(second.Extra["webhook_service"]); got != `"github"` {
+		t.Errorf("cached webhook_service = %s, want \"github\"", got)
+	}
+}
+
+func TestCacheInvalidatedByRelatedChanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(ctx context.Context, c *Client) (*Response, error)
+	}{
+		{
+			name: "AssociateLabel",
+			change: func(ctx context.Context, c *Client) (*Response, error) {
+				return c.JobTemplate.AssociateLabel(ctx, 1, 2)
+			},
+		},
+		{
+			name: "DisassociateLabel",
+			change: func(ctx context.Context, c *Client) (*Response, error) {
+				return c.JobTemplate.DisassociateLabel(ctx, 1, 2)
+			},
+		},
+		{
+			name: "SetInstanceGroups",
+			change: func(ctx context.Context, c *Client) (*Response, error) {
+				return c.JobTemplate.SetInstanceGroups(ctx, 1, []int{3})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, gets := cachedJobTemplateServer(t)
+			ctx := context.Background()
+
+			if _, _, err := client.JobTemplate.Get(ctx, 1); err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if _, err := tt.change(ctx, client); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			if _, _, err := client.JobTemplate.Get(ctx, 1); err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if *gets != 2 {
+				t.Errorf("fetched the job template %d times, want 2", *gets)
+			}
+		})
+	}
+}
+
+func TestCacheInvalidatedByInstanceChanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(ctx context.Context, c *Client) (*Response, error)
+	}{
+		{
+			name: "AssociateInstance",
+			change: func(ctx context.Context, c *Client) (*Response, error) {
+				return c.InstanceGroup.AssociateInstance(ctx, 1, 2)
+			},
+		},
+		{
+			name: "DisassociateInstance",
+			change: func(ctx context.Context, c *Client) (*Response, error) {
+				return c.InstanceGroup.DisassociateInstance(ctx, 1, 2)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux := setup(t)
+			instances := 0
+			mux.HandleFunc("/api/v2/instance_groups/1/", func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(t, w, http.StatusOK, map[string]interface{}{"id": 1, "name": "default", "instances": instances})
+			})
+			mux.HandleFunc("/api/v2/instance_groups/1/instances/", func(w http.ResponseWriter, r *http.Request) {
+				instances++
+				w.WriteHeader(http.StatusNoContent)
+			})
+			if err := WithCache(NewCache(nil))(client); err != nil {
+				t.Fatalf("WithCache: %v", err)
+			}
+			ctx := context.Background()
+
+			if _, _, err := client.InstanceGroup.Get(ctx, 1); err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if _, err := tt.change(ctx, client); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			group, _, err := client.InstanceGroup.Get(ctx, 1)
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if group.Instances != 1 {
+				t.Errorf("instances = %d, want the count after %s", group.Instances, tt.name)
+			}
+		})
+	}
+}
+
+func TestCacheDropsFetchInvalidatedInFlight(t *testing.T) {
+	client, mux := setup(t)
+	var (
+		mu       sync.Mutex
+		name     = "deploy"
+		gets     int
+		fetching = make(chan struct{})
+		release  = make(chan struct{})
+	)
+	mux.HandleFunc("/api/v2/job_templates/1/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		if r.Method != http.MethodGet {
+			name = "release"
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		gets++
+		first, current := gets == 1, name
+		mu.Unlock()
+
+		if first {
+			// Hold the first response, read before the update, until the
+			// update has been made.
+			close(fetching)
+			<-release
+		}
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{"id": 1, "name": current})
+	})
+	if err := WithCache(NewCache(nil))(client); err != nil {
+		t.Fatalf("WithCache: %v", err)
+	}
+	ctx := context.Background()
+
+	done := make(chan error)
+	go func() {
+		_, _, err := client.JobTemplate.Get(ctx, 1)
+		done <- err
+	}()
+	<-fetching
+	if _, err := client.JobTemplate.Update(ctx, &JobTemplateCreateRequest{Name: "release"}, 1); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	jt, _, err := client.JobTemplate.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if jt.Name != "release" {
+		t.Errorf("Get after Update = %q, want release rather than the response fetched before it", jt.Name)
+	}
+}
+
+func TestCacheInvalidatedByRawPatch(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "relative", path: "job_templates/1/"},
+		{name: "absolute", path: "/api/v2/job_templates/1/"},
+		{name: "url", path: "{base}api/v2/job_templates/1/?format=json"},
+		{name: "sub-resource", path: "/api/v2/job_templates/1/labels/"},
+		{name: "named url", path: "job_templates/deploy++Default/"},
+		{name: "rejected named url", path: "job_templates/missing++Default/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, gets := cachedJobTemplateServer(t)
+			ctx := context.Background()
+
+			if _, _, err := client.JobTemplate.Get(ctx, 1); err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			path := strings.Replace(tt.path, "{base}", client.BaseURL.String(), 1)
+			client.Raw.Patch(ctx, path, map[string]interface{}{"name": "deploy"})
+			if _, _, err := client.JobTemplate.Get(ctx, 1); err != nil {
+				t.Fatalf("Get after Patch: %v", err)
+			}
+			if *gets != 2 {
+				t.Errorf("fetched the job template %d times, want 2", *gets)
+			}
+		})
+	}
+}
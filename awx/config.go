@@ -0,0 +1,268 @@
+package awx
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Environment variable prefixes, from lowest to highest precedence.
+var envPrefixes = []string{"TOWER_", "CONTROLLER_"}
+
+// systemConfigFile is the tower_cli.cfg file shared by every user, read
+// first by LoadConfig.
+var systemConfigFile = "/etc/tower/tower_cli.cfg"
+
+// generalSection is the section of a tower_cli.cfg file read for every
+// profile.
+const generalSection = "general"
+
+// Config holds the settings used to connect to AWX.
+type Config struct {
+	// Host is the URL of AWX. A host without a scheme is reached over https.
+	Host string
+	// Username and Password authenticate with basic auth.
+	Username string
+	Password string
+	// OAuthToken authenticates with a bearer token and takes precedence over
+	// Username and Password.
+	OAuthToken string
+	// InsecureSkipVerify disables verification of the AWX TLS certificate.
+	InsecureSkipVerify bool
+}
+
+// ConfigFiles are the tower_cli.cfg files LoadConfig reads when no path is
+// given, in order; settings in later files override earlier ones.
+func ConfigFiles() []string {
+	files := []string{systemConfigFile}
+	if home, err := os.UserHomeDir(); err == nil {
+		files = append(files, filepath.Join(home, ".tower_cli.cfg"))
+	}
+	return append(files, ".tower_cli.cfg")
+}
+
+// LoadConfig builds a Config from tower_cli.cfg style files and the
+// environment. Settings are applied in order of increasing precedence:
+//
+//  1. the [general] section of the config files
+//  2. the section named by profile, if not empty
+//  3. TOWER_HOST, TOWER_USERNAME, TOWER_PASSWORD, TOWER_OAUTH_TOKEN and
+//     TOWER_VERIFY_SSL
+//  4. the same variables prefixed with CONTROLLER_ instead of TOWER_
+//
+// If path is empty the ConfigFiles that exist are read; otherwise path must
+// exist. A profile that is not defined in any file is an error.
+func LoadConfig(path, profile string) (*Config, error) {
+	files := ConfigFiles()
+	if path != "" {
+		files = []string{path}
+	}
+
+	sections := make(map[string]map[string]string)
+	for _, file := range files {
+		err := readConfigFile(file, sections)
+		if errors.Is(err, fs.ErrNotExist) && path == "" {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cfg := &Config{}
+	if err := cfg.apply(sections[generalSection]); err != nil {
+		return nil, err
+	}
+	if profile != "" {
+		settings, ok := sections[profile]
+		if !ok {
+			return nil, fmt.Errorf("awx: profile %q not found in %s", profile, strings.Join(files, ", "))
+		}
+		if err := cfg.apply(settings); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// NewFromEnv returns a new AWX API client configured from the CONTROLLER_ and
+// TOWER_ environment variables described in LoadConfig. Options are applied
+// after the environment and override it.
+func NewFromEnv(opts ...ClientOpt) (*Client, error) {
+	cfg := &Config{}
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	return NewWithConfig(cfg, opts...)
+}
+
+// NewFromConfig returns a new AWX API client configured with LoadConfig from
+// the given file, or the default ConfigFiles if path is empty, and the
+// environment. Options are applied last and override both.
+func NewFromConfig(path, profile string, opts ...ClientOpt) (*Client, error) {
+	cfg, err := LoadConfig(path, profile)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithConfig(cfg, opts...)
+}
+
+// NewWithConfig returns a new AWX API client for cfg. Options are applied
+// after cfg and override it.
+func NewWithConfig(cfg *Config, opts ...ClientOpt) (*Client, error) {
+	if cfg == nil {
+		return nil, NewArgError("cfg", "cannot be nil")
+	}
+
+	c := NewClient(nil)
+	if cfg.InsecureSkipVerify {
+		if err := WithInsecureSkipVerify(true)(c); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Host != "" {
+		baseURL, err := parseHost(cfg.Host)
+		if err != nil {
+			return nil, err
+		}
+		c.BaseURL = baseURL
+	}
+	c.Username = cfg.Username
+	c.Password = cfg.Password
+	c.OAuthToken = cfg.OAuthToken
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// parseHost turns a configured host into a base URL, adding the https scheme
+// and trailing slash when missing.
+func parseHost(host string) (*url.URL, error) {
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("awx: invalid host %q: %w", host, err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("awx: invalid host %q: missing host name", host)
+	}
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	return u, nil
+}
+
+// apply sets the fields of cfg from the settings of a config file section.
+func (cfg *Config) apply(settings map[string]string) error {
+	for key, value := range settings {
+		if err := cfg.set(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyEnv sets the fields of cfg from the environment.
+func (cfg *Config) applyEnv() error {
+	for _, prefix := range envPrefixes {
+		for _, key := range []string{"host", "username", "password", "oauth_token", "verify_ssl"} {
+			name := prefix + strings.ToUpper(key)
+			if value, ok := os.LookupEnv(name); ok {
+				if err := cfg.set(key, value); err != nil {
+					return fmt.Errorf("%s: %w", name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (cfg *Config) set(key, value string) error {
+	switch key {
+	case "host":
+		cfg.Host = value
+	case "username":
+		cfg.Username = value
+	case "password":
+		cfg.Password = value
+	case "oauth_token":
+		cfg.OAuthToken = value
+	case "verify_ssl":
+		verify, err := parseConfigBool(value)
+		if err != nil {
+			return err
+		}
+		cfg.InsecureSkipVerify = !verify
+	}
+	return nil
+}
+
+func parseConfigBool(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "1", "t", "true", "y", "yes", "on":
+		return true, nil
+	case "0", "f", "false", "n", "no", "off":
+		return false, nil
+	}
+	return false, fmt.Errorf("awx: invalid boolean %q", value)
+}
+
+// readConfigFile reads the sections of an INI style config file into
+// sections, overriding settings already present.
+func readConfigFile(path string, sections map[string]map[string]string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	section := ""
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		if line[0] == '[' {
+			if !strings.HasSuffix(line, "]") {
+				return fmt.Errorf("awx: %s:%d: malformed section header", path, n)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+
+		i := strings.IndexAny(line, "=:")
+		if i < 0 {
+			return fmt.Errorf("awx: %s:%d: expected key = value", path, n)
+		}
+		if section == "" {
+			return fmt.Errorf("awx: %s:%d: setting outside of a section", path, n)
+		}
+
+		key := strings.ToLower(strings.TrimSpace(line[:i]))
+		value := strings.Trim(strings.TrimSpace(line[i+1:]), `"'`)
+		if sections[section] == nil {
+			sections[section] = make(map[string]string)
+		}
+		sections[section][key] = value
+	}
+
+	return scanner.Err()
+}
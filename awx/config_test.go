@@ -0,0 +1,250 @@
+package awx
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// configEnv are the environment variables LoadConfig reads.
+var configEnv = []string{
+	"TOWER_HOST", "TOWER_USERNAME", "TOWER_PASSWORD", "TOWER_OAUTH_TOKEN", "TOWER_VERIFY_SSL",
+	"CONTROLLER_HOST", "CONTROLLER_USERNAME", "CONTROLLER_PASSWORD", "CONTROLLER_OAUTH_TOKEN", "CONTROLLER_VERIFY_SSL",
+}
+
+// configDirs points the system config file, HOME and the working directory
+// at temporary directories with the given config files, and unsets the
+// config environment variables for the duration of the test. An empty file
+// is not written.
+func configDirs(t *testing.T, system, home, work string) {
+	t.Helper()
+
+	for _, name := range configEnv {
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+
+	dir := t.TempDir()
+	write := func(path, content string) {
+		if content == "" {
+			return
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("writing %s: %v", path, err)
+		}
+	}
+
+	orig := systemConfigFile
+	systemConfigFile = filepath.Join(dir, "tower_cli.cfg")
+	t.Cleanup(func() { systemConfigFile = orig })
+	write(systemConfigFile, system)
+
+	homeDir := filepath.Join(dir, "home")
+	workDir := filepath.Join(dir, "work")
+	for _, d := range []string{homeDir, workDir} {
+		if err := os.Mkdir(d, 0o700); err != nil {
+			t.Fatalf("creating %s: %v", d, err)
+		}
+	}
+	t.Setenv("HOME", homeDir)
+	write(filepath.Join(homeDir, ".tower_cli.cfg"), home)
+	t.Chdir(workDir)
+	write(".tower_cli.cfg", work)
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		system  string
+		home    string
+		work    string
+		env     map[string]string
+		profile string
+		want    Config
+		wantErr string
+	}{
+		{
+			name: "no files",
+			want: Config{},
+		},
+		{
+			name:   "system file",
+			system: "[general]\nhost = awx.example.com\nusername = admin\n",
+			want:   Config{Host: "awx.example.com", Username: "admin"},
+		},
+		{
+			name:   "home overrides system",
+			system: "[general]\nhost = awx.example.com\nusername = admin\n",
+			home:   "[general]\nhost = home.example.com\n",
+			want:   Config{Host: "home.example.com", Username: "admin"},
+		},
+		{
+			name:   "working directory overrides home",
+			system: "[general]\nusername = admin\n",
+			home:   "[general]\nhost = home.example.com\npassword = secret\n",
+			work:   "[general]\nhost: 'work.example.com'\n",
+			want:   Config{Host: "work.example.com", Username: "admin", Password: "secret"},
+		},
+		{
+			name:    "profile overrides general",
+			home:    "[staging]\nhost = staging.example.com\n",
+			work:    "[general]\nhost = awx.example.com\nusername = admin\n",
+			profile: "staging",
+			want:    Config{Host: "staging.example.com", Username: "admin"},
+		},
+		{
+			name: "profile left out",
+			home: "[staging]\nhost = staging.example.com\n",
+			work: "[general]\nhost = awx.example.com\n",
+			want: Config{Host: "awx.example.com"},
+		},
+		{
+			name: "TOWER_ overrides files",
+			work: "[general]\nhost = awx.example.com\nusername = admin\n",
+			env:  map[string]string{"TOWER_HOST": "tower.example.com", "TOWER_OAUTH_TOKEN": "token"},
+			want: Config{Host: "tower.example.com", Username: "admin", OAuthToken: "token"},
+		},
+		{
+			name: "CONTROLLER_ overrides TOWER_",
+			env:  map[string]string{"TOWER_HOST": "tower.example.com", "TOWER_USERNAME": "admin", "CONTROLLER_HOST": "controller.example.com"},
+			want: Config{Host: "controller.example.com", Username: "admin"},
+		},
+		{
+			name: "verify_ssl off",
+			work: "[general]\nverify_ssl = false\n",
+			want: Config{InsecureSkipVerify: true},
+		},
+		{
+			name: "verify_ssl on in the environment",
+			work: "[general]\nverify_ssl = no\n",
+			env:  map[string]string{"TOWER_VERIFY_SSL": "True"},
+			want: Config{},
+		},
+		{
+			name:    "invalid verify_ssl",
+			work:    "[general]\nverify_ssl = maybe\n",
+			wantErr: `invalid boolean "maybe"`,
+		},
+		{
+			name:    "invalid verify_ssl in the environment",
+			env:     map[string]string{"CONTROLLER_VERIFY_SSL": "sometimes"},
+			wantErr: "CONTROLLER_VERIFY_SSL",
+		},
+		{
+			name:    "unknown profile",
+			work:    "[general]\nhost = awx.example.com\n",
+			profile: "production",
+			wantErr: `profile "production" not found`,
+		},
+		{
+			name:    "profile without files",
+			profile: "production",
+			wantErr: `profile "production" not found`,
+		},
+		{
+			name:    "malformed file",
+			work:    "host = awx.example.com\n",
+			wantErr: "setting outside of a section",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configDirs(t, tt.system, tt.home, tt.work)
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			cfg, err := LoadConfig("", tt.profile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadConfig: %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfig: %v", err)
+			}
+			if !reflect.DeepEqual(*cfg, tt.want) {
+				t.Errorf("LoadConfig = %+v, want %+v", *cfg, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigPath(t *testing.T) {
+	configDirs(t, "", "[general]\nhost = home.example.com\n", "")
+	path := filepath.Join(t.TempDir(), "awx.cfg")
+	if err := os.WriteFile(path, []byte("[general]\nusername = admin\n[prod]\nhost = prod.example.com\n"), 0o600); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+
+	cfg, err := LoadConfig(path, "prod")
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if want := (Config{Host: "prod.example.com", Username: "admin"}); *cfg != want {
+		t.Errorf("LoadConfig = %+v, want only the settings of %s: %+v", *cfg, path, want)
+	}
+
+	if _, err := LoadConfig(filepath.Join(t.TempDir(), "missing.cfg"), ""); !os.IsNotExist(err) {
+		t.Errorf("LoadConfig of a missing file: %v, want a not-exist error", err)
+	}
+}
+
+func TestNewFromEnv(t *testing.T) {
+	configDirs(t, "", "", "")
+	t.Setenv("TOWER_HOST", "awx.example.com")
+	t.Setenv("TOWER_USERNAME", "admin")
+	t.Setenv("CONTROLLER_PASSWORD", "secret")
+	t.Setenv("CONTROLLER_VERIFY_SSL", "off")
+
+	client, err := NewFromEnv()
+	if err != nil {
+		t.Fatalf("NewFromEnv: %v", err)
+	}
+	if got := client.BaseURL.String(); got != "https://awx.example.com/" {
+		t.Errorf("BaseURL = %q, want https://awx.example.com/", got)
+	}
+	if client.Username != "admin" || client.Password != "secret" {
+		t.Errorf("credentials = %q, %q, want admin, secret", client.Username, client.Password)
+	}
+	if client.ownTransport == nil || !client.ownTransport.TLSClientConfig.InsecureSkipVerify {
+		t.Errorf("TLS verification enabled, want it disabled by CONTROLLER_VERIFY_SSL")
+	}
+}
+
+func TestNewFromConfig(t *testing.T) {
+	configDirs(t, "", "", "[general]\nhost = http://awx.example.com:8052/awx\noauth_token = token\n")
+
+	client, err := NewFromConfig("", "", func(c *Client) error {
+		c.OAuthToken = "override"
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("NewFromConfig: %v", err)
+	}
+	if got := client.BaseURL.String(); got != "http://awx.example.com:8052/awx/" {
+		t.Errorf("BaseURL = %q, want the host with a trailing slash", got)
+	}
+	if client.OAuthToken != "override" {
+		t.Errorf("OAuthToken = %q, want the option to override the file", client.OAuthToken)
+	}
+	if client.ownTransport != nil {
+		t.Errorf("transport replaced, want the default with TLS verification")
+	}
+
+	if _, err := NewFromConfig("", "missing"); err == nil {
+		t.Errorf("NewFromConfig with an unknown profile succeeded")
+	}
+}
+
+func TestParseHost(t *testing.T) {
+	for _, host := range []string{"https://", "http:// bad"} {
+		if _, err := parseHost(host); err == nil {
+			t.Errorf("parseHost(%q) succeeded, want an error", host)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const executionEnvironmentBasePath = "execution_environments/"
+
+// Pull policies for ExecutionEnvironment images.
+const (
+	PullAlways  = "always"
+	PullMissing = "missing"
+	PullNever   = "never"
+)
+
+// ExecutionEnvironmentService is an interface for interfacing with the ExecutionEnvironment
+// endpoints of the AWX API
+// See: http://localhost/api/v2/execution_environments/
+type ExecutionEnvironmentService interface {
+	List(context.Context) ([]ExecutionEnvironment, *Response, error)
+	Get(context.Context, int) (*ExecutionEnvironment, *Response, error)
+	Create(context.Context, *ExecutionEnvironmentCreateRequest) (*ExecutionEnvironment, *Response, error)
+	Update(context.Context, *ExecutionEnvironmentCreateRequest, int) (*Response, error)
+	Delete(context.Context, int) (*Response, error)
+}
+
+// ExecutionEnvironmentServiceOp handles communication with the ExecutionEnvironment related methods of the
+// AWX API.
+type ExecutionEnvironmentServiceOp struct {
+	client *Client
+}
+
+// ExecutionEnvironment represents a AWX ExecutionEnvironment, the container
+// image jobs are run in.
+type ExecutionEnvironment struct {
+	ID      int    `json:"id"`
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+	Related struct {
+		CreatedBy           string `json:"created_by"`
+		ModifiedBy          string `json:"modified_by"`
+		ActivityStream      string `json:"activity_stream"`
+		UnifiedJobTemplates string `json:"unified_job_templates"`
+		Copy                string `json:"copy"`
+		Organization        string `json:"organization"`
+		Credential          string `json:"credential"`
+	} `json:"related"`
+	SummaryFields struct {
+		Organization     *ObjectSummary     `json:"organization"`
+		Credential       *CredentialSummary `json:"credential"`
+		CreatedBy        *UserSummary       `json:"created_by"`
+		ModifiedBy       *UserSummary       `json:"modified_by"`
+		UserCapabilities UserCapabilities   `json:"user_capabilities"`
+	} `json:"summary_fields"`
+	Created      time.Time `json:"created"`
+	Modified     time.Time `json:"modified"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	Organization *int      `json:"organization"`
+	Image        string    `json:"image"`
+	Managed      bool      `json:"managed"`
+	Credential   *int      `json:"credential"`
+	Pull         string    `json:"pull"`
+	// Extra holds the fields returned by AWX that have no field above,
+	// such as fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a ExecutionEnvironment, keeping unknown fields in Extra.
+func (ee *ExecutionEnvironment) UnmarshalJSON(data []byte) error {
+	type executionEnvironment ExecutionEnvironment
+	extra, err := unmarshalWithExtra(data, (*executionEnvironment)(ee))
+	ee.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a ExecutionEnvironment together with the fields in Extra.
+func (ee ExecutionEnvironment) MarshalJSON() ([]byte, error) {
+	type executionEnvironment ExecutionEnvironment
+	return marshalWithExtra(executionEnvironment(ee), ee.Extra)
+}
+
+// ExecutionEnvironmentCreateRequest represents a request to create a ExecutionEnvironment.
+// Credential is the container registry credential used to pull Image.
+type ExecutionEnvironmentCreateRequest struct {
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	Organization int    `json:"organization,omitempty"`
+	Image        string `json:"image"`
+	Credential   int    `json:"credential,omitempty"`
+	Pull         string `json:"pull,omitempty"`
+	// Extra holds fields to send that have no field above, such as
+	// fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes a ExecutionEnvironmentCreateRequest together with the fields in Extra.
+func (r ExecutionEnvironmentCreateRequest) MarshalJSON() ([]byte, error) {
+	type request ExecutionEnvironmentCreateRequest
+	return marshalWithExtra(request(r), r.Extra)
+}
+
+// executionEnvironmentRoot represents a ExecutionEnvironment root
+type executionEnvironmentRoot struct {
+	Count    int                    `json:"count"`
+	Next     string                 `json:"next"`
+	Previous string                 `json:"previous"`
+	Results  []ExecutionEnvironment `json:"results"`
+}
+
+// List all ExecutionEnvironments.
+func (s *ExecutionEnvironmentServiceOp) List(ctx context.Context) ([]ExecutionEnvironment, *Response, error) {
+	path := executionEnvironmentBasePath
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(executionEnvironmentRoot)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root.Results, resp, err
+}
+
+// Get individual ExecutionEnvironment.
+func (s *ExecutionEnvironmentServiceOp) Get(ctx context.Context, executionEnvironmentID int) (*ExecutionEnvironment, *Response, error) {
+	if executionEnvironmentID < 1 {
+		return nil, nil, NewArgError("executionEnvironmentID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/", executionEnvironmentBasePath, executionEnvironmentID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(ExecutionEnvironment)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Create ExecutionEnvironment
+func (s *ExecutionEnvironmentServiceOp) Create(ctx context.Context, createRequest *ExecutionEnvironmentCreateRequest) (*ExecutionEnvironment, *Response, error) {
+	if createRequest == nil {
+		return nil, nil, NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := executionEnvironmentBasePath
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(ExecutionEnvironment)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Update ExecutionEnvironment.
+func (s *ExecutionEnvironmentServiceOp) Update(ctx context.Context, createRequest *ExecutionEnvironmentCreateRequest, executionEnvironmentID int) (*Response, error) {
+	if executionEnvironmentID < 1 {
+		return nil, NewArgError("executionEnvironmentID", "cannot be less than 1")
+	}
+	if createRequest == nil {
+		return nil, NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := fmt.Sprintf("%s%d/", executionEnvironmentBasePath, executionEnvironmentID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodPatch, path, createRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+
+	return resp, err
+}
+
+// Delete ExecutionEnvironment.
+func (s *ExecutionEnvironmentServiceOp) Delete(ctx context.Context, executionEnvironmentID int) (*Response, error) {
+	if executionEnvironmentID < 1 {
+		return nil, NewArgError("executionEnvironmentID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/", executionEnvironmentBasePath, executionEnvironmentID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+
+	return resp, err
+}
@@ -0,0 +1,99 @@
+package awx
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// knownFields caches the JSON field names of the struct types decoded by
+// unmarshalWithExtra.
+var knownFields sync.Map // reflect.Type -> map[string]bool
+
+// jsonFields returns the names of the JSON fields of struct type t.
+func jsonFields(t reflect.Type) map[string]bool {
+	if fields, ok := knownFields.Load(t); ok {
+		return fields.(map[string]bool)
+	}
+
+	fields := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		fields[name] = true
+	}
+
+	knownFields.Store(t, fields)
+	return fields
+}
+
+// unmarshalWithExtra decodes data into v, a pointer to a struct, and returns
+// the fields of data v has no field for, or nil if there are none. Like
+// json.Unmarshal it keeps going after a field of the wrong type, so the
+// unknown fields are returned along with the error.
+func unmarshalWithExtra(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	err := json.Unmarshal(data, v)
+
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(data, &fields) != nil {
+		return nil, err
+	}
+
+	known := jsonFields(reflect.TypeOf(v).Elem())
+	for name := range fields {
+		if known[name] {
+			delete(fields, name)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, err
+	}
+	return fields, err
+}
+
+// marshalWithExtra encodes v, a struct or a pointer to one, and adds the
+// fields of extra v did not encode itself.
+func marshalWithExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range extra {
+		if _, ok := fields[name]; !ok {
+			fields[name] = value
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// clearID clears an optional ID field of a request set to a pointer to 0:
+// the field is left out and sent through the returned extra fields as null,
+// which is how AWX expects a related object to be unset.
+func clearID(id **int, name string, extra map[string]json.RawMessage) map[string]json.RawMessage {
+	if *id == nil || **id != 0 {
+		return extra
+	}
+	*id = nil
+
+	cleared := make(map[string]json.RawMessage, len(extra)+1)
+	for k, v := range extra {
+		cleared[k] = v
+	}
+	cleared[name] = json.RawMessage("null")
+	return cleared
+}
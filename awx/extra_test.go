@@ -0,0 +1,123 @@
+package awx
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestExtraRoundTrip(t *testing.T) {
+	data := []byte(`{"id": 7, "name": "deploy", "webhook_service": "github", "job_slice_count": 2, "prevent_instance_group_fallback": null}`)
+
+	var jt JobTemplate
+	if err := json.Unmarshal(data, &jt); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got := string(jt.Extra["webhook_service"]); got != `"github"` {
+		t.Errorf("Extra webhook_service = %s, want \"github\"", got)
+	}
+	if _, ok := jt.Extra["name"]; ok {
+		t.Errorf("Extra holds name, a field of JobTemplate")
+	}
+
+	encoded, err := json.Marshal(jt)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	for key, want := range map[string]interface{}{"id": 7.0, "name": "deploy", "webhook_service": "github", "job_slice_count": 2.0, "prevent_instance_group_fallback": nil} {
+		if got, ok := fields[key]; !ok || got != want {
+			t.Errorf("Marshal: %s = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestExtraDoesNotOverrideFields(t *testing.T) {
+	jt := JobTemplate{ID: 7, Name: "deploy", Extra: map[string]json.RawMessage{
+		"name":            json.RawMessage(`"other"`),
+		"webhook_service": json.RawMessage(`"github"`),
+	}}
+	encoded, err := json.Marshal(jt)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if fields["name"] != "deploy" || fields["webhook_service"] != "github" {
+		t.Errorf("Marshal = %s, want the name of the field and the webhook service of Extra", encoded)
+	}
+
+	req := JobTemplateCreateRequest{Name: "deploy", Extra: map[string]json.RawMessage{"name": json.RawMessage(`"other"`)}}
+	encoded, err = json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Marshal request: %v", err)
+	}
+	if !strings.Contains(string(encoded), `"name":"deploy"`) || strings.Contains(string(encoded), "other") {
+		t.Errorf("Marshal request = %s, want the name of the field", encoded)
+	}
+}
+
+func TestExtraKeptOnTypeError(t *testing.T) {
+	data := []byte(`{"id": "seven", "name": "deploy", "webhook_service": "github"}`)
+
+	var jt JobTemplate
+	err := json.Unmarshal(data, &jt)
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		t.Fatalf("Unmarshal: %v, want a *json.UnmarshalTypeError", err)
+	}
+	if jt.Name != "deploy" {
+		t.Errorf("Name = %q, want the fields that decoded", jt.Name)
+	}
+	if got := string(jt.Extra["webhook_service"]); got != `"github"` {
+		t.Errorf("Extra webhook_service = %s, want it kept despite the error", got)
+	}
+}
+
+func TestClearID(t *testing.T) {
+	tests := []struct {
+		name string
+		req  interface{}
+		want string
+	}{
+		{
+			name: "unset",
+			req:  &JobTemplateCreateRequest{Name: "deploy"},
+			want: `{"name":"deploy"}`,
+		},
+		{
+			name: "set",
+			req:  &JobTemplateCreateRequest{Name: "deploy", ExecutionEnvironment: Int(3)},
+			want: `{"execution_environment":3,"name":"deploy"}`,
+		},
+		{
+			name: "cleared",
+			req:  &JobTemplateCreateRequest{Name: "deploy", ExecutionEnvironment: Int(0)},
+			want: `{"execution_environment":null,"name":"deploy"}`,
+		},
+		{
+			name: "cleared default",
+			req:  &OrganizationCreateRequest{Name: "ops", DefaultEnvironment: Int(0)},
+			want: `{"default_environment":null,"name":"ops"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.req)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if !equalJSON(t, got, []byte(tt.want)) {
+				t.Errorf("Marshal = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"sync"
 )
 
 const (
@@ -23,22 +25,65 @@ type Client struct {
 	// HTTP client used to communicate with the AWX API.
 	client *http.Client
 
+	// Transport cloned for TLS and proxy options, if any were given
+	ownTransport *http.Transport
+
 	// Base URL for API requests.
 	BaseURL *url.URL
 
+	// Root of the AWX API, resolved against BaseURL. Paths used by the
+	// services are relative to it.
+	APIRoot string
+
 	// User agent for client
 	UserAgent string
 
 	// Services used for communicating with the API
-	Inventory       InventoryService
-	InventorySource InventorySourceService
-	Organization    OrganizationService
-	Project         ProjectService
-	JobTemplate     JobTemplateService
+	Inventory            InventoryService
+	InventorySource      InventorySourceService
+	InventoryUpdate      InventoryUpdateService
+	Organization         OrganizationService
+	Project              ProjectService
+	JobTemplate          JobTemplateService
+	WorkflowJobTemplate  WorkflowJobTemplateService
+	AdHocCommand         AdHocCommandService
+	ExecutionEnvironment ExecutionEnvironmentService
+	InstanceGroup        InstanceGroupService
+	Instance             InstanceService
+	Label                LabelService
+	UnifiedJob           UnifiedJobService
+	UnifiedJobTemplate   UnifiedJobTemplateService
+	ActivityStream       ActivityStreamService
+	Ping                 PingService
+	ServerConfig         ServerConfigService
+	Metadata             MetadataService
+	Raw                  RawService
 
 	//Basic Auth
 	Username string
 	Password string
+
+	// OAuth2 token, sent as a bearer token instead of basic auth if set
+	OAuthToken string
+
+	// Middleware wrapped around every request sent by Do
+	middleware []Middleware
+
+	// Logger for requests sent by Do, if set
+	logger *slog.Logger
+
+	// Rate limiter shared by every request sent by Do, if set
+	limiter *rateLimiter
+
+	// Server version, fetched once if version negotiation is enabled
+	negotiateVersion bool
+	versionMu        sync.Mutex
+	serverVersion    string
+	versionFetch     chan struct{}
+
+	// Whether APIRoot is yet to be discovered
+	discoverAPIRoot bool
+	rootMu          sync.Mutex
 }
 
 // Response is a AWX response. This wraps the standard http.Response returned from AWX.
@@ -56,6 +101,9 @@ type ErrorResponse struct {
 
 	// RequestID returned from the API, useful to contact support.
 	RequestID string `json:"request_id"`
+
+	// Fields maps each field rejected by a 400 Bad Request to its errors.
+	Fields map[string][]string `json:"-"`
 }
 
 // SetUserAgent is a client option for setting the user agent.
@@ -89,27 +137,41 @@ func NewClient(httpClient *http.Client) *Client {
 
 	baseURL, _ := url.Parse(defaultBaseURL)
 
-	c := &Client{client: httpClient, BaseURL: baseURL, UserAgent: userAgent}
+	c := &Client{client: httpClient, BaseURL: baseURL, APIRoot: defaultAPIRoot, UserAgent: userAgent}
 	c.Inventory = &InventoryServiceOp{client: c}
 	c.InventorySource = &InventorySourceServiceOp{client: c}
+	c.InventoryUpdate = &InventoryUpdateServiceOp{client: c}
 	c.Organization = &OrganizationServiceOp{client: c}
 	c.Project = &ProjectServiceOp{client: c}
 	c.JobTemplate = &JobTemplateServiceOp{client: c}
+	c.WorkflowJobTemplate = &WorkflowJobTemplateServiceOp{client: c}
+	c.AdHocCommand = &AdHocCommandServiceOp{client: c}
+	c.ExecutionEnvironment = &ExecutionEnvironmentServiceOp{client: c}
+	c.InstanceGroup = &InstanceGroupServiceOp{client: c}
+	c.Instance = &InstanceServiceOp{client: c}
+	c.Label = &LabelServiceOp{client: c}
+	c.UnifiedJob = &UnifiedJobServiceOp{client: c}
+	c.UnifiedJobTemplate = &UnifiedJobTemplateServiceOp{client: c}
+	c.ActivityStream = &ActivityStreamServiceOp{client: c}
+	c.Ping = &PingServiceOp{client: c}
+	c.ServerConfig = &ServerConfigServiceOp{client: c}
+	c.Metadata = &MetadataServiceOp{client: c}
+	c.Raw = &RawServiceOp{client: c}
 
 	return c
 }
 
 // NewRequest creates an API request. A relative URL can be provided in urlStr, which will be resolved to the
-// BaseURL of the Client. Relative URLS should always be specified without a preceding slash. If specified, the
-// value pointed to by body is JSON encoded and included in as the request body.
+// APIRoot of the Client, e.g. "inventories/". URLs with a preceding slash, such as those returned by AWX for
+// related objects and further pages, are resolved to the BaseURL instead. So are relative URLs starting with
+// "api/", e.g. "api/v2/inventories/", as they were before APIRoot was introduced. If specified, the value
+// pointed to by body is JSON encoded and included in as the request body.
 func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body interface{}) (*http.Request, error) {
-	rel, err := url.Parse(urlStr)
+	u, err := c.resolveURL(ctx, urlStr)
 	if err != nil {
 		return nil, err
 	}
 
-	u := c.BaseURL.ResolveReference(rel)
-
 	buf := new(bytes.Buffer)
 	if body != nil {
 		err = json.NewEncoder(buf).Encode(body)
@@ -126,15 +188,40 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body int
 	req.Header.Add("Content-Type", mediaType)
 	req.Header.Add("Accept", mediaType)
 	req.Header.Add("User-Agent", c.UserAgent)
-	req.SetBasicAuth(c.Username, c.Password)
+	if c.OAuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.OAuthToken)
+	} else {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	if cond, ok := ctx.Value(conditionalKey{}).(conditionalHeaders); ok {
+		if cond.etag != "" {
+			req.Header.Set("If-None-Match", cond.etag)
+		}
+		if cond.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cond.lastModified)
+		}
+	}
 	return req, nil
 }
 
 // Do sends an API request and returns the API response. The API response is JSON decoded and stored in the value
 // pointed to by v, or returned as an error if an API error has occurred. If v implements the io.Writer interface,
-// the raw response will be written to v, without attempting to decode it.
+// the raw response will be written to v, without attempting to decode it. The request is sent through any
+// Middleware configured with WithMiddleware.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
-	resp, err := DoRequestWithClient(ctx, c.client, req)
+	if c.negotiateVersion {
+		if err := c.checkVersion(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	var resp *http.Response
+	var err error
+	if c.logger != nil {
+		resp, err = c.logRequest(ctx, req)
+	} else {
+		resp, err = c.roundTrip(req.WithContext(ctx))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -197,11 +284,42 @@ func CheckResponse(r *http.Response) error {
 		err := json.Unmarshal(data, errorResponse)
 		if err != nil {
 			errorResponse.Message = string(data)
+		} else if r.StatusCode == http.StatusBadRequest {
+			errorResponse.Fields = fieldErrors(data)
+			if errorResponse.Message == "" {
+				errorResponse.Message = formatFieldErrors(errorResponse.Fields)
+			}
 		}
 	}
 	return errorResponse
 }
 
+// fieldErrors extracts the errors of a validation failure, which AWX returns
+// as an object mapping each field to a message or a list of messages.
+func fieldErrors(data []byte) map[string][]string {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+
+	fields := make(map[string][]string)
+	for name, value := range raw {
+		var msgs []string
+		if err := json.Unmarshal(value, &msgs); err == nil {
+			fields[name] = msgs
+			continue
+		}
+		var msg string
+		if err := json.Unmarshal(value, &msg); err == nil {
+			fields[name] = []string{msg}
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
+
 func (r *ErrorResponse) Error() string {
 	if r.RequestID != "" {
 		return fmt.Sprintf("%v %v: %d (request %q) %v",
@@ -210,3 +328,9 @@ func (r *ErrorResponse) Error() string {
 	return fmt.Sprintf("%v %v: %d %v",
 		r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.Message)
 }
+
+// Int is a helper routine that allocates a new int value to store v and
+// returns a pointer to it, for optional ID fields of requests.
+func Int(v int) *int {
+	return &v
+}
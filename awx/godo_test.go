@@ -0,0 +1,51 @@
+package awx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// setup returns a Client sending its requests to a test server with the
+// handlers of mux, rooted at /api/v2/.
+func setup(t *testing.T) (*Client, *http.ServeMux) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := NewClient(server.Client())
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	return client, mux
+}
+
+// writeJSON writes v as the JSON response to a request.
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, v interface{}) {
+	t.Helper()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Errorf("writing response: %v", err)
+	}
+}
+
+// equalJSON reports whether two JSON documents hold the same values,
+// ignoring the order of object keys.
+func equalJSON(t *testing.T, a, b []byte) bool {
+	t.Helper()
+
+	var va, vb interface{}
+	if err := json.Unmarshal(a, &va); err != nil {
+		t.Fatalf("decoding %s: %v", a, err)
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		t.Fatalf("decoding %s: %v", b, err)
+	}
+	return reflect.DeepEqual(va, vb)
+}
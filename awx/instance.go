@@ -0,0 +1,166 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const instanceBasePath = "instances/"
+
+// InstanceService is an interface for interfacing with the Instance
+// endpoints of the AWX API
+// See: http://localhost/api/v2/instances/
+type InstanceService interface {
+	List(context.Context) ([]Instance, *Response, error)
+	Get(context.Context, int) (*Instance, *Response, error)
+	Update(context.Context, *InstanceUpdateRequest, int) (*Response, error)
+}
+
+// InstanceServiceOp handles communication with the Instance related methods of the
+// AWX API.
+type InstanceServiceOp struct {
+	client *Client
+}
+
+// Instance represents a AWX Instance, a node of the cluster that runs jobs.
+type Instance struct {
+	ID      int    `json:"id"`
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+	Related struct {
+		Jobs           string `json:"jobs"`
+		InstanceGroups string `json:"instance_groups"`
+		HealthCheck    string `json:"health_check"`
+	} `json:"related"`
+	SummaryFields struct {
+		UserCapabilities UserCapabilities `json:"user_capabilities"`
+	} `json:"summary_fields"`
+	Hostname                 string     `json:"hostname"`
+	UUID                     string     `json:"uuid"`
+	Created                  time.Time  `json:"created"`
+	Modified                 time.Time  `json:"modified"`
+	LastSeen                 *time.Time `json:"last_seen"`
+	LastHealthCheck          *time.Time `json:"last_health_check"`
+	Errors                   string     `json:"errors"`
+	CapacityAdjustment       string     `json:"capacity_adjustment"`
+	Version                  string     `json:"version"`
+	Capacity                 int        `json:"capacity"`
+	ConsumedCapacity         float64    `json:"consumed_capacity"`
+	PercentCapacityRemaining float64    `json:"percent_capacity_remaining"`
+	JobsRunning              int        `json:"jobs_running"`
+	JobsTotal                int        `json:"jobs_total"`
+	CPU                      string     `json:"cpu"`
+	Memory                   int64      `json:"memory"`
+	CPUCapacity              int        `json:"cpu_capacity"`
+	MemCapacity              int        `json:"mem_capacity"`
+	Enabled                  bool       `json:"enabled"`
+	ManagedByPolicy          bool       `json:"managed_by_policy"`
+	NodeType                 string     `json:"node_type"`
+	NodeState                string     `json:"node_state"`
+	// Extra holds the fields returned by AWX that have no field above,
+	// such as fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a Instance, keeping unknown fields in Extra.
+func (i *Instance) UnmarshalJSON(data []byte) error {
+	type instance Instance
+	extra, err := unmarshalWithExtra(data, (*instance)(i))
+	i.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a Instance together with the fields in Extra.
+func (i Instance) MarshalJSON() ([]byte, error) {
+	type instance Instance
+	return marshalWithExtra(instance(i), i.Extra)
+}
+
+// InstanceUpdateRequest represents a request to update a Instance.
+// CapacityAdjustment is a decimal between "0" and "1" that moves the
+// capacity of the Instance between its CPU and memory based limits.
+type InstanceUpdateRequest struct {
+	Enabled            *bool  `json:"enabled,omitempty"`
+	ManagedByPolicy    *bool  `json:"managed_by_policy,omitempty"`
+	CapacityAdjustment string `json:"capacity_adjustment,omitempty"`
+	// Extra holds fields to send that have no field above, such as
+	// fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes a InstanceUpdateRequest together with the fields in Extra.
+func (r InstanceUpdateRequest) MarshalJSON() ([]byte, error) {
+	type request InstanceUpdateRequest
+	return marshalWithExtra(request(r), r.Extra)
+}
+
+// instanceRoot represents a Instance root
+type instanceRoot struct {
+	Count    int        `json:"count"`
+	Next     string     `json:"next"`
+	Previous string     `json:"previous"`
+	Results  []Instance `json:"results"`
+}
+
+// List all Instances.
+func (s *InstanceServiceOp) List(ctx context.Context) ([]Instance, *Response, error) {
+	path := instanceBasePath
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(instanceRoot)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root.Results, resp, err
+}
+
+// Get individual Instance.
+func (s *InstanceServiceOp) Get(ctx context.Context, instanceID int) (*Instance, *Response, error) {
+	if instanceID < 1 {
+		return nil, nil, NewArgError("instanceID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/", instanceBasePath, instanceID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Instance)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Update Instance.
+func (s *InstanceServiceOp) Update(ctx context.Context, updateRequest *InstanceUpdateRequest, instanceID int) (*Response, error) {
+	if instanceID < 1 {
+		return nil, NewArgError("instanceID", "cannot be less than 1")
+	}
+	if updateRequest == nil {
+		return nil, NewArgError("updateRequest", "cannot be nil")
+	}
+
+	path := fmt.Sprintf("%s%d/", instanceBasePath, instanceID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodPatch, path, updateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+
+	return resp, err
+}
@@ -0,0 +1,280 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const instanceGroupBasePath = "instance_groups/"
+
+// InstanceGroupService is an interface for interfacing with the InstanceGroup
+// endpoints of the AWX API
+// See: http://localhost/api/v2/instance_groups/
+type InstanceGroupService interface {
+	List(context.Context) ([]InstanceGroup, *Response, error)
+	Get(context.Context, int) (*InstanceGroup, *Response, error)
+	Create(context.Context, *InstanceGroupCreateRequest) (*InstanceGroup, *Response, error)
+	Update(context.Context, *InstanceGroupCreateRequest, int) (*Response, error)
+	Delete(context.Context, int) (*Response, error)
+	ListInstances(context.Context, int) ([]Instance, *Response, error)
+	AssociateInstance(context.Context, int, int) (*Response, error)
+	DisassociateInstance(context.Context, int, int) (*Response, error)
+}
+
+// InstanceGroupServiceOp handles communication with the InstanceGroup related methods of the
+// AWX API.
+type InstanceGroupServiceOp struct {
+	client *Client
+}
+
+// InstanceGroup represents a AWX InstanceGroup
+type InstanceGroup struct {
+	ID      int    `json:"id"`
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+	Related struct {
+		Jobs        string `json:"jobs"`
+		Instances   string `json:"instances"`
+		AccessList  string `json:"access_list"`
+		ObjectRoles string `json:"object_roles"`
+		Credential  string `json:"credential"`
+	} `json:"related"`
+	SummaryFields struct {
+		ObjectRoles struct {
+			AdminRole ObjectRole `json:"admin_role"`
+			UseRole   ObjectRole `json:"use_role"`
+			ReadRole  ObjectRole `json:"read_role"`
+		} `json:"object_roles"`
+		UserCapabilities UserCapabilities `json:"user_capabilities"`
+	} `json:"summary_fields"`
+	Name                     string    `json:"name"`
+	Created                  time.Time `json:"created"`
+	Modified                 time.Time `json:"modified"`
+	Capacity                 int       `json:"capacity"`
+	ConsumedCapacity         float64   `json:"consumed_capacity"`
+	PercentCapacityRemaining float64   `json:"percent_capacity_remaining"`
+	JobsRunning              int       `json:"jobs_running"`
+	MaxConcurrentJobs        int       `json:"max_concurrent_jobs"`
+	MaxForks                 int       `json:"max_forks"`
+	JobsTotal                int       `json:"jobs_total"`
+	Instances                int       `json:"instances"`
+	IsContainerGroup         bool      `json:"is_container_group"`
+	Credential               *int      `json:"credential"`
+	PolicyInstancePercentage int       `json:"policy_instance_percentage"`
+	PolicyInstanceMinimum    int       `json:"policy_instance_minimum"`
+	PolicyInstanceList       []string  `json:"policy_instance_list"`
+	PodSpecOverride          string    `json:"pod_spec_override"`
+	// Extra holds the fields returned by AWX that have no field above,
+	// such as fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a InstanceGroup, keeping unknown fields in Extra.
+func (ig *InstanceGroup) UnmarshalJSON(data []byte) error {
+	type instanceGroup InstanceGroup
+	extra, err := unmarshalWithExtra(data, (*instanceGroup)(ig))
+	ig.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a InstanceGroup together with the fields in Extra.
+func (ig InstanceGroup) MarshalJSON() ([]byte, error) {
+	type instanceGroup InstanceGroup
+	return marshalWithExtra(instanceGroup(ig), ig.Extra)
+}
+
+// InstanceGroupCreateRequest represents a request to create a InstanceGroup.
+// The policy fields control which Instances AWX assigns to the group
+// automatically.
+type InstanceGroupCreateRequest struct {
+	Name                     string   `json:"name"`
+	MaxConcurrentJobs        int      `json:"max_concurrent_jobs,omitempty"`
+	MaxForks                 int      `json:"max_forks,omitempty"`
+	IsContainerGroup         bool     `json:"is_container_group,omitempty"`
+	Credential               int      `json:"credential,omitempty"`
+	PolicyInstancePercentage int      `json:"policy_instance_percentage,omitempty"`
+	PolicyInstanceMinimum    int      `json:"policy_instance_minimum,omitempty"`
+	PolicyInstanceList       []string `json:"policy_instance_list,omitempty"`
+	PodSpecOverride          string   `json:"pod_spec_override,omitempty"`
+	// Extra holds fields to send that have no field above, such as
+	// fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes a InstanceGroupCreateRequest together with the fields in Extra.
+func (r InstanceGroupCreateRequest) MarshalJSON() ([]byte, error) {
+	type request InstanceGroupCreateRequest
+	return marshalWithExtra(request(r), r.Extra)
+}
+
+// List all InstanceGroups.
+func (s *InstanceGroupServiceOp) List(ctx context.Context) ([]InstanceGroup, *Response, error) {
+	return listInstanceGroups(ctx, s.client, instanceGroupBasePath)
+}
+
+// Get individual InstanceGroup.
+func (s *InstanceGroupServiceOp) Get(ctx context.Context, instanceGroupID int) (*InstanceGroup, *Response, error) {
+	if instanceGroupID < 1 {
+		return nil, nil, NewArgError("instanceGroupID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/", instanceGroupBasePath, instanceGroupID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(InstanceGroup)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Create InstanceGroup
+func (s *InstanceGroupServiceOp) Create(ctx context.Context, createRequest *InstanceGroupCreateRequest) (*InstanceGroup, *Response, error) {
+	if createRequest == nil {
+		return nil, nil, NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := instanceGroupBasePath
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(InstanceGroup)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Update InstanceGroup.
+func (s *InstanceGroupServiceOp) Update(ctx context.Context, createRequest *InstanceGroupCreateRequest, instanceGroupID int) (*Response, error) {
+	if instanceGroupID < 1 {
+		return nil, NewArgError("instanceGroupID", "cannot be less than 1")
+	}
+	if createRequest == nil {
+		return nil, NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := fmt.Sprintf("%s%d/", instanceGroupBasePath, instanceGroupID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodPatch, path, createRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+
+	return resp, err
+}
+
+// Delete InstanceGroup.
+func (s *InstanceGroupServiceOp) Delete(ctx context.Context, instanceGroupID int) (*Response, error) {
+	if instanceGroupID < 1 {
+		return nil, NewArgError("instanceGroupID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/", instanceGroupBasePath, instanceGroupID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+
+	return resp, err
+}
+
+// ListInstances lists the Instances that are members of an InstanceGroup.
+func (s *InstanceGroupServiceOp) ListInstances(ctx context.Context, instanceGroupID int) ([]Instance, *Response, error) {
+	if instanceGroupID < 1 {
+		return nil, nil, NewArgError("instanceGroupID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/instances/", instanceGroupBasePath, instanceGroupID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(instanceRoot)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root.Results, resp, err
+}
+
+// AssociateInstance adds an Instance to an InstanceGroup.
+func (s *InstanceGroupServiceOp) AssociateInstance(ctx context.Context, instanceGroupID, instanceID int) (*Response, error) {
+	if instanceGroupID < 1 {
+		return nil, NewArgError("instanceGroupID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/instances/", instanceGroupBasePath, instanceGroupID)
+
+	return associate(ctx, s.client, path, instanceID)
+}
+
+// DisassociateInstance removes an Instance from an InstanceGroup.
+func (s *InstanceGroupServiceOp) DisassociateInstance(ctx context.Context, instanceGroupID, instanceID int) (*Response, error) {
+	if instanceGroupID < 1 {
+		return nil, NewArgError("instanceGroupID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/instances/", instanceGroupBasePath, instanceGroupID)
+
+	return disassociate(ctx, s.client, path, instanceID)
+}
+
+// listInstanceGroups lists the InstanceGroups on every page of path, which is
+// either the instance_groups endpoint or the related instance_groups
+// collection of an Organization, Inventory or JobTemplate, in which case the
+// order of the result is the order AWX tries the groups in.
+func listInstanceGroups(ctx context.Context, client *Client, path string) ([]InstanceGroup, *Response, error) {
+	var groups []InstanceGroup
+	resp, err := listAllPages(ctx, client, path, func(results json.RawMessage) error {
+		var page []InstanceGroup
+		if err := json.Unmarshal(results, &page); err != nil {
+			return err
+		}
+		groups = append(groups, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return groups, resp, err
+}
+
+// setInstanceGroups replaces the related instance_groups collection at path
+// with the given InstanceGroup IDs, in order of preference.
+func setInstanceGroups(ctx context.Context, client *Client, path string, instanceGroupIDs []int) (*Response, error) {
+	groups, resp, err := listInstanceGroups(ctx, client, path)
+	if err != nil {
+		return resp, err
+	}
+
+	current := make([]int, len(groups))
+	for i, group := range groups {
+		current[i] = group.ID
+	}
+
+	return setOrderedAssociation(ctx, client, path, current, instanceGroupIDs)
+}
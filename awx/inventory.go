@@ -2,12 +2,13 @@ package awx
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 )
 
-const inventoryBasePath = "api/v2/inventories/"
+const inventoryBasePath = "inventories/"
 
 // InventoryService is an interface for interfacing with the Inventory
 // endpoints of the AWX API
@@ -18,6 +19,9 @@ type InventoryService interface {
 	Create(context.Context, *InventoryCreateRequest) (*Inventory, *Response, error)
 	Update(context.Context, *InventoryCreateRequest, int) (*Response, error)
 	Delete(context.Context, int) (*Response, error)
+	SyncAllSources(context.Context, int) ([]InventorySourceSync, *Response, error)
+	ListInstanceGroups(context.Context, int) ([]InstanceGroup, *Response, error)
+	SetInstanceGroups(context.Context, int, []int) (*Response, error)
 }
 
 // DropletsServiceOp handles communication with the Inventory related methods of the
@@ -54,61 +58,18 @@ type Inventory struct {
 		Organization           string `json:"organization"`
 	} `json:"related"`
 	SummaryFields struct {
-		InsightsCredential struct {
-			ID          int    `json:"id"`
-			Name        string `json:"name"`
-			Description string `json:"description"`
-		} `json:"insights_credential"`
-		Organization struct {
-			ID          int    `json:"id"`
-			Name        string `json:"name"`
-			Description string `json:"description"`
-		} `json:"organization"`
-		CreatedBy struct {
-			ID        int    `json:"id"`
-			Username  string `json:"username"`
-			FirstName string `json:"first_name"`
-			LastName  string `json:"last_name"`
-		} `json:"created_by"`
-		ModifiedBy struct {
-			ID        int    `json:"id"`
-			Username  string `json:"username"`
-			FirstName string `json:"first_name"`
-			LastName  string `json:"last_name"`
-		} `json:"modified_by"`
-		ObjectRoles struct {
-			UseRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"use_role"`
-			AdminRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"admin_role"`
-			AdhocRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"adhoc_role"`
-			UpdateRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"update_role"`
-			ReadRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"read_role"`
+		InsightsCredential *CredentialSummary `json:"insights_credential"`
+		Organization       *ObjectSummary     `json:"organization"`
+		CreatedBy          *UserSummary       `json:"created_by"`
+		ModifiedBy         *UserSummary       `json:"modified_by"`
+		ObjectRoles        struct {
+			UseRole    ObjectRole `json:"use_role"`
+			AdminRole  ObjectRole `json:"admin_role"`
+			AdhocRole  ObjectRole `json:"adhoc_role"`
+			UpdateRole ObjectRole `json:"update_role"`
+			ReadRole   ObjectRole `json:"read_role"`
 		} `json:"object_roles"`
-		UserCapabilities struct {
-			Edit   bool `json:"edit"`
-			Copy   bool `json:"copy"`
-			Adhoc  bool `json:"adhoc"`
-			Delete bool `json:"delete"`
-		} `json:"user_capabilities"`
+		UserCapabilities UserCapabilities `json:"user_capabilities"`
 	} `json:"summary_fields"`
 	Created                      time.Time   `json:"created"`
 	Modified                     time.Time   `json:"modified"`
@@ -117,7 +78,7 @@ type Inventory struct {
 	Organization                 int         `json:"organization"`
 	Kind                         string      `json:"kind"`
 	HostFilter                   interface{} `json:"host_filter"`
-	Variables                    string      `json:"variables"`
+	Variables                    Vars        `json:"variables"`
 	HasActiveFailures            bool        `json:"has_active_failures"`
 	TotalHosts                   int         `json:"total_hosts"`
 	HostsWithActiveFailures      int         `json:"hosts_with_active_failures"`
@@ -126,8 +87,25 @@ type Inventory struct {
 	HasInventorySources          bool        `json:"has_inventory_sources"`
 	TotalInventorySources        int         `json:"total_inventory_sources"`
 	InventorySourcesWithFailures int         `json:"inventory_sources_with_failures"`
-	InsightsCredential           int         `json:"insights_credential"`
+	InsightsCredential           *int        `json:"insights_credential"`
 	PendingDeletion              bool        `json:"pending_deletion"`
+	// Extra holds the fields returned by AWX that have no field above,
+	// such as fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a Inventory, keeping unknown fields in Extra.
+func (i *Inventory) UnmarshalJSON(data []byte) error {
+	type inventory Inventory
+	extra, err := unmarshalWithExtra(data, (*inventory)(i))
+	i.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a Inventory together with the fields in Extra.
+func (i Inventory) MarshalJSON() ([]byte, error) {
+	type inventory Inventory
+	return marshalWithExtra(inventory(i), i.Extra)
 }
 
 // InventoryCreateRequest represents a request to create a Inventory.
@@ -137,15 +115,33 @@ type InventoryCreateRequest struct {
 	Organization       int    `json:"organization"`
 	Kind               string `json:"kind,omitempty"`
 	HostFilter         string `json:"host_filter,omitempty"`
-	Variables          string `json:"variables,omitempty"`
+	Variables          *Vars  `json:"variables,omitempty"`
 	InsightsCredential int    `json:"insights_credential,omitempty"`
+	// Extra holds fields to send that have no field above, such as
+	// fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes a InventoryCreateRequest together with the fields in Extra.
+func (r InventoryCreateRequest) MarshalJSON() ([]byte, error) {
+	type request InventoryCreateRequest
+	return marshalWithExtra(request(r), r.Extra)
+}
+
+// InventorySourceSync reports whether an update was started for one of the
+// InventorySources of an Inventory. InventoryUpdate is 0 when Status explains
+// why the source could not be updated.
+type InventorySourceSync struct {
+	InventorySource int    `json:"inventory_source"`
+	InventoryUpdate int    `json:"inventory_update"`
+	Status          string `json:"status"`
 }
 
 // InventoryRoot represents a Inventory root
 type inventoryRoot struct {
 	Count     int         `json:"count"`
-	Next      int         `json:"next"`
-	Previous  int         `json:"previous"`
+	Next      string      `json:"next"`
+	Previous  string      `json:"previous"`
 	Results   []Inventory `json:"results"`
 	Inventory *Inventory
 }
@@ -249,3 +245,48 @@ func (s *InventoryServiceOp) Delete(ctx context.Context, inventoryID int) (*Resp
 
 	return resp, err
 }
+
+// SyncAllSources starts an InventoryUpdate for every InventorySource of the Inventory.
+func (s *InventoryServiceOp) SyncAllSources(ctx context.Context, inventoryID int) ([]InventorySourceSync, *Response, error) {
+	if inventoryID < 1 {
+		return nil, nil, NewArgError("inventoryID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/update_inventory_sources/", inventoryBasePath, inventoryID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var syncs []InventorySourceSync
+	resp, err := s.client.Do(ctx, req, &syncs)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return syncs, resp, err
+}
+
+// ListInstanceGroups lists the InstanceGroups of a Inventory in order of preference.
+func (s *InventoryServiceOp) ListInstanceGroups(ctx context.Context, inventoryID int) ([]InstanceGroup, *Response, error) {
+	if inventoryID < 1 {
+		return nil, nil, NewArgError("inventoryID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/instance_groups/", inventoryBasePath, inventoryID)
+
+	return listInstanceGroups(ctx, s.client, path)
+}
+
+// SetInstanceGroups replaces the InstanceGroups of a Inventory. Jobs are sent to
+// the first group with capacity, in the order given.
+func (s *InventoryServiceOp) SetInstanceGroups(ctx context.Context, inventoryID int, instanceGroupIDs []int) (*Response, error) {
+	if inventoryID < 1 {
+		return nil, NewArgError("inventoryID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/instance_groups/", inventoryBasePath, inventoryID)
+
+	return setInstanceGroups(ctx, s.client, path, instanceGroupIDs)
+}
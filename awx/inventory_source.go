@@ -2,12 +2,13 @@ package awx
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 )
 
-const inventorySourceBasePath = "api/v2/inventory_sources/"
+const inventorySourceBasePath = "inventory_sources/"
 
 // InventorySourceService is an interface for interfacing with the InventorySource
 // endpoints of the AWX API
@@ -18,6 +19,7 @@ type InventorySourceService interface {
 	Create(context.Context, *InventorySourceCreateRequest) (*InventorySource, *Response, error)
 	Update(context.Context, *InventorySourceCreateRequest, int) (*Response, error)
 	Delete(context.Context, int) (*Response, error)
+	Sync(context.Context, int) (*InventoryUpdate, *Response, error)
 }
 
 // InventorySourceServiceOp handles communication with the InventorySource related methods of the
@@ -48,67 +50,56 @@ type InventorySource struct {
 		Inventory                    string `json:"inventory"`
 	} `json:"related"`
 	SummaryFields struct {
-		Inventory struct {
-			ID                           int    `json:"id"`
-			Name                         string `json:"name"`
-			Description                  string `json:"description"`
-			HasActiveFailures            bool   `json:"has_active_failures"`
-			TotalHosts                   int    `json:"total_hosts"`
-			HostsWithActiveFailures      int    `json:"hosts_with_active_failures"`
-			TotalGroups                  int    `json:"total_groups"`
-			GroupsWithActiveFailures     int    `json:"groups_with_active_failures"`
-			HasInventorySources          bool   `json:"has_inventory_sources"`
-			TotalInventorySources        int    `json:"total_inventory_sources"`
-			InventorySourcesWithFailures int    `json:"inventory_sources_with_failures"`
-			OrganizationID               int    `json:"organization_id"`
-			Kind                         string `json:"kind"`
-		} `json:"inventory"`
-		CreatedBy struct {
-			ID        int    `json:"id"`
-			Username  string `json:"username"`
-			FirstName string `json:"first_name"`
-			LastName  string `json:"last_name"`
-		} `json:"created_by"`
-		ModifiedBy struct {
-			ID        int    `json:"id"`
-			Username  string `json:"username"`
-			FirstName string `json:"first_name"`
-			LastName  string `json:"last_name"`
-		} `json:"modified_by"`
-		UserCapabilities struct {
-			Edit     bool `json:"edit"`
-			Start    bool `json:"start"`
-			Schedule bool `json:"schedule"`
-			Delete   bool `json:"delete"`
-		} `json:"user_capabilities"`
+		Inventory        *InventorySummary `json:"inventory"`
+		CreatedBy        *UserSummary      `json:"created_by"`
+		ModifiedBy       *UserSummary      `json:"modified_by"`
+		UserCapabilities UserCapabilities  `json:"user_capabilities"`
 	} `json:"summary_fields"`
-	Created               time.Time `json:"created"`
-	Modified              time.Time `json:"modified"`
-	Name                  string    `json:"name"`
-	Description           string    `json:"description"`
-	Source                string    `json:"source"`
-	SourcePath            string    `json:"source_path"`
-	SourceScript          string    `json:"source_script"`
-	SourceVars            string    `json:"source_vars"`
-	Credential            int       `json:"credential"`
-	SourceRegions         string    `json:"source_regions"`
-	InstanceFilters       string    `json:"instance_filters"`
-	GroupBy               string    `json:"group_by"`
-	Overwrite             bool      `json:"overwrite"`
-	OverwriteVars         bool      `json:"overwrite_vars"`
-	Timeout               int       `json:"timeout"`
-	Verbosity             int       `json:"verbosity"`
-	LastJobRun            time.Time `json:"last_job_run"`
-	LastJobFailed         bool      `json:"last_job_failed"`
-	NextJobRun            time.Time `json:"next_job_run"`
-	Status                string    `json:"status"`
-	Inventory             int       `json:"inventory"`
-	UpdateOnLaunch        bool      `json:"update_on_launch"`
-	UpdateCacheTimeout    int       `json:"update_cache_timeout"`
-	SourceProject         int       `json:"source_project"`
-	UpdateOnProjectUpdate bool      `json:"update_on_project_update"`
-	LastUpdateFailed      bool      `json:"last_update_failed"`
-	LastUpdated           time.Time `json:"last_updated"`
+	Created               time.Time  `json:"created"`
+	Modified              time.Time  `json:"modified"`
+	Name                  string     `json:"name"`
+	Description           string     `json:"description"`
+	Source                string     `json:"source"`
+	SourcePath            string     `json:"source_path"`
+	SourceScript          string     `json:"source_script"`
+	SourceVars            Vars       `json:"source_vars"`
+	Credential            *int       `json:"credential"`
+	SourceRegions         string     `json:"source_regions"`
+	InstanceFilters       string     `json:"instance_filters"`
+	GroupBy               string     `json:"group_by"`
+	Overwrite             bool       `json:"overwrite"`
+	OverwriteVars         bool       `json:"overwrite_vars"`
+	Timeout               int        `json:"timeout"`
+	Verbosity             int        `json:"verbosity"`
+	LastJobRun            *time.Time `json:"last_job_run"`
+	LastJobFailed         bool       `json:"last_job_failed"`
+	NextJobRun            *time.Time `json:"next_job_run"`
+	Status                string     `json:"status"`
+	Inventory             int        `json:"inventory"`
+	UpdateOnLaunch        bool       `json:"update_on_launch"`
+	UpdateCacheTimeout    int        `json:"update_cache_timeout"`
+	SourceProject         *int       `json:"source_project"`
+	UpdateOnProjectUpdate bool       `json:"update_on_project_update"`
+	LastUpdateFailed      bool       `json:"last_update_failed"`
+	LastUpdated           *time.Time `json:"last_updated"`
+	ExecutionEnvironment  *int       `json:"execution_environment"`
+	// Extra holds the fields returned by AWX that have no field above,
+	// such as fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a InventorySource, keeping unknown fields in Extra.
+func (is *InventorySource) UnmarshalJSON(data []byte) error {
+	type inventorySource InventorySource
+	extra, err := unmarshalWithExtra(data, (*inventorySource)(is))
+	is.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a InventorySource together with the fields in Extra.
+func (is InventorySource) MarshalJSON() ([]byte, error) {
+	type inventorySource InventorySource
+	return marshalWithExtra(inventorySource(is), is.Extra)
 }
 
 // InventorySourceCreateRequest represents a request to create a InventorySource.
@@ -118,7 +109,7 @@ type InventorySourceCreateRequest struct {
 	Source                string `json:"source,omitempty"`
 	SourcePath            string `json:"source_path,omitempty"`
 	SourceScript          string `json:"source_script,omitempty"`
-	SourceVars            string `json:"source_vars,omitempty"`
+	SourceVars            *Vars  `json:"source_vars,omitempty"`
 	Credential            int    `json:"credential,omitempty"`
 	SourceRegions         string `json:"source_regions,omitempty"`
 	InstanceFilters       string `json:"instance_filters,omitempty"`
@@ -132,13 +123,26 @@ type InventorySourceCreateRequest struct {
 	UpdateCacheTimeout    int    `json:"update_cache_timeout,omitempty"`
 	SourceProject         int    `json:"source_project,omitempty"`
 	UpdateOnProjectUpdate bool   `json:"update_on_project_update,omitempty"`
+	// ExecutionEnvironment is the ID of the execution environment to use.
+	// Leave it nil to keep the current one, or set it to Int(0) to unset it.
+	ExecutionEnvironment *int `json:"execution_environment,omitempty"`
+	// Extra holds fields to send that have no field above, such as
+	// fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes a InventorySourceCreateRequest together with the fields in Extra.
+func (r InventorySourceCreateRequest) MarshalJSON() ([]byte, error) {
+	type request InventorySourceCreateRequest
+	extra := clearID(&r.ExecutionEnvironment, "execution_environment", r.Extra)
+	return marshalWithExtra(request(r), extra)
 }
 
 // InventorySourceRoot represents a InventorySource root
 type inventorySourceRoot struct {
 	Count     int               `json:"count"`
-	Next      int               `json:"next"`
-	Previous  int               `json:"previous"`
+	Next      string            `json:"next"`
+	Previous  string            `json:"previous"`
 	Results   []InventorySource `json:"results"`
 	Inventory *InventorySource
 }
@@ -242,3 +246,25 @@ func (s *InventorySourceServiceOp) Delete(ctx context.Context, inventorySourceID
 
 	return resp, err
 }
+
+// Sync starts an InventoryUpdate for the InventorySource.
+func (s *InventorySourceServiceOp) Sync(ctx context.Context, inventorySourceID int) (*InventoryUpdate, *Response, error) {
+	if inventorySourceID < 1 {
+		return nil, nil, NewArgError("inventorySourceID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/update/", inventorySourceBasePath, inventorySourceID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(InventoryUpdate)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
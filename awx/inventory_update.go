@@ -0,0 +1,188 @@
+package awx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const inventoryUpdateBasePath = "inventory_updates/"
+
+// InventoryUpdateService is an interface for interfacing with the InventoryUpdate
+// endpoints of the AWX API
+// See: http://localhost/api/v2/inventory_updates/
+type InventoryUpdateService interface {
+	List(context.Context) ([]InventoryUpdate, *Response, error)
+	Get(context.Context, int) (*InventoryUpdate, *Response, error)
+	Cancel(context.Context, int) (*Response, error)
+	Stdout(context.Context, int) (string, *Response, error)
+}
+
+// InventoryUpdateServiceOp handles communication with the InventoryUpdate related methods of the
+// AWX API.
+type InventoryUpdateServiceOp struct {
+	client *Client
+}
+
+// InventoryUpdate represents a AWX InventoryUpdate, the job created when an
+// InventorySource is synced.
+type InventoryUpdate struct {
+	ID      int    `json:"id"`
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+	Related struct {
+		CreatedBy           string `json:"created_by"`
+		UnifiedJobTemplate  string `json:"unified_job_template"`
+		Stdout              string `json:"stdout"`
+		InventorySource     string `json:"inventory_source"`
+		Cancel              string `json:"cancel"`
+		Notifications       string `json:"notifications"`
+		Events              string `json:"events"`
+		Credentials         string `json:"credentials"`
+		SourceProjectUpdate string `json:"source_project_update"`
+		Inventory           string `json:"inventory"`
+	} `json:"related"`
+	SummaryFields struct {
+		Organization       *ObjectSummary             `json:"organization"`
+		Inventory          *InventorySummary          `json:"inventory"`
+		InventorySource    *InventorySourceSummary    `json:"inventory_source"`
+		UnifiedJobTemplate *UnifiedJobTemplateSummary `json:"unified_job_template"`
+		CreatedBy          *UserSummary               `json:"created_by"`
+		UserCapabilities   UserCapabilities           `json:"user_capabilities"`
+	} `json:"summary_fields"`
+	Created                 time.Time  `json:"created"`
+	Modified                time.Time  `json:"modified"`
+	Name                    string     `json:"name"`
+	Description             string     `json:"description"`
+	Source                  string     `json:"source"`
+	SourcePath              string     `json:"source_path"`
+	SourceVars              Vars       `json:"source_vars"`
+	Credential              *int       `json:"credential"`
+	Overwrite               bool       `json:"overwrite"`
+	OverwriteVars           bool       `json:"overwrite_vars"`
+	Timeout                 int        `json:"timeout"`
+	Verbosity               int        `json:"verbosity"`
+	UnifiedJobTemplate      *int       `json:"unified_job_template"`
+	LaunchType              string     `json:"launch_type"`
+	Status                  string     `json:"status"`
+	Failed                  bool       `json:"failed"`
+	Started                 *time.Time `json:"started"`
+	Finished                *time.Time `json:"finished"`
+	Elapsed                 float64    `json:"elapsed"`
+	JobExplanation          string     `json:"job_explanation"`
+	ExecutionNode           string     `json:"execution_node"`
+	ResultTraceback         string     `json:"result_traceback"`
+	EventProcessingFinished bool       `json:"event_processing_finished"`
+	Inventory               int        `json:"inventory"`
+	InventorySource         int        `json:"inventory_source"`
+	LicenseError            bool       `json:"license_error"`
+	SourceProjectUpdate     *int       `json:"source_project_update"`
+	ScmRevision             string     `json:"scm_revision"`
+	// Extra holds the fields returned by AWX that have no field above,
+	// such as fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a InventoryUpdate, keeping unknown fields in Extra.
+func (iu *InventoryUpdate) UnmarshalJSON(data []byte) error {
+	type inventoryUpdate InventoryUpdate
+	extra, err := unmarshalWithExtra(data, (*inventoryUpdate)(iu))
+	iu.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a InventoryUpdate together with the fields in Extra.
+func (iu InventoryUpdate) MarshalJSON() ([]byte, error) {
+	type inventoryUpdate InventoryUpdate
+	return marshalWithExtra(inventoryUpdate(iu), iu.Extra)
+}
+
+// inventoryUpdateRoot represents a InventoryUpdate root
+type inventoryUpdateRoot struct {
+	Count    int               `json:"count"`
+	Next     string            `json:"next"`
+	Previous string            `json:"previous"`
+	Results  []InventoryUpdate `json:"results"`
+}
+
+// List all InventoryUpdates.
+func (s *InventoryUpdateServiceOp) List(ctx context.Context) ([]InventoryUpdate, *Response, error) {
+	path := inventoryUpdateBasePath
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(inventoryUpdateRoot)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root.Results, resp, err
+}
+
+// Get individual InventoryUpdate.
+func (s *InventoryUpdateServiceOp) Get(ctx context.Context, inventoryUpdateID int) (*InventoryUpdate, *Response, error) {
+	if inventoryUpdateID < 1 {
+		return nil, nil, NewArgError("inventoryUpdateID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/", inventoryUpdateBasePath, inventoryUpdateID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(InventoryUpdate)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Cancel a pending or running InventoryUpdate.
+func (s *InventoryUpdateServiceOp) Cancel(ctx context.Context, inventoryUpdateID int) (*Response, error) {
+	if inventoryUpdateID < 1 {
+		return nil, NewArgError("inventoryUpdateID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/cancel/", inventoryUpdateBasePath, inventoryUpdateID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+
+	return resp, err
+}
+
+// Stdout returns the plain text output of an InventoryUpdate.
+func (s *InventoryUpdateServiceOp) Stdout(ctx context.Context, inventoryUpdateID int) (string, *Response, error) {
+	if inventoryUpdateID < 1 {
+		return "", nil, NewArgError("inventoryUpdateID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/stdout/?format=txt", inventoryUpdateBasePath, inventoryUpdateID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	resp, err := s.client.Do(ctx, req, buf)
+	if err != nil {
+		return "", resp, err
+	}
+
+	return buf.String(), resp, err
+}
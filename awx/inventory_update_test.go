@@ -0,0 +1,113 @@
+package awx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+// handle registers a handler for path that fails the test unless the request
+// has the given method and raw query, then calls serve.
+func handle(t *testing.T, mux *http.ServeMux, method, path, query string, serve http.HandlerFunc) {
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method || r.URL.RawQuery != query {
+			t.Errorf("request %s %s, want %s %s?%s", r.Method, r.URL, method, path, query)
+		}
+		serve(w, r)
+	})
+}
+
+func TestInventoryUpdateService(t *testing.T) {
+	client, mux := setup(t)
+	ctx := context.Background()
+	update := map[string]interface{}{
+		"id": 12, "type": "inventory_update", "name": "production - ec2",
+		"status": "successful", "source": "ec2", "source_vars": "regions: eu-west-1\n",
+		"inventory": 2, "inventory_source": 5, "source_project_update": nil,
+		"started": "2024-06-03T10:00:02Z", "finished": "2024-06-03T10:00:40Z",
+	}
+
+	handle(t, mux, http.MethodPost, "/api/v2/inventory_sources/5/update/", "", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusAccepted, update)
+	})
+	handle(t, mux, http.MethodGet, "/api/v2/inventory_updates/", "", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{"count": 1, "results": []interface{}{update}})
+	})
+	handle(t, mux, http.MethodGet, "/api/v2/inventory_updates/12/", "", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, update)
+	})
+	handle(t, mux, http.MethodPost, "/api/v2/inventory_updates/12/cancel/", "", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	handle(t, mux, http.MethodGet, "/api/v2/inventory_updates/12/stdout/", "format=txt", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, "Loaded 12 hosts.\n")
+	})
+
+	check := func(name string, iu *InventoryUpdate) {
+		t.Helper()
+		if iu.ID != 12 || iu.Status != "successful" || iu.InventorySource != 5 || iu.SourceProjectUpdate != nil {
+			t.Errorf("%s = %+v, want successful update 12 of source 5", name, iu)
+		}
+		if region, _ := iu.SourceVars.Get("regions"); region != "eu-west-1" {
+			t.Errorf("%s source_vars = %v, want the regions", name, iu.SourceVars)
+		}
+		if iu.Finished == nil || iu.Finished.Sub(*iu.Started).Seconds() != 38 {
+			t.Errorf("%s started %v, finished %v, want 38s apart", name, iu.Started, iu.Finished)
+		}
+	}
+
+	iu, _, err := client.InventorySource.Sync(ctx, 5)
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	check("Sync", iu)
+
+	updates, _, err := client.InventoryUpdate.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(updates) != 1 {
+		t.Fatalf("List returned %d updates, want 1", len(updates))
+	}
+	check("List", &updates[0])
+
+	iu, _, err = client.InventoryUpdate.Get(ctx, 12)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	check("Get", iu)
+
+	if _, err := client.InventoryUpdate.Cancel(ctx, 12); err != nil {
+		t.Errorf("Cancel: %v", err)
+	}
+
+	stdout, _, err := client.InventoryUpdate.Stdout(ctx, 12)
+	if err != nil {
+		t.Fatalf("Stdout: %v", err)
+	}
+	if stdout != "Loaded 12 hosts.\n" {
+		t.Errorf("Stdout = %q, want the plain text output", stdout)
+	}
+}
+
+func TestInventoryUpdateServiceArgErrors(t *testing.T) {
+	client := NewClient(nil)
+	ctx := context.Background()
+
+	var argErr *ArgError
+	if _, _, err := client.InventoryUpdate.Get(ctx, 0); !errors.As(err, &argErr) {
+		t.Errorf("Get(0): %v, want an *ArgError", err)
+	}
+	if _, err := client.InventoryUpdate.Cancel(ctx, 0); !errors.As(err, &argErr) {
+		t.Errorf("Cancel(0): %v, want an *ArgError", err)
+	}
+	if _, _, err := client.InventoryUpdate.Stdout(ctx, 0); !errors.As(err, &argErr) {
+		t.Errorf("Stdout(0): %v, want an *ArgError", err)
+	}
+	if _, _, err := client.InventorySource.Sync(ctx, 0); !errors.As(err, &argErr) {
+		t.Errorf("Sync(0): %v, want an *ArgError", err)
+	}
+}
@@ -2,12 +2,13 @@ package awx
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 )
 
-const jobTemplateBasePath = "api/v2/job_templates/"
+const jobTemplateBasePath = "job_templates/"
 
 // JobTemplateService is an interface for interfacing with the JobTemplate
 // endpoints of the AWX API
@@ -18,6 +19,14 @@ type JobTemplateService interface {
 	Create(context.Context, *JobTemplateCreateRequest) (*JobTemplate, *Response, error)
 	Update(context.Context, *JobTemplateCreateRequest, int) (*Response, error)
 	Delete(context.Context, int) (*Response, error)
+	GetSurveySpec(context.Context, int) (*SurveySpec, *Response, error)
+	SetSurveySpec(context.Context, int, *SurveySpec) (*Response, error)
+	DeleteSurveySpec(context.Context, int) (*Response, error)
+	ListInstanceGroups(context.Context, int) ([]InstanceGroup, *Response, error)
+	SetInstanceGroups(context.Context, int, []int) (*Response, error)
+	ListLabels(context.Context, int) ([]Label, *Response, error)
+	AssociateLabel(context.Context, int, int) (*Response, error)
+	DisassociateLabel(context.Context, int, int) (*Response, error)
 }
 
 // JobTemplateServiceOp handles communication with the JobTemplate related methods of the
@@ -53,105 +62,77 @@ type JobTemplate struct {
 		SurveySpec                   string `json:"survey_spec"`
 	} `json:"related"`
 	SummaryFields struct {
-		Inventory struct {
-			ID                           int    `json:"id"`
-			Name                         string `json:"name"`
-			Description                  string `json:"description"`
-			HasActiveFailures            bool   `json:"has_active_failures"`
-			TotalHosts                   int    `json:"total_hosts"`
-			HostsWithActiveFailures      int    `json:"hosts_with_active_failures"`
-			TotalGroups                  int    `json:"total_groups"`
-			GroupsWithActiveFailures     int    `json:"groups_with_active_failures"`
-			HasInventorySources          bool   `json:"has_inventory_sources"`
-			TotalInventorySources        int    `json:"total_inventory_sources"`
-			InventorySourcesWithFailures int    `json:"inventory_sources_with_failures"`
-			OrganizationID               int    `json:"organization_id"`
-			Kind                         string `json:"kind"`
-			InsightsCredentialID         int    `json:"insights_credential_id"`
-		} `json:"inventory"`
-		CreatedBy struct {
-			ID        int    `json:"id"`
-			Username  string `json:"username"`
-			FirstName string `json:"first_name"`
-			LastName  string `json:"last_name"`
-		} `json:"created_by"`
-		ModifiedBy struct {
-			ID        int    `json:"id"`
-			Username  string `json:"username"`
-			FirstName string `json:"first_name"`
-			LastName  string `json:"last_name"`
-		} `json:"modified_by"`
+		Inventory   *InventorySummary `json:"inventory"`
+		CreatedBy   *UserSummary      `json:"created_by"`
+		ModifiedBy  *UserSummary      `json:"modified_by"`
 		ObjectRoles struct {
-			AdminRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"admin_role"`
-			ExecuteRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"execute_role"`
-			ReadRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"read_role"`
+			AdminRole   ObjectRole `json:"admin_role"`
+			ExecuteRole ObjectRole `json:"execute_role"`
+			ReadRole    ObjectRole `json:"read_role"`
 		} `json:"object_roles"`
-		UserCapabilities struct {
-			Edit     bool `json:"edit"`
-			Start    bool `json:"start"`
-			Copy     bool `json:"copy"`
-			Schedule bool `json:"schedule"`
-			Delete   bool `json:"delete"`
-		} `json:"user_capabilities"`
-		Labels struct {
-			Count   int      `json:"count"`
-			Results []string `json:"results"`
-		} `json:"labels"`
-		RecentJobs       []int `json:"recent_jobs"`
-		ExtraCredentials []int `json:"extra_credentials"`
-		Credentials      []int `json:"credentials"`
+		UserCapabilities UserCapabilities    `json:"user_capabilities"`
+		Labels           LabelsSummary       `json:"labels"`
+		RecentJobs       []RecentJobSummary  `json:"recent_jobs"`
+		ExtraCredentials []CredentialSummary `json:"extra_credentials"`
+		Credentials      []CredentialSummary `json:"credentials"`
 	} `json:"summary_fields"`
-	Created               time.Time `json:"created"`
-	Modified              time.Time `json:"modified"`
-	Name                  string    `json:"name"`
-	Description           string    `json:"description"`
-	JobType               string    `json:"job_type"`
-	Inventory             int       `json:"inventory"`
-	Project               int       `json:"project"`
-	Playbook              string    `json:"playbook"`
-	Forks                 int       `json:"forks"`
-	Limit                 string    `json:"limit"`
-	Verbosity             int       `json:"verbosity"`
-	ExtraVars             string    `json:"extra_vars"`
-	JobTags               string    `json:"job_tags"`
-	ForceHandlers         bool      `json:"force_handlers"`
-	SkipTags              string    `json:"skip_tags"`
-	StartAtTask           string    `json:"start_at_task"`
-	Timeout               int       `json:"timeout"`
-	UseFactCache          bool      `json:"use_fact_cache"`
-	LastJobRun            time.Time `json:"last_job_run"`
-	LastJobFailed         bool      `json:"last_job_failed"`
-	NextJobRun            time.Time `json:"next_job_run"`
-	Status                string    `json:"status"`
-	HostConfigKey         string    `json:"host_config_key"`
-	AskDiffModeOnLaunch   bool      `json:"ask_diff_mode_on_launch"`
-	AskVariablesOnLaunch  bool      `json:"ask_variables_on_launch"`
-	AskLimitOnLaunch      bool      `json:"ask_limit_on_launch"`
-	AskTagsOnLaunch       bool      `json:"ask_tags_on_launch"`
-	AskSkipTagsOnLaunch   bool      `json:"ask_skip_tags_on_launch"`
-	AskJobTypeOnLaunch    bool      `json:"ask_job_type_on_launch"`
-	AskVerbosityOnLaunch  bool      `json:"ask_verbosity_on_launch"`
-	AskInventoryOnLaunch  bool      `json:"ask_inventory_on_launch"`
-	AskCredentialOnLaunch bool      `json:"ask_credential_on_launch"`
-	SurveyEnabled         bool      `json:"survey_enabled"`
-	BecomeEnabled         bool      `json:"become_enabled"`
-	DiffMode              bool      `json:"diff_mode"`
-	AllowSimultaneous     bool      `json:"allow_simultaneous"`
-	CustomVirtualenv      string    `json:"custom_virtualenv"`
-	Credential            int       `json:"credential"`
-	VaultCredential       int       `json:"vault_credential"`
+	Created               time.Time  `json:"created"`
+	Modified              time.Time  `json:"modified"`
+	Name                  string     `json:"name"`
+	Description           string     `json:"description"`
+	JobType               string     `json:"job_type"`
+	Inventory             *int       `json:"inventory"`
+	Project               *int       `json:"project"`
+	Playbook              string     `json:"playbook"`
+	Forks                 int        `json:"forks"`
+	Limit                 string     `json:"limit"`
+	Verbosity             int        `json:"verbosity"`
+	ExtraVars             Vars       `json:"extra_vars"`
+	JobTags               string     `json:"job_tags"`
+	ForceHandlers         bool       `json:"force_handlers"`
+	SkipTags              string     `json:"skip_tags"`
+	StartAtTask           string     `json:"start_at_task"`
+	Timeout               int        `json:"timeout"`
+	UseFactCache          bool       `json:"use_fact_cache"`
+	LastJobRun            *time.Time `json:"last_job_run"`
+	LastJobFailed         bool       `json:"last_job_failed"`
+	NextJobRun            *time.Time `json:"next_job_run"`
+	Status                string     `json:"status"`
+	HostConfigKey         string     `json:"host_config_key"`
+	AskDiffModeOnLaunch   bool       `json:"ask_diff_mode_on_launch"`
+	AskVariablesOnLaunch  bool       `json:"ask_variables_on_launch"`
+	AskLimitOnLaunch      bool       `json:"ask_limit_on_launch"`
+	AskTagsOnLaunch       bool       `json:"ask_tags_on_launch"`
+	AskSkipTagsOnLaunch   bool       `json:"ask_skip_tags_on_launch"`
+	AskJobTypeOnLaunch    bool       `json:"ask_job_type_on_launch"`
+	AskVerbosityOnLaunch  bool       `json:"ask_verbosity_on_launch"`
+	AskInventoryOnLaunch  bool       `json:"ask_inventory_on_launch"`
+	AskCredentialOnLaunch bool       `json:"ask_credential_on_launch"`
+	SurveyEnabled         bool       `json:"survey_enabled"`
+	BecomeEnabled         bool       `json:"become_enabled"`
+	DiffMode              bool       `json:"diff_mode"`
+	AllowSimultaneous     bool       `json:"allow_simultaneous"`
+	CustomVirtualenv      string     `json:"custom_virtualenv"`
+	ExecutionEnvironment  *int       `json:"execution_environment"`
+	Credential            *int       `json:"credential"`
+	VaultCredential       *int       `json:"vault_credential"`
+	// Extra holds the fields returned by AWX that have no field above,
+	// such as fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a JobTemplate, keeping unknown fields in Extra.
+func (jt *JobTemplate) UnmarshalJSON(data []byte) error {
+	type jobTemplate JobTemplate
+	extra, err := unmarshalWithExtra(data, (*jobTemplate)(jt))
+	jt.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a JobTemplate together with the fields in Extra.
+func (jt JobTemplate) MarshalJSON() ([]byte, error) {
+	type jobTemplate JobTemplate
+	return marshalWithExtra(jobTemplate(jt), jt.Extra)
 }
 
 // JobTemplateCreateRequest represents a request to create a JobTemplate.
@@ -165,7 +146,7 @@ type JobTemplateCreateRequest struct {
 	Forks                 int    `json:"forks,omitempty"`
 	Limit                 string `json:"limit,omitempty"`
 	Verbosity             int    `json:"verbosity,omitempty"`
-	ExtraVars             string `json:"extra_vars,omitempty"`
+	ExtraVars             *Vars  `json:"extra_vars,omitempty"`
 	JobTags               string `json:"job_tags,omitempty"`
 	ForceHandlers         bool   `json:"force_handlers,omitempty"`
 	SkipTags              string `json:"skip_tags,omitempty"`
@@ -187,15 +168,28 @@ type JobTemplateCreateRequest struct {
 	DiffMode              bool   `json:"diff_mode,omitempty"`
 	AllowSimultaneous     bool   `json:"allow_simultaneous,omitempty"`
 	CustomVirtualenv      string `json:"custom_virtualenv,omitempty"`
-	Credential            int    `json:"credential,omitempty"`
-	VaultCredential       int    `json:"vault_credential,omitempty"`
+	// ExecutionEnvironment is the ID of the execution environment to use.
+	// Leave it nil to keep the current one, or set it to Int(0) to unset it.
+	ExecutionEnvironment *int `json:"execution_environment,omitempty"`
+	Credential           int  `json:"credential,omitempty"`
+	VaultCredential      int  `json:"vault_credential,omitempty"`
+	// Extra holds fields to send that have no field above, such as
+	// fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes a JobTemplateCreateRequest together with the fields in Extra.
+func (r JobTemplateCreateRequest) MarshalJSON() ([]byte, error) {
+	type request JobTemplateCreateRequest
+	extra := clearID(&r.ExecutionEnvironment, "execution_environment", r.Extra)
+	return marshalWithExtra(request(r), extra)
 }
 
 // jobTemplateRoot represents a JobTemplate root
 type jobTemplateRoot struct {
 	Count       int           `json:"count"`
-	Next        int           `json:"next"`
-	Previous    int           `json:"previous"`
+	Next        string        `json:"next"`
+	Previous    string        `json:"previous"`
 	Results     []JobTemplate `json:"results"`
 	JobTemplate *JobTemplate
 }
@@ -297,3 +291,93 @@ func (s *JobTemplateServiceOp) Delete(ctx context.Context, jobTemplateID int) (*
 
 	return resp, err
 }
+
+// GetSurveySpec returns the survey of a JobTemplate.
+func (s *JobTemplateServiceOp) GetSurveySpec(ctx context.Context, jobTemplateID int) (*SurveySpec, *Response, error) {
+	if jobTemplateID < 1 {
+		return nil, nil, NewArgError("jobTemplateID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/survey_spec/", jobTemplateBasePath, jobTemplateID)
+
+	return getSurveySpec(ctx, s.client, path)
+}
+
+// SetSurveySpec replaces the survey of a JobTemplate. The survey is only
+// presented on launch while SurveyEnabled is set on the template.
+func (s *JobTemplateServiceOp) SetSurveySpec(ctx context.Context, jobTemplateID int, spec *SurveySpec) (*Response, error) {
+	if jobTemplateID < 1 {
+		return nil, NewArgError("jobTemplateID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/survey_spec/", jobTemplateBasePath, jobTemplateID)
+
+	return setSurveySpec(ctx, s.client, path, spec)
+}
+
+// DeleteSurveySpec removes the survey of a JobTemplate.
+func (s *JobTemplateServiceOp) DeleteSurveySpec(ctx context.Context, jobTemplateID int) (*Response, error) {
+	if jobTemplateID < 1 {
+		return nil, NewArgError("jobTemplateID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/survey_spec/", jobTemplateBasePath, jobTemplateID)
+
+	return deleteSurveySpec(ctx, s.client, path)
+}
+
+// ListInstanceGroups lists the InstanceGroups of a JobTemplate in order of preference.
+func (s *JobTemplateServiceOp) ListInstanceGroups(ctx context.Context, jobTemplateID int) ([]InstanceGroup, *Response, error) {
+	if jobTemplateID < 1 {
+		return nil, nil, NewArgError("jobTemplateID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/instance_groups/", jobTemplateBasePath, jobTemplateID)
+
+	return listInstanceGroups(ctx, s.client, path)
+}
+
+// SetInstanceGroups replaces the InstanceGroups of a JobTemplate. Jobs are sent to
+// the first group with capacity, in the order given.
+func (s *JobTemplateServiceOp) SetInstanceGroups(ctx context.Context, jobTemplateID int, instanceGroupIDs []int) (*Response, error) {
+	if jobTemplateID < 1 {
+		return nil, NewArgError("jobTemplateID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/instance_groups/", jobTemplateBasePath, jobTemplateID)
+
+	return setInstanceGroups(ctx, s.client, path, instanceGroupIDs)
+}
+
+// ListLabels lists the Labels of a JobTemplate.
+func (s *JobTemplateServiceOp) ListLabels(ctx context.Context, jobTemplateID int) ([]Label, *Response, error) {
+	if jobTemplateID < 1 {
+		return nil, nil, NewArgError("jobTemplateID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/labels/", jobTemplateBasePath, jobTemplateID)
+
+	return listLabels(ctx, s.client, path)
+}
+
+// AssociateLabel adds a Label to a JobTemplate.
+func (s *JobTemplateServiceOp) AssociateLabel(ctx context.Context, jobTemplateID, labelID int) (*Response, error) {
+	if jobTemplateID < 1 {
+		return nil, NewArgError("jobTemplateID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/labels/", jobTemplateBasePath, jobTemplateID)
+
+	return associate(ctx, s.client, path, labelID)
+}
+
+// DisassociateLabel removes a Label from a JobTemplate.
+func (s *JobTemplateServiceOp) DisassociateLabel(ctx context.Context, jobTemplateID, labelID int) (*Response, error) {
+	if jobTemplateID < 1 {
+		return nil, NewArgError("jobTemplateID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/labels/", jobTemplateBasePath, jobTemplateID)
+
+	return disassociate(ctx, s.client, path, labelID)
+}
@@ -0,0 +1,218 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const labelBasePath = "labels/"
+
+// LabelService is an interface for interfacing with the Label
+// endpoints of the AWX API
+// See: http://localhost/api/v2/labels/
+type LabelService interface {
+	List(context.Context) ([]Label, *Response, error)
+	Get(context.Context, int) (*Label, *Response, error)
+	Create(context.Context, *LabelCreateRequest) (*Label, *Response, error)
+	Update(context.Context, *LabelCreateRequest, int) (*Response, error)
+	FindTemplates(context.Context, string) (*LabeledTemplates, *Response, error)
+}
+
+// LabelServiceOp handles communication with the Label related methods of the
+// AWX API.
+type LabelServiceOp struct {
+	client *Client
+}
+
+// Label represents a AWX Label. Labels cannot be deleted directly; AWX
+// removes them once they are no longer associated with any template or job.
+type Label struct {
+	ID      int    `json:"id"`
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+	Related struct {
+		Organization string `json:"organization"`
+	} `json:"related"`
+	SummaryFields struct {
+		Organization *ObjectSummary `json:"organization"`
+	} `json:"summary_fields"`
+	Created      time.Time `json:"created"`
+	Modified     time.Time `json:"modified"`
+	Name         string    `json:"name"`
+	Organization int       `json:"organization"`
+	// Extra holds the fields returned by AWX that have no field above,
+	// such as fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a Label, keeping unknown fields in Extra.
+func (l *Label) UnmarshalJSON(data []byte) error {
+	type label Label
+	extra, err := unmarshalWithExtra(data, (*label)(l))
+	l.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a Label together with the fields in Extra.
+func (l Label) MarshalJSON() ([]byte, error) {
+	type label Label
+	return marshalWithExtra(label(l), l.Extra)
+}
+
+// LabelSummary is the short form of a Label embedded in the summary fields
+// of templates and jobs.
+type LabelSummary struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// LabelCreateRequest represents a request to create a Label.
+type LabelCreateRequest struct {
+	Name         string `json:"name"`
+	Organization int    `json:"organization"`
+	// Extra holds fields to send that have no field above, such as
+	// fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes a LabelCreateRequest together with the fields in Extra.
+func (r LabelCreateRequest) MarshalJSON() ([]byte, error) {
+	type request LabelCreateRequest
+	return marshalWithExtra(request(r), r.Extra)
+}
+
+// LabeledTemplates holds the templates found to carry a Label.
+type LabeledTemplates struct {
+	JobTemplates         []JobTemplate
+	WorkflowJobTemplates []WorkflowJobTemplate
+}
+
+// List all Labels.
+func (s *LabelServiceOp) List(ctx context.Context) ([]Label, *Response, error) {
+	return listLabels(ctx, s.client, labelBasePath)
+}
+
+// Get individual Label.
+func (s *LabelServiceOp) Get(ctx context.Context, labelID int) (*Label, *Response, error) {
+	if labelID < 1 {
+		return nil, nil, NewArgError("labelID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/", labelBasePath, labelID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Label)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Create Label
+func (s *LabelServiceOp) Create(ctx context.Context, createRequest *LabelCreateRequest) (*Label, *Response, error) {
+	if createRequest == nil {
+		return nil, nil, NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := labelBasePath
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Label)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Update Label.
+func (s *LabelServiceOp) Update(ctx context.Context, createRequest *LabelCreateRequest, labelID int) (*Response, error) {
+	if labelID < 1 {
+		return nil, NewArgError("labelID", "cannot be less than 1")
+	}
+	if createRequest == nil {
+		return nil, NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := fmt.Sprintf("%s%d/", labelBasePath, labelID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodPatch, path, createRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+
+	return resp, err
+}
+
+// FindTemplates returns every JobTemplate and WorkflowJobTemplate carrying
+// the Label with the given name, following pagination to the last page.
+func (s *LabelServiceOp) FindTemplates(ctx context.Context, labelName string) (*LabeledTemplates, *Response, error) {
+	if labelName == "" {
+		return nil, nil, NewArgError("labelName", "cannot be empty")
+	}
+
+	query := url.Values{"labels__name": {labelName}, "page_size": {"200"}}.Encode()
+	templates := new(LabeledTemplates)
+
+	resp, err := listAllPages(ctx, s.client, jobTemplateBasePath+"?"+query, func(results json.RawMessage) error {
+		var jobTemplates []JobTemplate
+		if err := json.Unmarshal(results, &jobTemplates); err != nil {
+			return err
+		}
+		templates.JobTemplates = append(templates.JobTemplates, jobTemplates...)
+		return nil
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	resp, err = listAllPages(ctx, s.client, workflowJobTemplateBasePath+"?"+query, func(results json.RawMessage) error {
+		var workflowJobTemplates []WorkflowJobTemplate
+		if err := json.Unmarshal(results, &workflowJobTemplates); err != nil {
+			return err
+		}
+		templates.WorkflowJobTemplates = append(templates.WorkflowJobTemplates, workflowJobTemplates...)
+		return nil
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return templates, resp, nil
+}
+
+// listLabels lists every Label at path, which is either the labels endpoint
+// or the related labels collection of a template, across all pages.
+func listLabels(ctx context.Context, client *Client, path string) ([]Label, *Response, error) {
+	var labels []Label
+	resp, err := listAllPages(ctx, client, path, func(results json.RawMessage) error {
+		var page []Label
+		if err := json.Unmarshal(results, &page); err != nil {
+			return err
+		}
+		labels = append(labels, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return labels, resp, err
+}
@@ -0,0 +1,67 @@
+package awx
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// labelPages serves the labels named in pages from path, linking each page
+// to the next with a zero-based page parameter.
+func labelPages(t *testing.T, mux *http.ServeMux, path string, pages ...[]string) {
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+
+		var results []map[string]interface{}
+		for _, name := range pages[page] {
+			results = append(results, map[string]interface{}{"id": len(results) + 1, "name": name})
+		}
+		root := map[string]interface{}{"count": len(pages), "results": results}
+		if page+1 < len(pages) {
+			root["next"] = path + "?page=" + strconv.Itoa(page+1)
+		}
+		writeJSON(t, w, http.StatusOK, root)
+	})
+}
+
+func TestLabelListAllPages(t *testing.T) {
+	client, mux := setup(t)
+	labelPages(t, mux, "/api/v2/labels/", []string{"prod", "web"}, []string{"db"}, []string{"eu"})
+	labelPages(t, mux, "/api/v2/job_templates/7/labels/", []string{"prod"}, []string{"nightly"})
+	labelPages(t, mux, "/api/v2/workflow_job_templates/8/labels/", []string{"release"}, []string{"prod"})
+	ctx := context.Background()
+
+	names := func(labels []Label) []string {
+		var names []string
+		for _, label := range labels {
+			names = append(names, label.Name)
+		}
+		return names
+	}
+
+	labels, _, err := client.Label.List(ctx)
+	if err != nil {
+		t.Fatalf("Label.List: %v", err)
+	}
+	if got, want := names(labels), []string{"prod", "web", "db", "eu"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Label.List = %q, want %q", got, want)
+	}
+
+	labels, _, err = client.JobTemplate.ListLabels(ctx, 7)
+	if err != nil {
+		t.Fatalf("JobTemplate.ListLabels: %v", err)
+	}
+	if got, want := names(labels), []string{"prod", "nightly"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("JobTemplate.ListLabels = %q, want %q", got, want)
+	}
+
+	labels, _, err = client.WorkflowJobTemplate.ListLabels(ctx, 8)
+	if err != nil {
+		t.Fatalf("WorkflowJobTemplate.ListLabels: %v", err)
+	}
+	if got, want := names(labels), []string{"release", "prod"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("WorkflowJobTemplate.ListLabels = %q, want %q", got, want)
+	}
+}
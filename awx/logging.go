@@ -0,0 +1,254 @@
+package awx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// requestIDHeader is the header AWX identifies each API request with.
+const requestIDHeader = "X-API-Request-Id"
+
+// maxLoggedBody bounds the size of bodies logged at debug level.
+const maxLoggedBody = 4096
+
+const redacted = "REDACTED"
+
+// secretFields are the names of fields whose values are never logged.
+var secretFields = map[string]bool{
+	"password":       true,
+	"passphrase":     true,
+	"secret":         true,
+	"token":          true,
+	"authorization":  true,
+	"api_key":        true,
+	"secret_key":     true,
+	"private_key":    true,
+	"ssh_key_data":   true,
+	"ssh_key_unlock": true,
+}
+
+// secretSuffixes end the names of other fields whose values are never
+// logged, such as "become_password" or "refresh_token".
+var secretSuffixes = []string{
+	"_password",
+	"_pass",
+	"_passphrase",
+	"_secret",
+	"_token",
+	"_api_key",
+	"_secret_key",
+	"_private_key",
+	"_key_data",
+	"_key_unlock",
+}
+
+// WithLogger is a client option that logs every request made through Do: the
+// method, path, status, duration, retry count and AWX request ID. At debug
+// level request and response bodies are logged too, with passwords, keys,
+// tokens and other secrets redacted.
+func WithLogger(logger *slog.Logger) ClientOpt {
+	return func(c *Client) error {
+		if logger == nil {
+			return NewArgError("logger", "cannot be nil")
+		}
+		c.logger = logger
+		return nil
+	}
+}
+
+// attemptsKey is the context key under which Do counts how many times a
+// request was sent, including retries made by Middleware.
+type attemptsKey struct{}
+
+func withAttemptCounter(ctx context.Context) (context.Context, *int32) {
+	attempts := new(int32)
+	return context.WithValue(ctx, attemptsKey{}, attempts), attempts
+}
+
+func countAttempt(ctx context.Context) {
+	if attempts, ok := ctx.Value(attemptsKey{}).(*int32); ok {
+		atomic.AddInt32(attempts, 1)
+	}
+}
+
+// logRequest sends req through the middleware chain, logging the outcome.
+func (c *Client) logRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
+	ctx, attempts := withAttemptCounter(ctx)
+	req = req.WithContext(ctx)
+
+	debug := c.logger.Enabled(ctx, slog.LevelDebug)
+	var reqBody []byte
+	if debug && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			reqBody, _ = io.ReadAll(body)
+		}
+	}
+
+	start := time.Now()
+	resp, err := c.roundTrip(req)
+	duration := time.Since(start)
+
+	retries := int(atomic.LoadInt32(attempts)) - 1
+	if retries < 0 {
+		retries = 0
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Duration("duration", duration),
+		slog.Int("retries", retries),
+	}
+	if debug && len(reqBody) > 0 {
+		attrs = append(attrs, slog.String("request_body", redactBody(reqBody)))
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		c.logger.LogAttrs(ctx, slog.LevelError, "awx request failed", attrs...)
+		return resp, err
+	}
+
+	attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	if id := resp.Header.Get(requestIDHeader); id != "" {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+	if debug && resp.Body != nil {
+		body, rerr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if rerr != nil {
+			return resp, rerr
+		}
+		if len(body) > 0 {
+			attrs = append(attrs, slog.String("response_body", redactBody(body)))
+		}
+	}
+
+	level := slog.LevelInfo
+	if resp.StatusCode >= 400 {
+		level = slog.LevelWarn
+	}
+	c.logger.LogAttrs(ctx, level, "awx request", attrs...)
+
+	return resp, err
+}
+
+// Redact returns a copy of a JSON request or response body with the values
+// of passwords, keys, tokens and other secrets replaced, as WithLogger logs
+// them. A body that is not JSON is returned unchanged.
+func Redact(body []byte) []byte {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+	redactedBody, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return body
+	}
+	return redactedBody
+}
+
+// redactBody returns body for logging, with the values of secret fields
+// replaced if it is JSON, truncated to maxLoggedBody bytes.
+func redactBody(body []byte) string {
+	body = Redact(body)
+
+	if len(body) > maxLoggedBody {
+		return string(body[:maxLoggedBody]) + "...(truncated)"
+	}
+	return string(body)
+}
+
+// redactValue walks a decoded JSON value and replaces the values of secret
+// fields and of any field AWX reports as Encrypted.
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if isSecretField(key) || isEncrypted(value) {
+				v[key] = redactSecret(value)
+				continue
+			}
+			if str, ok := value.(string); ok && isVarsField(key) {
+				v[key] = redactVars(str)
+				continue
+			}
+			v[key] = redactValue(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+	}
+	return v
+}
+
+// redactSecret replaces every string in the value of a secret field, keeping
+// the shape of the value so that redacted bodies still decode.
+func redactSecret(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		if v != "" {
+			return redacted
+		}
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = redactSecret(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactSecret(value)
+		}
+	}
+	return v
+}
+
+func isSecretField(key string) bool {
+	key = strings.ToLower(key)
+	if secretFields[key] {
+		return true
+	}
+	for _, suffix := range secretSuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isVarsField reports whether a field holds Ansible variables as JSON or
+// YAML text, which may contain secrets of their own.
+func isVarsField(key string) bool {
+	return key == "extra_vars" || key == "variables" || key == "source_vars"
+}
+
+// redactVars redacts the secrets in variables text. Text that does not
+// parse, or cannot be encoded again, is replaced as a whole, since its
+// secrets cannot be found.
+func redactVars(s string) string {
+	vars, err := ParseVars(s)
+	if err != nil {
+		return redacted
+	}
+	for key, value := range redactValue(vars.Map()).(map[string]interface{}) {
+		vars.Set(key, value)
+	}
+	text, err := vars.text()
+	if err != nil {
+		return redacted
+	}
+	return text
+}
+
+func isEncrypted(v interface{}) bool {
+	s, ok := v.(string)
+	return ok && strings.HasPrefix(s, Encrypted)
+}
@@ -0,0 +1,111 @@
+package awx
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "secret fields",
+			body: `{"username": "admin", "password": "hunter2", "vault_password": "", "ssh_key_data": "-----BEGIN"}`,
+			want: `{"username": "admin", "password": "REDACTED", "vault_password": "", "ssh_key_data": "REDACTED"}`,
+		},
+		{
+			name: "encrypted values",
+			body: `{"inputs": {"host": "db", "api_key": "$encrypted$"}}`,
+			want: `{"inputs": {"host": "db", "api_key": "REDACTED"}}`,
+		},
+		{
+			name: "secret values keep their shape",
+			body: `{"secret": {"key": "abc", "rotate": true, "ids": [1, 2]}}`,
+			want: `{"secret": {"key": "REDACTED", "rotate": true, "ids": [1, 2]}}`,
+		},
+		{
+			name: "secret suffixes",
+			body: `{"refresh_token": "abc", "client_secret": "def", "ANSIBLE_BECOME_PASS": "ghi", "become_password": "jkl", "aws_secret_key": "mno"}`,
+			want: `{"refresh_token": "REDACTED", "client_secret": "REDACTED", "ANSIBLE_BECOME_PASS": "REDACTED", "become_password": "REDACTED", "aws_secret_key": "REDACTED"}`,
+		},
+		{
+			name: "fields named like secrets",
+			body: `{"secret_count": 2, "token_description": "ci", "has_secrets": true, "password_required": "yes", "tokens": [{"id": 1, "token": "abc", "description": "ci"}]}`,
+			want: `{"secret_count": 2, "token_description": "ci", "has_secrets": true, "password_required": "yes", "tokens": [{"id": 1, "token": "REDACTED", "description": "ci"}]}`,
+		},
+		{
+			name: "json extra_vars",
+			body: `{"extra_vars": "{\"db_password\": \"hunter2\", \"env\": \"prod\"}"}`,
+			want: `{"extra_vars": "{\"db_password\":\"REDACTED\",\"env\":\"prod\"}"}`,
+		},
+		{
+			name: "yaml extra_vars",
+			body: `{"extra_vars": "db_password: hunter2\nenv: prod\n"}`,
+			want: `{"extra_vars": "---\ndb_password: REDACTED\nenv: prod\n"}`,
+		},
+		{
+			name: "unparsable extra_vars",
+			body: `{"extra_vars": "db_password: hunter2\n  - broken: [\n"}`,
+			want: `{"extra_vars": "REDACTED"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Redact([]byte(tt.body))
+			if !equalJSON(t, got, []byte(tt.want)) {
+				t.Errorf("Redact = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactNotJSON(t *testing.T) {
+	body := []byte("password=hunter2")
+	if got := Redact(body); !bytes.Equal(got, body) {
+		t.Errorf("Redact = %s, want the body unchanged", got)
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	client, mux := setup(t)
+	mux.HandleFunc("/api/v2/credentials/1/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(requestIDHeader, "req-1")
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{
+			"id":     1,
+			"inputs": map[string]string{"username": "admin", "password": "hunter2"},
+		})
+	})
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	if err := WithLogger(logger)(client); err != nil {
+		t.Fatalf("WithLogger: %v", err)
+	}
+
+	ctx := context.Background()
+	req, err := client.NewRequest(ctx, http.MethodGet, "credentials/1/", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	line := buf.String()
+	for _, want := range []string{"method=GET", "path=/api/v2/credentials/1/", "status=200", "request_id=req-1", "retries=0", "REDACTED"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("log %q does not contain %q", line, want)
+		}
+	}
+	if strings.Contains(line, "hunter2") {
+		t.Errorf("log %q contains the password", line)
+	}
+}
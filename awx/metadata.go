@@ -0,0 +1,306 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Field types reported in EndpointMetadata.
+const (
+	FieldTypeString   = "string"
+	FieldTypeInteger  = "integer"
+	FieldTypeFloat    = "float"
+	FieldTypeDecimal  = "decimal"
+	FieldTypeBoolean  = "boolean"
+	FieldTypeChoice   = "choice"
+	FieldTypeID       = "id"
+	FieldTypeJSON     = "json"
+	FieldTypeObject   = "object"
+	FieldTypeList     = "list"
+	FieldTypeDateTime = "datetime"
+)
+
+// MetadataService is an interface for interfacing with the OPTIONS metadata
+// of the endpoints of the AWX API. Metadata is fetched once per endpoint and
+// cached for the lifetime of the Client.
+type MetadataService interface {
+	Get(context.Context, string) (*EndpointMetadata, *Response, error)
+	Choices(context.Context, string, string) ([]FieldChoice, error)
+	Validate(context.Context, string, string, interface{}) error
+}
+
+// MetadataServiceOp handles communication with the OPTIONS metadata of the
+// AWX API.
+type MetadataServiceOp struct {
+	client *Client
+
+	mu    sync.Mutex
+	cache map[string]*EndpointMetadata
+}
+
+// EndpointMetadata represents the OPTIONS response of a AWX endpoint. Actions
+// maps the methods the requesting user may use, such as "POST" for a list
+// endpoint and "PUT" for a detail endpoint, to the fields they accept.
+type EndpointMetadata struct {
+	Name        string                              `json:"name"`
+	Description string                              `json:"description"`
+	Types       []string                            `json:"types"`
+	Actions     map[string]map[string]FieldMetadata `json:"actions"`
+}
+
+// FieldMetadata describes a single field of an endpoint.
+type FieldMetadata struct {
+	Type       string        `json:"type"`
+	Label      string        `json:"label"`
+	HelpText   string        `json:"help_text"`
+	Required   bool          `json:"required"`
+	ReadOnly   bool          `json:"read_only"`
+	Filterable bool          `json:"filterable"`
+	MaxLength  *int          `json:"max_length"`
+	MinValue   *float64      `json:"min_value"`
+	MaxValue   *float64      `json:"max_value"`
+	Default    interface{}   `json:"default"`
+	Choices    []FieldChoice `json:"choices"`
+}
+
+// FieldChoice is one of the valid values of a choice field.
+type FieldChoice struct {
+	Value interface{}
+	Label string
+}
+
+// UnmarshalJSON decodes a FieldChoice from the [value, label] pair AWX returns.
+func (c *FieldChoice) UnmarshalJSON(data []byte) error {
+	var pair []interface{}
+	if err := json.Unmarshal(data, &pair); err != nil {
+		return err
+	}
+	if len(pair) == 0 || len(pair) > 2 {
+		return fmt.Errorf("awx: invalid choice %s", data)
+	}
+
+	c.Value = pair[0]
+	c.Label = fmt.Sprint(pair[0])
+	if len(pair) == 2 {
+		if label, ok := pair[1].(string); ok {
+			c.Label = label
+		}
+	}
+
+	return nil
+}
+
+// ValidationError is returned by MetadataService.Validate when a request
+// would be rejected by AWX. Fields maps each invalid field to its errors,
+// in the same shape as ErrorResponse.Fields.
+type ValidationError struct {
+	Fields map[string][]string
+}
+
+var _ error = &ValidationError{}
+
+func (e *ValidationError) Error() string {
+	return "validation failed: " + formatFieldErrors(e.Fields)
+}
+
+func (e *ValidationError) add(field, format string, args ...interface{}) {
+	if e.Fields == nil {
+		e.Fields = make(map[string][]string)
+	}
+	e.Fields[field] = append(e.Fields[field], fmt.Sprintf(format, args...))
+}
+
+// formatFieldErrors formats field errors in a stable order.
+func formatFieldErrors(fields map[string][]string) string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	msgs := make([]string, len(names))
+	for i, name := range names {
+		msgs[i] = fmt.Sprintf("%s: %s", name, strings.Join(fields[name], " "))
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Get the metadata of the endpoint at path, e.g. "job_templates/".
+func (s *MetadataServiceOp) Get(ctx context.Context, path string) (*EndpointMetadata, *Response, error) {
+	if path == "" {
+		return nil, nil, NewArgError("path", "cannot be empty")
+	}
+
+	s.mu.Lock()
+	cached, ok := s.cache[path]
+	s.mu.Unlock()
+	if ok {
+		return cached, nil, nil
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodOptions, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(EndpointMetadata)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	s.mu.Lock()
+	if s.cache == nil {
+		s.cache = make(map[string]*EndpointMetadata)
+	}
+	s.cache[path] = root
+	s.mu.Unlock()
+
+	return root, resp, err
+}
+
+// Choices lists the valid values of a choice field of the endpoint at path,
+// e.g. the "job_type" of "job_templates/". Fields accepted on create are
+// looked up first, then those that can be filtered on.
+func (s *MetadataServiceOp) Choices(ctx context.Context, path, field string) ([]FieldChoice, error) {
+	metadata, _, err := s.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodGet} {
+		if f, ok := metadata.Actions[method][field]; ok && len(f.Choices) > 0 {
+			return f.Choices, nil
+		}
+	}
+
+	return nil, fmt.Errorf("awx: field %q of %s has no choices", field, path)
+}
+
+// Validate checks body, such as a *JobTemplateCreateRequest, against the
+// metadata of the endpoint at path before it is sent with method. POST
+// requests are checked against the fields accepted on create, including the
+// required ones; PUT and PATCH requests against the fields accepted on
+// update. A PATCH need only include the fields it changes, but those it
+// includes may not be blank or null if they are required. It returns a *ValidationError listing every
+// invalid field.
+func (s *MetadataServiceOp) Validate(ctx context.Context, method, path string, body interface{}) error {
+	if body == nil {
+		return NewArgError("body", "cannot be nil")
+	}
+
+	action := method
+	if method == http.MethodPatch {
+		action = http.MethodPut
+	}
+
+	metadata, _, err := s.Get(ctx, path)
+	if err != nil {
+		return err
+	}
+	fields, ok := metadata.Actions[action]
+	if !ok {
+		return fmt.Errorf("awx: %s %s is not allowed", method, path)
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("awx: body must encode to a JSON object: %w", err)
+	}
+
+	verr := &ValidationError{}
+	for name, field := range fields {
+		if field.ReadOnly {
+			continue
+		}
+		value, ok := values[name]
+		if !ok {
+			// The metadata is that of PUT, but a partial update need not
+			// include its required fields.
+			if field.Required && method != http.MethodPatch {
+				verr.add(name, "This field is required.")
+			}
+			continue
+		}
+
+		field.validate(verr, name, value)
+	}
+
+	if len(verr.Fields) > 0 {
+		return verr
+	}
+
+	return nil
+}
+
+// validate checks a value against the field, with the messages AWX uses.
+func (f *FieldMetadata) validate(verr *ValidationError, name string, value interface{}) {
+	if value == nil {
+		if f.Required {
+			verr.add(name, "This field may not be null.")
+		}
+		return
+	}
+
+	switch f.Type {
+	case FieldTypeString:
+		s, ok := value.(string)
+		if !ok {
+			verr.add(name, "Not a valid string.")
+			return
+		}
+		if s == "" && f.Required {
+			verr.add(name, "This field may not be blank.")
+		}
+		if f.MaxLength != nil && len([]rune(s)) > *f.MaxLength {
+			verr.add(name, "Ensure this field has no more than %d characters.", *f.MaxLength)
+		}
+	case FieldTypeInteger, FieldTypeID:
+		n, ok := value.(float64)
+		if !ok || n != math.Trunc(n) {
+			verr.add(name, "A valid integer is required.")
+			return
+		}
+		f.validateRange(verr, name, n)
+	case FieldTypeFloat, FieldTypeDecimal:
+		n, ok := value.(float64)
+		if !ok {
+			verr.add(name, "A valid number is required.")
+			return
+		}
+		f.validateRange(verr, name, n)
+	case FieldTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			verr.add(name, "Must be a valid boolean.")
+		}
+	case FieldTypeChoice:
+		if s, ok := value.(string); ok && s == "" && !f.Required {
+			return
+		}
+		for _, choice := range f.Choices {
+			if fmt.Sprint(choice.Value) == fmt.Sprint(value) {
+				return
+			}
+		}
+		verr.add(name, "\"%v\" is not a valid choice.", value)
+	}
+}
+
+func (f *FieldMetadata) validateRange(verr *ValidationError, name string, n float64) {
+	if f.MinValue != nil && n < *f.MinValue {
+		verr.add(name, "Ensure this value is greater than or equal to %v.", *f.MinValue)
+	}
+	if f.MaxValue != nil && n > *f.MaxValue {
+		verr.add(name, "Ensure this value is less than or equal to %v.", *f.MaxValue)
+	}
+}
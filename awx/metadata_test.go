@@ -0,0 +1,91 @@
+package awx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+const jobTemplateOptions = `{
+	"name": "Job Template Detail",
+	"actions": {
+		"PUT": {
+			"name": {"type": "string", "required": true, "max_length": 512},
+			"playbook": {"type": "string", "required": true},
+			"job_type": {"type": "choice", "required": false, "choices": [["run", "Run"], ["check", "Check"]]},
+			"forks": {"type": "integer", "required": false, "min_value": 0},
+			"id": {"type": "integer", "read_only": true}
+		}
+	}
+}`
+
+func TestMetadataValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		body    map[string]interface{}
+		invalid []string
+	}{
+		{
+			name:   "put",
+			method: http.MethodPut,
+			body:   map[string]interface{}{"name": "deploy", "playbook": "site.yml", "job_type": "run"},
+		},
+		{
+			name:    "put missing required",
+			method:  http.MethodPut,
+			body:    map[string]interface{}{"name": ""},
+			invalid: []string{"name", "playbook"},
+		},
+		{
+			name:   "patch without required",
+			method: http.MethodPatch,
+			body:   map[string]interface{}{"forks": 5},
+		},
+		{
+			name:    "patch with blank required",
+			method:  http.MethodPatch,
+			body:    map[string]interface{}{"name": "", "playbook": nil, "forks": 5},
+			invalid: []string{"name", "playbook"},
+		},
+		{
+			name:    "patch still checks values",
+			method:  http.MethodPatch,
+			body:    map[string]interface{}{"job_type": "scan", "forks": -1},
+			invalid: []string{"forks", "job_type"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux := setup(t)
+			mux.HandleFunc("/api/v2/job_templates/1/", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodOptions {
+					t.Errorf("%s %s, want OPTIONS", r.Method, r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(jobTemplateOptions))
+			})
+
+			err := client.Metadata.Validate(context.Background(), tt.method, "job_templates/1/", tt.body)
+
+			var invalid []string
+			if err != nil {
+				var verr *ValidationError
+				if !errors.As(err, &verr) {
+					t.Fatalf("Validate: %v, want a *ValidationError", err)
+				}
+				for field := range verr.Fields {
+					invalid = append(invalid, field)
+				}
+				sort.Strings(invalid)
+			}
+			if !reflect.DeepEqual(invalid, tt.invalid) {
+				t.Errorf("Validate: invalid %v, want %v (%v)", invalid, tt.invalid, err)
+			}
+		})
+	}
+}
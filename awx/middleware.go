@@ -0,0 +1,66 @@
+package awx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrReadOnly is returned for requests that would change data on a Client
+// using the ReadOnly middleware.
+var ErrReadOnly = errors.New("awx: client is read-only")
+
+// RoundTripFunc sends a single HTTP request to AWX and returns its response.
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+// Middleware wraps the RoundTripFunc Client.Do sends requests with. A
+// Middleware may modify the request, inspect the response, or return without
+// calling next at all.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// WithMiddleware is a client option that adds Middleware around every request
+// sent by Client.Do. The first Middleware given is the outermost and sees the
+// request first; options may be repeated and add to the chain in order.
+func WithMiddleware(mw ...Middleware) ClientOpt {
+	return func(c *Client) error {
+		for _, m := range mw {
+			if m == nil {
+				return NewArgError("mw", "cannot contain nil Middleware")
+			}
+		}
+		c.middleware = append(c.middleware, mw...)
+		return nil
+	}
+}
+
+// ReadOnly returns Middleware that rejects every request other than GET,
+// HEAD and OPTIONS with ErrReadOnly, without sending it.
+func ReadOnly() Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			switch req.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				return next(req)
+			}
+			return nil, fmt.Errorf("%w: %s %s", ErrReadOnly, req.Method, req.URL.Path)
+		}
+	}
+}
+
+// roundTrip sends req through the middleware chain of the Client.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	next := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		countAttempt(req.Context())
+		return DoRequestWithClient(req.Context(), c.client, req)
+	})
+	if c.limiter != nil {
+		send := next
+		next = func(req *http.Request) (*http.Response, error) {
+			return c.limiter.send(req, send)
+		}
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		next = c.middleware[i](next)
+	}
+	return next(req)
+}
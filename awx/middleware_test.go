@@ -0,0 +1,135 @@
+package awx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	client, mux := setup(t)
+	var calls []string
+	mux.HandleFunc("/api/v2/organizations/1/", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "AWX "+r.Header.Get("X-Trace"))
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{"id": 1, "name": "Default"})
+	})
+
+	record := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, "> "+name)
+				req.Header.Set("X-Trace", req.Header.Get("X-Trace")+name)
+				resp, err := next(req)
+				calls = append(calls, "< "+name)
+				return resp, err
+			}
+		}
+	}
+	for _, opt := range []ClientOpt{WithMiddleware(record("a"), record("b")), WithMiddleware(record("c"))} {
+		if err := opt(client); err != nil {
+			t.Fatalf("WithMiddleware: %v", err)
+		}
+	}
+
+	if _, _, err := client.Organization.Get(context.Background(), 1); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	want := []string{"> a", "> b", "> c", "AWX abc", "< c", "< b", "< a"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %q, want %q", calls, want)
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	client, _ := setup(t)
+	sent := 0
+	canned := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{"id": 1, "name": "canned"}`)),
+				Request:    req,
+			}, nil
+		}
+	}
+	counting := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			sent++
+			return next(req)
+		}
+	}
+	if err := WithMiddleware(canned, counting)(client); err != nil {
+		t.Fatalf("WithMiddleware: %v", err)
+	}
+
+	org, _, err := client.Organization.Get(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if org.Name != "canned" || sent != 0 {
+		t.Errorf("Get = %q with %d requests sent, want the canned response and none", org.Name, sent)
+	}
+}
+
+// countingTransport counts the requests it sends.
+type countingTransport struct {
+	next http.RoundTripper
+	sent []string
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.sent = append(t.sent, req.Method)
+	return t.next.RoundTrip(req)
+}
+
+func TestReadOnly(t *testing.T) {
+	client, mux := setup(t)
+	mux.HandleFunc("/api/v2/organizations/1/", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{"id": 1, "name": "Default"})
+	})
+	transport := &countingTransport{next: client.client.Transport}
+	client.client = &http.Client{Transport: transport}
+	if err := WithMiddleware(ReadOnly())(client); err != nil {
+		t.Fatalf("WithMiddleware: %v", err)
+	}
+	ctx := context.Background()
+
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodOptions} {
+		req, err := client.NewRequest(ctx, method, "organizations/1/", nil)
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		if _, err := client.Do(ctx, req, nil); err != nil {
+			t.Errorf("%s: %v, want it sent", method, err)
+		}
+	}
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		req, err := client.NewRequest(ctx, method, "organizations/1/", map[string]string{"name": "changed"})
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		if _, err := client.Do(ctx, req, nil); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("%s: %v, want ErrReadOnly", method, err)
+		}
+	}
+	if _, err := client.Organization.Delete(ctx, 1); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Delete: %v, want ErrReadOnly", err)
+	}
+
+	if want := []string{http.MethodGet, http.MethodHead, http.MethodOptions}; !reflect.DeepEqual(transport.sent, want) {
+		t.Errorf("sent %q, want only %q", transport.sent, want)
+	}
+}
+
+func TestWithMiddlewareNil(t *testing.T) {
+	var argErr *ArgError
+	if err := WithMiddleware(ReadOnly(), nil)(NewClient(nil)); !errors.As(err, &argErr) {
+		t.Errorf("WithMiddleware(nil): %v, want an *ArgError", err)
+	}
+}
@@ -2,12 +2,13 @@ package awx
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 )
 
-const organizationBasePath = "api/v2/organizations/"
+const organizationBasePath = "organizations/"
 
 // OrganizationService is an interface for interfacing with the Organization
 // endpoints of the AWX API
@@ -18,6 +19,8 @@ type OrganizationService interface {
 	Create(context.Context, *OrganizationCreateRequest) (*Organization, *Response, error)
 	Update(context.Context, *OrganizationCreateRequest, int) (*Response, error)
 	Delete(context.Context, int) (*Response, error)
+	ListInstanceGroups(context.Context, int) ([]InstanceGroup, *Response, error)
+	SetInstanceGroups(context.Context, int, []int) (*Response, error)
 }
 
 // OrganizationServiceOp handles communication with the Organization related methods of the
@@ -53,74 +56,21 @@ type Organization struct {
 		ActivityStream               string `json:"activity_stream"`
 	} `json:"related"`
 	SummaryFields struct {
-		CreatedBy struct {
-			ID        int    `json:"id"`
-			Username  string `json:"username"`
-			FirstName string `json:"first_name"`
-			LastName  string `json:"last_name"`
-		} `json:"created_by"`
-		ModifiedBy struct {
-			ID        int    `json:"id"`
-			Username  string `json:"username"`
-			FirstName string `json:"first_name"`
-			LastName  string `json:"last_name"`
-		} `json:"modified_by"`
+		CreatedBy   *UserSummary `json:"created_by"`
+		ModifiedBy  *UserSummary `json:"modified_by"`
 		ObjectRoles struct {
-			AdminRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"admin_role"`
-			MemberRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"member_role"`
-			ExecuteRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"execute_role"`
-			NotificationAdminRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"notification_admin_role"`
-			WorkflowAdminRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"workflow_admin_role"`
-			CredentialAdminRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"credential_admin_role"`
-			ReadRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"read_role"`
-			ProjectAdminRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"project_admin_role"`
-			AuditorRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"auditor_role"`
-			InventoryAdminRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"inventory_admin_role"`
+			AdminRole             ObjectRole `json:"admin_role"`
+			MemberRole            ObjectRole `json:"member_role"`
+			ExecuteRole           ObjectRole `json:"execute_role"`
+			NotificationAdminRole ObjectRole `json:"notification_admin_role"`
+			WorkflowAdminRole     ObjectRole `json:"workflow_admin_role"`
+			CredentialAdminRole   ObjectRole `json:"credential_admin_role"`
+			ReadRole              ObjectRole `json:"read_role"`
+			ProjectAdminRole      ObjectRole `json:"project_admin_role"`
+			AuditorRole           ObjectRole `json:"auditor_role"`
+			InventoryAdminRole    ObjectRole `json:"inventory_admin_role"`
 		} `json:"object_roles"`
-		UserCapabilities struct {
-			Edit   bool `json:"edit"`
-			Delete bool `json:"delete"`
-		} `json:"user_capabilities"`
+		UserCapabilities   UserCapabilities `json:"user_capabilities"`
 		RelatedFieldCounts struct {
 			JobTemplates int `json:"job_templates"`
 			Users        int `json:"users"`
@@ -130,11 +80,29 @@ type Organization struct {
 			Projects     int `json:"projects"`
 		} `json:"related_field_counts"`
 	} `json:"summary_fields"`
-	Created          time.Time `json:"created"`
-	Modified         time.Time `json:"modified"`
-	Name             string    `json:"name"`
-	Description      string    `json:"description"`
-	CustomVirtualenv string    `json:"custom_virtualenv"`
+	Created            time.Time `json:"created"`
+	Modified           time.Time `json:"modified"`
+	Name               string    `json:"name"`
+	Description        string    `json:"description"`
+	CustomVirtualenv   string    `json:"custom_virtualenv"`
+	DefaultEnvironment *int      `json:"default_environment"`
+	// Extra holds the fields returned by AWX that have no field above,
+	// such as fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a Organization, keeping unknown fields in Extra.
+func (o *Organization) UnmarshalJSON(data []byte) error {
+	type organization Organization
+	extra, err := unmarshalWithExtra(data, (*organization)(o))
+	o.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a Organization together with the fields in Extra.
+func (o Organization) MarshalJSON() ([]byte, error) {
+	type organization Organization
+	return marshalWithExtra(organization(o), o.Extra)
 }
 
 // OrganizationCreateRequest represents a request to create a Organization.
@@ -142,13 +110,26 @@ type OrganizationCreateRequest struct {
 	Name             string `json:"name"`
 	Description      string `json:"description,omitempty"`
 	CustomVirtualenv string `json:"custom_virtualenv,omitempty"`
+	// DefaultEnvironment is the ID of the default execution environment.
+	// Leave it nil to keep the current one, or set it to Int(0) to unset it.
+	DefaultEnvironment *int `json:"default_environment,omitempty"`
+	// Extra holds fields to send that have no field above, such as
+	// fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes a OrganizationCreateRequest together with the fields in Extra.
+func (r OrganizationCreateRequest) MarshalJSON() ([]byte, error) {
+	type request OrganizationCreateRequest
+	extra := clearID(&r.DefaultEnvironment, "default_environment", r.Extra)
+	return marshalWithExtra(request(r), extra)
 }
 
 // OrganizationRoot represents a Organization root
 type organizationRoot struct {
 	Count        int            `json:"count"`
-	Next         int            `json:"next"`
-	Previous     int            `json:"previous"`
+	Next         string         `json:"next"`
+	Previous     string         `json:"previous"`
 	Results      []Organization `json:"results"`
 	Organization *Organization
 }
@@ -252,3 +233,26 @@ func (s *OrganizationServiceOp) Delete(ctx context.Context, organizationID int)
 
 	return resp, err
 }
+
+// ListInstanceGroups lists the InstanceGroups of a Organization in order of preference.
+func (s *OrganizationServiceOp) ListInstanceGroups(ctx context.Context, organizationID int) ([]InstanceGroup, *Response, error) {
+	if organizationID < 1 {
+		return nil, nil, NewArgError("organizationID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/instance_groups/", organizationBasePath, organizationID)
+
+	return listInstanceGroups(ctx, s.client, path)
+}
+
+// SetInstanceGroups replaces the InstanceGroups of a Organization. Jobs are sent to
+// the first group with capacity, in the order given.
+func (s *OrganizationServiceOp) SetInstanceGroups(ctx context.Context, organizationID int, instanceGroupIDs []int) (*Response, error) {
+	if organizationID < 1 {
+		return nil, NewArgError("organizationID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/instance_groups/", organizationBasePath, organizationID)
+
+	return setInstanceGroups(ctx, s.client, path, instanceGroupIDs)
+}
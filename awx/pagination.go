@@ -0,0 +1,40 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// page is the envelope AWX wraps the results of every list endpoint in.
+type page struct {
+	Count    int             `json:"count"`
+	Next     string          `json:"next"`
+	Previous string          `json:"previous"`
+	Results  json.RawMessage `json:"results"`
+}
+
+// listAllPages fetches path and every page following it, passing the raw
+// results of each page to add. The Response of the last page is returned.
+func listAllPages(ctx context.Context, client *Client, path string, add func(json.RawMessage) error) (*Response, error) {
+	var resp *Response
+	for path != "" {
+		req, err := client.NewRequest(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return resp, err
+		}
+
+		root := new(page)
+		resp, err = client.Do(ctx, req, root)
+		if err != nil {
+			return resp, err
+		}
+
+		if err := add(root.Results); err != nil {
+			return resp, err
+		}
+		path = root.Next
+	}
+
+	return resp, nil
+}
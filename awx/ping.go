@@ -0,0 +1,68 @@
+package awx
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+const pingBasePath = "ping/"
+
+// PingService is an interface for interfacing with the Ping
+// endpoint of the AWX API
+// See: http://localhost/api/v2/ping/
+type PingService interface {
+	Get(context.Context) (*Ping, *Response, error)
+}
+
+// PingServiceOp handles communication with the Ping related methods of the
+// AWX API.
+type PingServiceOp struct {
+	client *Client
+}
+
+// Ping represents the health of a AWX cluster. The ping endpoint does not
+// require authentication.
+type Ping struct {
+	HA             bool                `json:"ha"`
+	Version        string              `json:"version"`
+	ActiveNode     string              `json:"active_node"`
+	InstallUUID    string              `json:"install_uuid"`
+	Instances      []PingInstance      `json:"instances"`
+	InstanceGroups []PingInstanceGroup `json:"instance_groups"`
+}
+
+// PingInstance represents a node of the cluster as reported by Ping.
+type PingInstance struct {
+	Node      string    `json:"node"`
+	NodeType  string    `json:"node_type"`
+	UUID      string    `json:"uuid"`
+	Heartbeat time.Time `json:"heartbeat"`
+	Capacity  int       `json:"capacity"`
+	Version   string    `json:"version"`
+}
+
+// PingInstanceGroup represents an instance group as reported by Ping.
+type PingInstanceGroup struct {
+	Name      string   `json:"name"`
+	Capacity  int      `json:"capacity"`
+	Instances []string `json:"instances"`
+}
+
+// Get the health of the cluster.
+func (s *PingServiceOp) Get(ctx context.Context) (*Ping, *Response, error) {
+	path := pingBasePath
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Ping)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
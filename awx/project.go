@@ -2,12 +2,13 @@ package awx
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 )
 
-const projectBasePath = "api/v2/projects/"
+const projectBasePath = "projects/"
 
 // ProjectService is an interface for interfacing with the Project
 // endpoints of the AWX API
@@ -18,6 +19,8 @@ type ProjectService interface {
 	Create(context.Context, *ProjectCreateRequest) (*Project, *Response, error)
 	Update(context.Context, *ProjectCreateRequest, int) (*Response, error)
 	Delete(context.Context, int) (*Response, error)
+	Playbooks(context.Context, int) ([]string, *Response, error)
+	InventoryFiles(context.Context, int) ([]string, *Response, error)
 }
 
 // ProjectsServiceOp handles communication with the Project related methods of the
@@ -53,86 +56,60 @@ type Project struct {
 		LastUpdate                   string `json:"last_update"`
 	} `json:"related"`
 	SummaryFields struct {
-		LastJob struct {
-			ID          int       `json:"id"`
-			Name        string    `json:"name"`
-			Description string    `json:"description"`
-			Finished    time.Time `json:"finished"`
-			Status      string    `json:"status"`
-			Failed      bool      `json:"failed"`
-		} `json:"last_job"`
-		LastUpdate struct {
-			ID          int    `json:"id"`
-			Name        string `json:"name"`
-			Description string `json:"description"`
-			Status      string `json:"status"`
-			Failed      bool   `json:"failed"`
-		} `json:"last_update"`
-		Organization struct {
-			ID          int    `json:"id"`
-			Name        string `json:"name"`
-			Description string `json:"description"`
-		} `json:"organization"`
-		CreatedBy struct {
-			ID        int    `json:"id"`
-			Username  string `json:"username"`
-			FirstName string `json:"first_name"`
-			LastName  string `json:"last_name"`
-		} `json:"created_by"`
-		ObjectRoles struct {
-			AdminRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"admin_role"`
-			UseRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"use_role"`
-			UpdateRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"update_role"`
-			ReadRole struct {
-				ID          int    `json:"id"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-			} `json:"read_role"`
+		LastJob      *JobSummary    `json:"last_job"`
+		LastUpdate   *JobSummary    `json:"last_update"`
+		Organization *ObjectSummary `json:"organization"`
+		CreatedBy    *UserSummary   `json:"created_by"`
+		ObjectRoles  struct {
+			AdminRole  ObjectRole `json:"admin_role"`
+			UseRole    ObjectRole `json:"use_role"`
+			UpdateRole ObjectRole `json:"update_role"`
+			ReadRole   ObjectRole `json:"read_role"`
 		} `json:"object_roles"`
-		UserCapabilities struct {
-			Edit     bool `json:"edit"`
-			Start    bool `json:"start"`
-			Copy     bool `json:"copy"`
-			Schedule bool `json:"schedule"`
-			Delete   bool `json:"delete"`
-		} `json:"user_capabilities"`
+		UserCapabilities UserCapabilities `json:"user_capabilities"`
 	} `json:"summary_fields"`
-	Created               time.Time `json:"created"`
-	Modified              time.Time `json:"modified"`
-	Name                  string    `json:"name"`
-	Description           string    `json:"description"`
-	LocalPath             string    `json:"local_path"`
-	ScmType               string    `json:"scm_type"`
-	ScmURL                string    `json:"scm_url"`
-	ScmBranch             string    `json:"scm_branch"`
-	ScmClean              bool      `json:"scm_clean"`
-	ScmDeleteOnUpdate     bool      `json:"scm_delete_on_update"`
-	Credential            int       `json:"credential"`
-	Timeout               int       `json:"timeout"`
-	LastJobRun            time.Time `json:"last_job_run"`
-	LastJobFailed         bool      `json:"last_job_failed"`
-	NextJobRun            time.Time `json:"next_job_run"`
-	Status                string    `json:"status"`
-	Organization          int       `json:"organization"`
-	ScmDeleteOnNextUpdate bool      `json:"scm_delete_on_next_update"`
-	ScmUpdateOnLaunch     bool      `json:"scm_update_on_launch"`
-	ScmUpdateCacheTimeout int       `json:"scm_update_cache_timeout"`
-	ScmRevision           string    `json:"scm_revision"`
-	CustomVirtualenv      string    `json:"custom_virtualenv"`
-	LastUpdateFailed      bool      `json:"last_update_failed"`
-	LastUpdated           time.Time `json:"last_updated"`
+	Created               time.Time  `json:"created"`
+	Modified              time.Time  `json:"modified"`
+	Name                  string     `json:"name"`
+	Description           string     `json:"description"`
+	LocalPath             string     `json:"local_path"`
+	ScmType               string     `json:"scm_type"`
+	ScmURL                string     `json:"scm_url"`
+	ScmBranch             string     `json:"scm_branch"`
+	ScmClean              bool       `json:"scm_clean"`
+	ScmDeleteOnUpdate     bool       `json:"scm_delete_on_update"`
+	Credential            *int       `json:"credential"`
+	Timeout               int        `json:"timeout"`
+	LastJobRun            *time.Time `json:"last_job_run"`
+	LastJobFailed         bool       `json:"last_job_failed"`
+	NextJobRun            *time.Time `json:"next_job_run"`
+	Status                string     `json:"status"`
+	Organization          *int       `json:"organization"`
+	ScmDeleteOnNextUpdate bool       `json:"scm_delete_on_next_update"`
+	ScmUpdateOnLaunch     bool       `json:"scm_update_on_launch"`
+	ScmUpdateCacheTimeout int        `json:"scm_update_cache_timeout"`
+	ScmRevision           string     `json:"scm_revision"`
+	CustomVirtualenv      string     `json:"custom_virtualenv"`
+	DefaultEnvironment    *int       `json:"default_environment"`
+	LastUpdateFailed      bool       `json:"last_update_failed"`
+	LastUpdated           *time.Time `json:"last_updated"`
+	// Extra holds the fields returned by AWX that have no field above,
+	// such as fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a Project, keeping unknown fields in Extra.
+func (p *Project) UnmarshalJSON(data []byte) error {
+	type project Project
+	extra, err := unmarshalWithExtra(data, (*project)(p))
+	p.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a Project together with the fields in Extra.
+func (p Project) MarshalJSON() ([]byte, error) {
+	type project Project
+	return marshalWithExtra(project(p), p.Extra)
 }
 
 // ProjectCreateRequest represents a request to create a Project.
@@ -151,15 +128,27 @@ type ProjectCreateRequest struct {
 	ScmUpdateOnLaunch     bool   `json:"scm_update_on_launch,omitempty"`
 	ScmUpdateCacheTimeout int    `json:"scm_update_cache_timeout,omitempty"`
 	CustomVirtualenv      string `json:"custom_virtualenv,omitempty"`
+	// DefaultEnvironment is the ID of the default execution environment.
+	// Leave it nil to keep the current one, or set it to Int(0) to unset it.
+	DefaultEnvironment *int `json:"default_environment,omitempty"`
+	// Extra holds fields to send that have no field above, such as
+	// fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes a ProjectCreateRequest together with the fields in Extra.
+func (r ProjectCreateRequest) MarshalJSON() ([]byte, error) {
+	type request ProjectCreateRequest
+	extra := clearID(&r.DefaultEnvironment, "default_environment", r.Extra)
+	return marshalWithExtra(request(r), extra)
 }
 
 // projectyRoot represents a Project root
 type projectRoot struct {
 	Count    int       `json:"count"`
-	Next     int       `json:"next"`
-	Previous int       `json:"previous"`
+	Next     string    `json:"next"`
+	Previous string    `json:"previous"`
 	Results  []Project `json:"results"`
-	Project  *Project
 }
 
 // List all Projects.
@@ -213,12 +202,14 @@ func (s *ProjectServiceOp) Create(ctx context.Context, createRequest *ProjectCre
 	if err != nil {
 		return nil, nil, err
 	}
-	root := new(projectRoot)
+
+	root := new(Project)
 	resp, err := s.client.Do(ctx, req, root)
 	if err != nil {
 		return nil, resp, err
 	}
-	return root.Project, resp, err
+
+	return root, resp, err
 }
 
 // Update Project.
@@ -259,3 +250,43 @@ func (s *ProjectServiceOp) Delete(ctx context.Context, projectID int) (*Response
 
 	return resp, err
 }
+
+// Playbooks lists the playbooks available in a Project's last synced revision.
+func (s *ProjectServiceOp) Playbooks(ctx context.Context, projectID int) ([]string, *Response, error) {
+	if projectID < 1 {
+		return nil, nil, NewArgError("projectID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/playbooks/", projectBasePath, projectID)
+
+	return s.listFiles(ctx, path)
+}
+
+// InventoryFiles lists the files in a Project that can be used as an
+// InventorySource SourcePath.
+func (s *ProjectServiceOp) InventoryFiles(ctx context.Context, projectID int) ([]string, *Response, error) {
+	if projectID < 1 {
+		return nil, nil, NewArgError("projectID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/inventory_files/", projectBasePath, projectID)
+
+	return s.listFiles(ctx, path)
+}
+
+// listFiles fetches one of the Project endpoints that return a plain JSON
+// array of file paths rather than a paginated root.
+func (s *ProjectServiceOp) listFiles(ctx context.Context, path string) ([]string, *Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var files []string
+	resp, err := s.client.Do(ctx, req, &files)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return files, resp, err
+}
@@ -0,0 +1,234 @@
+package awx
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultThrottlePause is how long requests are held back after a 429 or
+	// 503 response without a Retry-After header.
+	defaultThrottlePause = time.Second
+	// maxThrottlePause bounds the pause requested by a Retry-After header.
+	maxThrottlePause = 5 * time.Minute
+)
+
+// RateLimitOptions configures client-side rate limiting.
+type RateLimitOptions struct {
+	// RequestsPerSecond is the rate at which requests are sent, enforced with
+	// a token bucket. Zero leaves the rate unlimited.
+	RequestsPerSecond float64
+	// Burst is the number of requests that may be sent at once after a quiet
+	// period. It defaults to one.
+	Burst int
+	// MaxInFlight bounds the number of requests awaiting a response, counted
+	// until the response body is closed. Zero leaves it unlimited.
+	MaxInFlight int
+	// MinRequestsPerSecond is the lowest rate adaptive slowdown will reduce
+	// RequestsPerSecond to. It defaults to a tenth of RequestsPerSecond.
+	MinRequestsPerSecond float64
+}
+
+// WithRateLimit is a client option that limits the rate and concurrency of
+// the requests sent by Client.Do, shared by every service of the Client and
+// applied to each attempt, including retries made by Middleware.
+//
+// When AWX answers 429 Too Many Requests or 503 Service Unavailable the
+// Client slows down: no request is sent until the Retry-After delay, or one
+// second, has passed and the rate is halved, recovering gradually as
+// requests succeed again.
+func WithRateLimit(opts RateLimitOptions) ClientOpt {
+	return func(c *Client) error {
+		if opts.RequestsPerSecond < 0 {
+			return NewArgError("RequestsPerSecond", "cannot be less than 0")
+		}
+		if opts.Burst < 0 {
+			return NewArgError("Burst", "cannot be less than 0")
+		}
+		if opts.MaxInFlight < 0 {
+			return NewArgError("MaxInFlight", "cannot be less than 0")
+		}
+		if opts.MinRequestsPerSecond < 0 {
+			return NewArgError("MinRequestsPerSecond", "cannot be less than 0")
+		}
+		c.limiter = newRateLimiter(opts)
+		return nil
+	}
+}
+
+// rateLimiter is a token bucket with a cap on requests in flight.
+type rateLimiter struct {
+	inFlight chan struct{}
+
+	// now and after are time.Now and time.After, replaced in tests.
+	now   func() time.Time
+	after func(time.Duration) <-chan time.Time
+
+	mu      sync.Mutex
+	limit   float64
+	rate    float64
+	minRate float64
+	burst   float64
+	tokens  float64
+	last    time.Time
+	paused  time.Time
+}
+
+func newRateLimiter(opts RateLimitOptions) *rateLimiter {
+	l := &rateLimiter{
+		now:     time.Now,
+		after:   time.After,
+		limit:   opts.RequestsPerSecond,
+		rate:    opts.RequestsPerSecond,
+		minRate: opts.MinRequestsPerSecond,
+		burst:   float64(opts.Burst),
+		last:    time.Now(),
+	}
+	if l.burst == 0 {
+		l.burst = 1
+	}
+	if l.minRate == 0 || l.minRate > l.limit {
+		l.minRate = l.limit / 10
+	}
+	l.tokens = l.burst
+	if opts.MaxInFlight > 0 {
+		l.inFlight = make(chan struct{}, opts.MaxInFlight)
+	}
+	return l
+}
+
+// acquire blocks until a request may be sent, returning the function that
+// releases its in-flight slot.
+func (l *rateLimiter) acquire(ctx context.Context) (func(), error) {
+	for {
+		wait := l.reserve()
+		if wait <= 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-l.after(wait):
+		}
+	}
+
+	if l.inFlight == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.inFlight <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-l.inFlight })
+	}, nil
+}
+
+// reserve takes a token if one is available, or returns how long to wait
+// before trying again.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Before(l.paused) {
+		return l.paused.Sub(now)
+	}
+	if l.rate == 0 {
+		return 0
+	}
+
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// observe adapts the rate to the response AWX sent.
+func (l *rateLimiter) observe(resp *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		now := l.now()
+		until := now.Add(retryAfter(resp.Header.Get("Retry-After"), now))
+		if until.After(l.paused) {
+			l.paused = until
+		}
+		l.rate = math.Max(l.minRate, l.rate/2)
+		l.tokens = 0
+	default:
+		if l.rate < l.limit {
+			l.rate = math.Min(l.limit, l.rate+l.limit/20)
+		}
+	}
+}
+
+// retryAfter parses a Retry-After header given in seconds or as a date,
+// relative to now.
+func retryAfter(value string, now time.Time) time.Duration {
+	var d time.Duration
+	if secs, err := strconv.Atoi(value); err == nil {
+		d = time.Duration(secs) * time.Second
+	} else if t, err := http.ParseTime(value); err == nil {
+		d = t.Sub(now)
+	}
+
+	if d <= 0 {
+		return defaultThrottlePause
+	}
+	if d > maxThrottlePause {
+		return maxThrottlePause
+	}
+	return d
+}
+
+// send sends req once the rate limiter allows it.
+func (l *rateLimiter) send(req *http.Request, next RoundTripFunc) (*http.Response, error) {
+	release, err := l.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := next(req)
+	if err != nil {
+		release()
+		return resp, err
+	}
+
+	l.observe(resp)
+	if resp.Body == nil {
+		release()
+		return resp, nil
+	}
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+
+	return resp, nil
+}
+
+// releasingBody frees the in-flight slot of a request when its response body
+// is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
@@ -0,0 +1,289 @@
+package awx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock stands in for the clock of a rateLimiter. Waits pass at once,
+// advancing the clock, unless the clock is stopped.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waits   []time.Duration
+	stopped bool
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.waits = append(c.waits, d)
+	if c.stopped {
+		return nil
+	}
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+func (c *fakeClock) takeWaits() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	waits := c.waits
+	c.waits = nil
+	return waits
+}
+
+func newTestLimiter(opts RateLimitOptions) (*rateLimiter, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)}
+	l := newRateLimiter(opts)
+	l.now = clock.Now
+	l.after = clock.After
+	l.last = clock.now
+	return l, clock
+}
+
+func acquireN(t *testing.T, l *rateLimiter, n int) {
+	t.Helper()
+
+	for i := 0; i < n; i++ {
+		release, err := l.acquire(context.Background())
+		if err != nil {
+			t.Fatalf("acquire: %v", err)
+		}
+		release()
+	}
+}
+
+func throttled(status int, retryAfter string) *http.Response {
+	resp := &http.Response{StatusCode: status, Header: http.Header{}}
+	if retryAfter != "" {
+		resp.Header.Set("Retry-After", retryAfter)
+	}
+	return resp
+}
+
+func TestRateLimitBurst(t *testing.T) {
+	l, clock := newTestLimiter(RateLimitOptions{RequestsPerSecond: 2, Burst: 3})
+
+	acquireN(t, l, 3)
+	if waits := clock.takeWaits(); len(waits) != 0 {
+		t.Errorf("burst waited %v, want no wait", waits)
+	}
+
+	acquireN(t, l, 2)
+	if waits, want := clock.takeWaits(), []time.Duration{500 * time.Millisecond, 500 * time.Millisecond}; !reflect.DeepEqual(waits, want) {
+		t.Errorf("after the burst waited %v, want %v", waits, want)
+	}
+
+	clock.advance(time.Minute)
+	acquireN(t, l, 4)
+	if waits, want := clock.takeWaits(), []time.Duration{500 * time.Millisecond}; !reflect.DeepEqual(waits, want) {
+		t.Errorf("after a quiet period waited %v, want only the request past the burst to wait %v", waits, want)
+	}
+}
+
+func TestRateLimitUnlimitedRate(t *testing.T) {
+	l, clock := newTestLimiter(RateLimitOptions{})
+
+	acquireN(t, l, 100)
+	if waits := clock.takeWaits(); len(waits) != 0 {
+		t.Errorf("waited %v without a rate, want no wait", waits)
+	}
+}
+
+func TestRateLimitBackoff(t *testing.T) {
+	now := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		resp *http.Response
+		wait time.Duration
+	}{
+		{name: "429 with seconds", resp: throttled(http.StatusTooManyRequests, "3"), wait: 3 * time.Second},
+		{name: "503 with a date", resp: throttled(http.StatusServiceUnavailable, now.Add(7*time.Second).Format(http.TimeFormat)), wait: 7 * time.Second},
+		{name: "503 without Retry-After", resp: throttled(http.StatusServiceUnavailable, ""), wait: defaultThrottlePause},
+		{name: "429 with a past date", resp: throttled(http.StatusTooManyRequests, now.Add(-time.Hour).Format(http.TimeFormat)), wait: defaultThrottlePause},
+		{name: "429 with a long delay", resp: throttled(http.StatusTooManyRequests, "86400"), wait: maxThrottlePause},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, clock := newTestLimiter(RateLimitOptions{RequestsPerSecond: 10, Burst: 10})
+
+			l.observe(tt.resp)
+			if l.rate != 5 {
+				t.Errorf("rate = %v after %d, want it halved to 5", l.rate, tt.resp.StatusCode)
+			}
+
+			acquireN(t, l, 1)
+			if waits, want := clock.takeWaits(), []time.Duration{tt.wait}; !reflect.DeepEqual(waits, want) {
+				t.Errorf("waited %v, want the pause of %v", waits, want)
+			}
+
+			// The bucket refilled during the pause; once it is empty
+			// requests are spaced at the halved rate.
+			acquireN(t, l, 20)
+			waits := clock.takeWaits()
+			if len(waits) < 10 {
+				t.Errorf("20 requests waited %d times, want at least 10", len(waits))
+			}
+			for _, wait := range waits {
+				if wait != 200*time.Millisecond {
+					t.Errorf("waited %v, want 200ms at 5 requests per second", wait)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitMinRate(t *testing.T) {
+	l, _ := newTestLimiter(RateLimitOptions{RequestsPerSecond: 8, MinRequestsPerSecond: 2})
+
+	for i := 0; i < 5; i++ {
+		l.observe(throttled(http.StatusTooManyRequests, ""))
+	}
+	if l.rate != 2 {
+		t.Errorf("rate = %v, want it to stop at MinRequestsPerSecond", l.rate)
+	}
+}
+
+func TestRateLimitRecovery(t *testing.T) {
+	l, _ := newTestLimiter(RateLimitOptions{RequestsPerSecond: 20})
+
+	l.observe(throttled(http.StatusTooManyRequests, ""))
+	var rates []float64
+	for i := 0; i < 12; i++ {
+		l.observe(&http.Response{StatusCode: http.StatusOK})
+		rates = append(rates, l.rate)
+	}
+
+	want := []float64{11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 20, 20}
+	if !reflect.DeepEqual(rates, want) {
+		t.Errorf("rates = %v, want %v", rates, want)
+	}
+}
+
+func TestRateLimitCanceledWhileWaiting(t *testing.T) {
+	l, clock := newTestLimiter(RateLimitOptions{RequestsPerSecond: 1, MaxInFlight: 1})
+	clock.stopped = true
+
+	release, err := l.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	// Waiting for a token.
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := l.acquire(ctx)
+		done <- err
+	}()
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("acquire waiting for a token: %v, want context.Canceled", err)
+	}
+
+	// Waiting for an in-flight slot.
+	clock.advance(time.Minute)
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		_, err := l.acquire(ctx)
+		done <- err
+	}()
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("acquire waiting for a slot: %v, want context.Canceled", err)
+	}
+
+	release()
+	clock.advance(time.Minute)
+	if _, err := l.acquire(context.Background()); err != nil {
+		t.Errorf("acquire after release: %v", err)
+	}
+}
+
+func TestRateLimitMaxInFlight(t *testing.T) {
+	client, mux := setup(t)
+	var (
+		mu            sync.Mutex
+		current, peak int
+	)
+	mux.HandleFunc("/api/v2/labels/1/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		current++
+		if current > peak {
+			peak = current
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		current--
+		mu.Unlock()
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{"id": 1, "name": "prod"})
+	})
+	if err := WithRateLimit(RateLimitOptions{MaxInFlight: 2})(client); err != nil {
+		t.Fatalf("WithRateLimit: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.Label.Get(context.Background(), 1); err != nil {
+				t.Errorf("Get: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak > 2 {
+		t.Errorf("%d requests in flight at once, want at most 2", peak)
+	}
+	select {
+	case client.limiter.inFlight <- struct{}{}:
+		<-client.limiter.inFlight
+	default:
+		t.Errorf("in-flight slots not released once the responses were read")
+	}
+}
+
+func TestWithRateLimitArgErrors(t *testing.T) {
+	for _, opts := range []RateLimitOptions{
+		{RequestsPerSecond: -1},
+		{Burst: -1},
+		{MaxInFlight: -1},
+		{MinRequestsPerSecond: -1},
+	} {
+		var argErr *ArgError
+		if err := WithRateLimit(opts)(NewClient(nil)); !errors.As(err, &argErr) {
+			t.Errorf("WithRateLimit(%+v): %v, want an *ArgError", opts, err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package awx
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// RawService is an interface for reading and changing any object of the AWX
+// API as a map of its JSON fields, for fields the typed resources do not know
+// about yet. Paths are relative to the APIRoot, e.g. "job_templates/7/", or
+// absolute, such as the URL of a resource.
+type RawService interface {
+	Get(context.Context, string) (map[string]interface{}, *Response, error)
+	Patch(context.Context, string, map[string]interface{}) (map[string]interface{}, *Response, error)
+}
+
+// RawServiceOp handles communication with any object of the AWX API.
+type RawServiceOp struct {
+	client *Client
+}
+
+// Get the object at path.
+func (s *RawServiceOp) Get(ctx context.Context, path string) (map[string]interface{}, *Response, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, nil, NewArgError("path", "cannot be empty")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var fields map[string]interface{}
+	resp, err := s.client.Do(ctx, req, &fields)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return fields, resp, err
+}
+
+// Patch changes the given fields of the object at path, leaving the others
+// as they are, and returns the object as changed.
+func (s *RawServiceOp) Patch(ctx context.Context, path string, fields map[string]interface{}) (map[string]interface{}, *Response, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, nil, NewArgError("path", "cannot be empty")
+	}
+	if len(fields) == 0 {
+		return nil, nil, NewArgError("fields", "cannot be empty")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPatch, path, fields)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var changed map[string]interface{}
+	resp, err := s.client.Do(ctx, req, &changed)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return changed, resp, err
+}
@@ -0,0 +1,66 @@
+package awx
+
+import (
+	"context"
+	"net/http"
+)
+
+const serverConfigBasePath = "config/"
+
+// ServerConfigService is an interface for interfacing with the Config
+// endpoint of the AWX API
+// See: http://localhost/api/v2/config/
+type ServerConfigService interface {
+	Get(context.Context) (*ServerConfig, *Response, error)
+}
+
+// ServerConfigServiceOp handles communication with the Config related methods
+// of the AWX API.
+type ServerConfigServiceOp struct {
+	client *Client
+}
+
+// ServerConfig represents the configuration of a AWX server: its version,
+// license and the settings that apply to the requesting user.
+type ServerConfig struct {
+	TimeZone          string      `json:"time_zone"`
+	LicenseInfo       LicenseInfo `json:"license_info"`
+	Version           string      `json:"version"`
+	EULA              string      `json:"eula"`
+	AnalyticsStatus   string      `json:"analytics_status"`
+	BecomeMethods     [][]string  `json:"become_methods"`
+	ProjectBaseDir    string      `json:"project_base_dir"`
+	ProjectLocalPaths []string    `json:"project_local_paths"`
+}
+
+// LicenseInfo represents the license or subscription of a AWX server. AWX
+// itself reports a license type of "open".
+type LicenseInfo struct {
+	LicenseType      string `json:"license_type"`
+	Valid            bool   `json:"valid_key"`
+	SubscriptionName string `json:"subscription_name"`
+	Compliant        bool   `json:"compliant"`
+	InstanceCount    int    `json:"instance_count"`
+	CurrentInstances int    `json:"current_instances"`
+	FreeInstances    int    `json:"free_instances"`
+	DateExpired      bool   `json:"date_expired"`
+	TimeRemaining    int64  `json:"time_remaining"`
+}
+
+// Get the server configuration.
+func (s *ServerConfigServiceOp) Get(ctx context.Context) (*ServerConfig, *Response, error) {
+	path := serverConfigBasePath
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(ServerConfig)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
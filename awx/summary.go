@@ -0,0 +1,125 @@
+package awx
+
+import "time"
+
+// The summary types below are the short forms of related objects AWX embeds
+// in the summary_fields of a resource. A related object is only summarized
+// when it is set, so resources hold them as pointers that are nil when the
+// object is absent.
+
+// ObjectSummary is the short form of a related object such as an
+// Organization or a JobTemplate.
+type ObjectSummary struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// UserSummary is the short form of a user, such as the one who created or
+// last modified a resource.
+type UserSummary struct {
+	ID        int    `json:"id"`
+	Username  string `json:"username"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+// InventorySummary is the short form of an Inventory. Jobs and ad hoc
+// commands only summarize its ID, name and description.
+type InventorySummary struct {
+	ID                           int    `json:"id"`
+	Name                         string `json:"name"`
+	Description                  string `json:"description"`
+	HasActiveFailures            bool   `json:"has_active_failures"`
+	TotalHosts                   int    `json:"total_hosts"`
+	HostsWithActiveFailures      int    `json:"hosts_with_active_failures"`
+	TotalGroups                  int    `json:"total_groups"`
+	GroupsWithActiveFailures     int    `json:"groups_with_active_failures"`
+	HasInventorySources          bool   `json:"has_inventory_sources"`
+	TotalInventorySources        int    `json:"total_inventory_sources"`
+	InventorySourcesWithFailures int    `json:"inventory_sources_with_failures"`
+	OrganizationID               int    `json:"organization_id"`
+	Kind                         string `json:"kind"`
+	InsightsCredentialID         *int   `json:"insights_credential_id"`
+}
+
+// CredentialSummary is the short form of a credential.
+type CredentialSummary struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Kind        string `json:"kind"`
+	Cloud       bool   `json:"cloud"`
+}
+
+// ProjectSummary is the short form of a Project.
+type ProjectSummary struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	ScmType     string `json:"scm_type"`
+}
+
+// UnifiedJobTemplateSummary is the short form of the template a job was
+// launched from.
+type UnifiedJobTemplateSummary struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	UnifiedJobType string `json:"unified_job_type"`
+}
+
+// JobSummary is the short form of a job, such as the last job or update of
+// a Project.
+type JobSummary struct {
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Finished    *time.Time `json:"finished"`
+	Status      string     `json:"status"`
+	Failed      bool       `json:"failed"`
+}
+
+// RecentJobSummary is the short form of one of the last jobs of a
+// JobTemplate.
+type RecentJobSummary struct {
+	ID         int        `json:"id"`
+	Status     string     `json:"status"`
+	Finished   *time.Time `json:"finished"`
+	CanceledOn *time.Time `json:"canceled_on"`
+	Type       string     `json:"type"`
+}
+
+// InventorySourceSummary is the short form of an InventorySource.
+type InventorySourceSummary struct {
+	Source           string     `json:"source"`
+	LastUpdated      *time.Time `json:"last_updated"`
+	Status           string     `json:"status"`
+	LastUpdateFailed bool       `json:"last_update_failed"`
+}
+
+// ObjectRole is a role granting access to a resource, such as its admin or
+// execute role.
+type ObjectRole struct {
+	ID          int    `json:"id"`
+	Description string `json:"description"`
+	Name        string `json:"name"`
+}
+
+// LabelsSummary holds the labels of a template or job.
+type LabelsSummary struct {
+	Count   int            `json:"count"`
+	Results []LabelSummary `json:"results"`
+}
+
+// UserCapabilities reports what the requesting user may do with a resource.
+// Capabilities AWX does not report for a type of resource are false.
+type UserCapabilities struct {
+	Edit     bool `json:"edit"`
+	Delete   bool `json:"delete"`
+	Start    bool `json:"start"`
+	Copy     bool `json:"copy"`
+	Schedule bool `json:"schedule"`
+	Adhoc    bool `json:"adhoc"`
+}
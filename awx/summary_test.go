@@ -0,0 +1,251 @@
+package awx
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// The payloads below are trimmed responses of AWX 24, with the null fields
+// and missing summary fields of objects that never ran or lost a relation.
+
+const jobTemplatePayload = `{
+	"id": 7, "type": "job_template", "name": "deploy",
+	"summary_fields": {
+		"organization": {"id": 1, "name": "Default", "description": ""},
+		"inventory": {"id": 2, "name": "production", "description": "", "total_hosts": 12, "organization_id": 1, "kind": "", "insights_credential_id": null},
+		"created_by": {"id": 1, "username": "admin", "first_name": "", "last_name": ""},
+		"user_capabilities": {"edit": true, "delete": true, "start": true, "schedule": true, "copy": true},
+		"labels": {"count": 0, "results": []},
+		"recent_jobs": [{"id": 41, "status": "failed", "finished": "2024-06-03T10:01:12.5Z", "canceled_on": null, "type": "job"}],
+		"credentials": [{"id": 5, "name": "machine", "description": "", "kind": "ssh", "cloud": false}]
+	},
+	"inventory": 2, "project": null, "playbook": "site.yml",
+	"last_job_run": null, "next_job_run": null, "last_job_failed": false,
+	"execution_environment": null, "credential": null, "vault_credential": null
+}`
+
+const projectPayload = `{
+	"id": 3, "type": "project", "name": "playbooks",
+	"summary_fields": {
+		"created_by": {"id": 1, "username": "admin", "first_name": "", "last_name": ""},
+		"user_capabilities": {"edit": true, "delete": true, "start": true, "schedule": true, "copy": true}
+	},
+	"credential": null, "organization": null, "default_environment": null,
+	"last_job_run": null, "next_job_run": null, "last_updated": null,
+	"status": "never updated"
+}`
+
+const inventoryPayload = `{
+	"id": 2, "type": "inventory", "name": "production",
+	"summary_fields": {
+		"organization": {"id": 1, "name": "Default", "description": ""},
+		"user_capabilities": {"edit": true, "delete": true, "copy": true, "adhoc": true}
+	},
+	"organization": 1, "host_filter": null, "insights_credential": null,
+	"variables": ""
+}`
+
+const jobPayload = `{
+	"id": 42, "type": "job", "name": "deploy", "status": "running",
+	"summary_fields": {
+		"unified_job_template": {"id": 7, "name": "deploy", "description": "", "unified_job_type": "job"},
+		"job_template": {"id": 7, "name": "deploy", "description": ""},
+		"user_capabilities": {"delete": true, "start": true},
+		"labels": {"count": 0, "results": []}
+	},
+	"inventory": null, "project": null, "organization": null,
+	"execution_environment": null, "instance_group": 1,
+	"started": "2024-06-03T10:00:02.25Z", "finished": null, "canceled_on": null,
+	"launched_by": {"id": 1, "name": "admin", "type": "user", "url": "/api/v2/users/1/"}
+}`
+
+const adHocCommandPayload = `{
+	"id": 45, "type": "ad_hoc_command", "name": "ping", "status": "pending",
+	"summary_fields": {
+		"inventory": {"id": 2, "name": "production", "description": ""},
+		"user_capabilities": {"delete": true, "start": true}
+	},
+	"inventory": 2, "credential": null, "started": null, "finished": null,
+	"module_name": "ping", "module_args": "", "extra_vars": ""
+}`
+
+const activityStreamPayload = `{
+	"count": 2,
+	"results": [
+		{
+			"id": 100, "type": "activity_stream", "operation": "update",
+			"summary_fields": {
+				"actor": {"id": 1, "username": "admin", "first_name": "", "last_name": ""},
+				"job_template": [{"id": 7, "name": "deploy", "description": ""}]
+			},
+			"changes": {"limit": ["", "web"]},
+			"object1": "job_template", "object2": "",
+			"timestamp": "2024-06-03T10:05:00Z"
+		},
+		{
+			"id": 101, "type": "activity_stream", "operation": "create",
+			"summary_fields": {
+				"project_update": [{"id": 43, "name": "playbooks", "description": ""}],
+				"project": [{"id": 3, "name": "playbooks", "description": ""}]
+			},
+			"changes": {"status": "pending"},
+			"object1": "project_update", "object2": "",
+			"timestamp": "2024-06-03T10:06:00Z"
+		}
+	]
+}`
+
+func TestDecodeNullableFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		check func(t *testing.T)
+	}{
+		{
+			name: "JobTemplate",
+			check: func(t *testing.T) {
+				var jt JobTemplate
+				decodePayload(t, jobTemplatePayload, &jt)
+
+				if jt.LastJobRun != nil || jt.NextJobRun != nil {
+					t.Errorf("last_job_run %v, next_job_run %v, want nil", jt.LastJobRun, jt.NextJobRun)
+				}
+				if jt.ExecutionEnvironment != nil || jt.Credential != nil || jt.VaultCredential != nil || jt.Project != nil {
+					t.Errorf("execution_environment, credential, vault_credential or project set, want nil")
+				}
+				if jt.Inventory == nil || *jt.Inventory != 2 {
+					t.Errorf("inventory %v, want 2", jt.Inventory)
+				}
+				inventory := jt.SummaryFields.Inventory
+				if inventory == nil || inventory.TotalHosts != 12 || inventory.InsightsCredentialID != nil {
+					t.Errorf("summary inventory %+v, want 12 hosts and no insights credential", inventory)
+				}
+				if jt.SummaryFields.ModifiedBy != nil {
+					t.Errorf("summary modified_by %+v, want nil", jt.SummaryFields.ModifiedBy)
+				}
+				recent := jt.SummaryFields.RecentJobs
+				if len(recent) != 1 || recent[0].ID != 41 || recent[0].Finished == nil || recent[0].CanceledOn != nil {
+					t.Errorf("summary recent_jobs %+v, want job 41, finished and not canceled", recent)
+				}
+				credentials := jt.SummaryFields.Credentials
+				if len(credentials) != 1 || credentials[0].Kind != "ssh" {
+					t.Errorf("summary credentials %+v, want the ssh credential", credentials)
+				}
+			},
+		},
+		{
+			name: "Project",
+			check: func(t *testing.T) {
+				var p Project
+				decodePayload(t, projectPayload, &p)
+
+				if p.LastJobRun != nil || p.NextJobRun != nil || p.LastUpdated != nil {
+					t.Errorf("last_job_run, next_job_run or last_updated set, want nil")
+				}
+				if p.Credential != nil || p.Organization != nil || p.DefaultEnvironment != nil {
+					t.Errorf("credential, organization or default_environment set, want nil")
+				}
+				if p.SummaryFields.LastJob != nil || p.SummaryFields.LastUpdate != nil || p.SummaryFields.Organization != nil {
+					t.Errorf("summary last_job, last_update or organization set, want nil for a project that never ran")
+				}
+				if p.SummaryFields.CreatedBy == nil || p.SummaryFields.CreatedBy.Username != "admin" {
+					t.Errorf("summary created_by %+v, want admin", p.SummaryFields.CreatedBy)
+				}
+			},
+		},
+		{
+			name: "Inventory",
+			check: func(t *testing.T) {
+				var i Inventory
+				decodePayload(t, inventoryPayload, &i)
+
+				if i.InsightsCredential != nil || i.SummaryFields.InsightsCredential != nil {
+					t.Errorf("insights credential set, want nil")
+				}
+				if i.SummaryFields.Organization == nil || i.SummaryFields.Organization.Name != "Default" {
+					t.Errorf("summary organization %+v, want Default", i.SummaryFields.Organization)
+				}
+				if i.SummaryFields.CreatedBy != nil {
+					t.Errorf("summary created_by %+v, want nil", i.SummaryFields.CreatedBy)
+				}
+				if !i.SummaryFields.UserCapabilities.Adhoc {
+					t.Errorf("summary user_capabilities %+v, want adhoc", i.SummaryFields.UserCapabilities)
+				}
+			},
+		},
+		{
+			name: "Job",
+			check: func(t *testing.T) {
+				var j Job
+				decodePayload(t, jobPayload, &j)
+
+				if j.Finished != nil || j.CanceledOn != nil {
+					t.Errorf("finished %v, canceled_on %v, want nil for a running job", j.Finished, j.CanceledOn)
+				}
+				if want := time.Date(2024, 6, 3, 10, 0, 2, 250000000, time.UTC); j.Started == nil || !j.Started.Equal(want) {
+					t.Errorf("started %v, want %v", j.Started, want)
+				}
+				if j.Inventory != nil || j.Project != nil || j.Organization != nil || j.ExecutionEnvironment != nil {
+					t.Errorf("inventory, project, organization or execution_environment set, want nil")
+				}
+				if j.SummaryFields.Inventory != nil || j.SummaryFields.Project != nil || j.SummaryFields.Organization != nil || j.SummaryFields.CreatedBy != nil {
+					t.Errorf("summary inventory, project, organization or created_by set, want nil")
+				}
+				if j.SummaryFields.UnifiedJobTemplate == nil || j.SummaryFields.UnifiedJobTemplate.UnifiedJobType != "job" {
+					t.Errorf("summary unified_job_template %+v, want the job template", j.SummaryFields.UnifiedJobTemplate)
+				}
+			},
+		},
+		{
+			name: "AdHocCommand",
+			check: func(t *testing.T) {
+				var ahc AdHocCommand
+				decodePayload(t, adHocCommandPayload, &ahc)
+
+				if ahc.Started != nil || ahc.Finished != nil || ahc.Credential != nil {
+					t.Errorf("started, finished or credential set, want nil for a pending command")
+				}
+				if ahc.SummaryFields.Credential != nil || ahc.SummaryFields.CreatedBy != nil {
+					t.Errorf("summary credential or created_by set, want nil")
+				}
+				if ahc.SummaryFields.Inventory == nil || ahc.SummaryFields.Inventory.Name != "production" {
+					t.Errorf("summary inventory %+v, want production", ahc.SummaryFields.Inventory)
+				}
+			},
+		},
+		{
+			name: "ActivityStream",
+			check: func(t *testing.T) {
+				var page struct {
+					Results []ActivityStreamEntry `json:"results"`
+				}
+				decodePayload(t, activityStreamPayload, &page)
+				if len(page.Results) != 2 {
+					t.Fatalf("decoded %d entries, want 2", len(page.Results))
+				}
+
+				byUser, bySystem := page.Results[0], page.Results[1]
+				if byUser.SummaryFields.Actor == nil || byUser.SummaryFields.Actor.Username != "admin" {
+					t.Errorf("actor %+v, want admin", byUser.SummaryFields.Actor)
+				}
+				if bySystem.SummaryFields.Actor != nil {
+					t.Errorf("actor %+v, want nil for a change made by AWX", bySystem.SummaryFields.Actor)
+				}
+				if objects := bySystem.SummaryFields.Objects["project"]; len(objects) != 1 || objects[0].ID != 3 {
+					t.Errorf("summary project %+v, want project 3", objects)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.check)
+	}
+}
+
+func decodePayload(t *testing.T, payload string, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal([]byte(payload), v); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+}
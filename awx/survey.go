@@ -0,0 +1,312 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Survey question types supported by AWX.
+const (
+	SurveyText           = "text"
+	SurveyTextarea       = "textarea"
+	SurveyPassword       = "password"
+	SurveyInteger        = "integer"
+	SurveyFloat          = "float"
+	SurveyMultipleChoice = "multiplechoice"
+	SurveyMultiSelect    = "multiselect"
+)
+
+// SurveySpec represents the survey of a JobTemplate or WorkflowJobTemplate.
+type SurveySpec struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Spec        []SurveyQuestion `json:"spec"`
+}
+
+// SurveyQuestion represents a single question of a SurveySpec. Min and Max
+// bound the value of integer and float questions and the length of text,
+// textarea and password answers. An empty Default, as AWX stores for
+// questions without one, is no default.
+type SurveyQuestion struct {
+	QuestionName        string        `json:"question_name"`
+	QuestionDescription string        `json:"question_description"`
+	Variable            string        `json:"variable"`
+	Type                string        `json:"type"`
+	Required            bool          `json:"required"`
+	Min                 *float64      `json:"min,omitempty"`
+	Max                 *float64      `json:"max,omitempty"`
+	Default             interface{}   `json:"default,omitempty"`
+	Choices             SurveyChoices `json:"choices,omitempty"`
+}
+
+// UnmarshalJSON decodes a SurveyQuestion, tolerating the empty strings older
+// AWX versions store for unset min and max values.
+func (q *SurveyQuestion) UnmarshalJSON(data []byte) error {
+	type question SurveyQuestion
+	aux := struct {
+		*question
+		Min json.RawMessage `json:"min"`
+		Max json.RawMessage `json:"max"`
+	}{question: (*question)(q)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if q.Min, err = decodeSurveyBound(aux.Min); err != nil {
+		return fmt.Errorf("survey question %q min: %w", q.Variable, err)
+	}
+	if q.Max, err = decodeSurveyBound(aux.Max); err != nil {
+		return fmt.Errorf("survey question %q max: %w", q.Variable, err)
+	}
+
+	return nil
+}
+
+func decodeSurveyBound(raw json.RawMessage) (*float64, error) {
+	s := strings.Trim(string(raw), `" `)
+	if s == "" || s == "null" {
+		return nil, nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+// hasDefault reports whether the question has a default answer.
+func (q SurveyQuestion) hasDefault() bool {
+	return q.Default != nil && q.Default != ""
+}
+
+// SurveyChoices are the options of a multiplechoice or multiselect question.
+// AWX returns them either as a list or as a newline separated string; both
+// decode to a list.
+type SurveyChoices []string
+
+// UnmarshalJSON decodes SurveyChoices from a list or a newline separated string.
+func (c *SurveyChoices) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*c = list
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	*c = nil
+	for _, choice := range strings.Split(s, "\n") {
+		if choice = strings.TrimSpace(choice); choice != "" {
+			*c = append(*c, choice)
+		}
+	}
+
+	return nil
+}
+
+// SurveyFieldError describes why the answer to one SurveyQuestion is invalid.
+type SurveyFieldError struct {
+	Variable string
+	Message  string
+}
+
+// SurveyValidationError is returned by SurveySpec.Validate when one or more
+// answers would be rejected by AWX.
+type SurveyValidationError struct {
+	Errors []SurveyFieldError
+}
+
+var _ error = &SurveyValidationError{}
+
+func (e *SurveyValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Message
+	}
+	return "survey validation failed: " + strings.Join(msgs, "; ")
+}
+
+// Validate checks the extra_vars proposed for a launch against the survey,
+// applying the same rules AWX does server side. It returns a
+// *SurveyValidationError listing every invalid answer.
+func (s *SurveySpec) Validate(vars map[string]interface{}) error {
+	verr := &SurveyValidationError{}
+
+	for _, q := range s.Spec {
+		value, ok := vars[q.Variable]
+		if !ok || value == nil {
+			if q.Required && !q.hasDefault() {
+				verr.add(q, "'%s' value missing", q.Variable)
+			}
+			continue
+		}
+
+		switch q.Type {
+		case SurveyText, SurveyTextarea, SurveyPassword:
+			str, ok := value.(string)
+			if !ok {
+				verr.add(q, "Value %v for '%s' expected to be a string.", value, q.Variable)
+				continue
+			}
+			if q.Type == SurveyPassword && str == Encrypted {
+				// AWX replaces the placeholder with the default, so it
+				// is only a valid answer if there is one.
+				if q.Required && !q.hasDefault() {
+					verr.add(q, "'%s' value missing", q.Variable)
+				}
+				continue
+			}
+			length := utf8.RuneCountInString(str)
+			if q.Min != nil && float64(length) < *q.Min {
+				verr.add(q, "'%s' value %q is too small (length is %d must be at least %v).", q.Variable, str, length, *q.Min)
+			}
+			if q.Max != nil && float64(length) > *q.Max {
+				verr.add(q, "'%s' value %q is too large (must be no more than %v).", q.Variable, str, *q.Max)
+			}
+		case SurveyInteger, SurveyFloat:
+			n, ok := surveyNumber(value)
+			if !ok {
+				verr.add(q, "Value %v for '%s' expected to be a numeric type.", value, q.Variable)
+				continue
+			}
+			if q.Type == SurveyInteger && n != math.Trunc(n) {
+				verr.add(q, "Value %v for '%s' expected to be an integer.", value, q.Variable)
+				continue
+			}
+			if q.Min != nil && n < *q.Min {
+				verr.add(q, "'%s' value %v is too small (must be at least %v).", q.Variable, value, *q.Min)
+			}
+			if q.Max != nil && n > *q.Max {
+				verr.add(q, "'%s' value %v is too large (must be no more than %v).", q.Variable, value, *q.Max)
+			}
+		case SurveyMultipleChoice:
+			str, ok := value.(string)
+			if !ok || !q.Choices.contains(str) {
+				verr.add(q, "Value %v for '%s' expected to be one of %v.", value, q.Variable, []string(q.Choices))
+			}
+		case SurveyMultiSelect:
+			selected, ok := surveyStrings(value)
+			if !ok {
+				verr.add(q, "'%s' value is expected to be a list.", q.Variable)
+				continue
+			}
+			for _, str := range selected {
+				if !q.Choices.contains(str) {
+					verr.add(q, "Value %s for '%s' expected to be one of %v.", str, q.Variable, []string(q.Choices))
+				}
+			}
+		}
+	}
+
+	if len(verr.Errors) > 0 {
+		return verr
+	}
+
+	return nil
+}
+
+func (e *SurveyValidationError) add(q SurveyQuestion, format string, args ...interface{}) {
+	e.Errors = append(e.Errors, SurveyFieldError{Variable: q.Variable, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c SurveyChoices) contains(choice string) bool {
+	for _, option := range c {
+		if option == choice {
+			return true
+		}
+	}
+	return false
+}
+
+func surveyNumber(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func surveyStrings(value interface{}) ([]string, bool) {
+	switch v := value.(type) {
+	case []string:
+		return v, true
+	case []interface{}:
+		strs := make([]string, len(v))
+		for i, item := range v {
+			str, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			strs[i] = str
+		}
+		return strs, true
+	}
+	return nil, false
+}
+
+// getSurveySpec fetches the survey_spec endpoint of a template. AWX returns an
+// empty object when no survey has been defined.
+func getSurveySpec(ctx context.Context, client *Client, path string) (*SurveySpec, *Response, error) {
+	req, err := client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(SurveySpec)
+	resp, err := client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+func setSurveySpec(ctx context.Context, client *Client, path string, spec *SurveySpec) (*Response, error) {
+	if spec == nil {
+		return nil, NewArgError("spec", "cannot be nil")
+	}
+
+	req, err := client.NewRequest(ctx, http.MethodPost, path, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(ctx, req, nil)
+
+	return resp, err
+}
+
+func deleteSurveySpec(ctx context.Context, client *Client, path string) (*Response, error) {
+	req, err := client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(ctx, req, nil)
+
+	return resp, err
+}
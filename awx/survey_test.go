@@ -0,0 +1,112 @@
+package awx
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSurveyQuestionUnmarshalBounds(t *testing.T) {
+	tests := []struct {
+		data     string
+		min, max *float64
+	}{
+		{data: `{"min": 0.5, "max": 2.25}`, min: float64Ptr(0.5), max: float64Ptr(2.25)},
+		{data: `{"min": "1", "max": "10"}`, min: float64Ptr(1), max: float64Ptr(10)},
+		{data: `{"min": "", "max": null}`},
+		{data: `{}`},
+	}
+
+	for _, tt := range tests {
+		var q SurveyQuestion
+		if err := json.Unmarshal([]byte(tt.data), &q); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", tt.data, err)
+		}
+		if !equalFloat64Ptr(q.Min, tt.min) || !equalFloat64Ptr(q.Max, tt.max) {
+			t.Errorf("Unmarshal(%s): min %v, max %v, want %v, %v", tt.data, fmtFloat64Ptr(q.Min), fmtFloat64Ptr(q.Max), fmtFloat64Ptr(tt.min), fmtFloat64Ptr(tt.max))
+		}
+	}
+}
+
+func TestSurveySpecValidate(t *testing.T) {
+	spec := &SurveySpec{Spec: []SurveyQuestion{
+		{Variable: "name", Type: SurveyText, Min: float64Ptr(2), Max: float64Ptr(4)},
+		{Variable: "ratio", Type: SurveyFloat, Min: float64Ptr(0.5), Max: float64Ptr(1.5)},
+		{Variable: "region", Type: SurveyText, Required: true, Default: ""},
+		{Variable: "zone", Type: SurveyText, Required: true, Default: "a"},
+		{Variable: "token", Type: SurveyPassword, Required: true, Default: ""},
+		{Variable: "secret", Type: SurveyPassword, Required: true, Default: "stored"},
+	}}
+
+	tests := []struct {
+		name    string
+		vars    map[string]interface{}
+		invalid []string
+	}{
+		{
+			name: "valid",
+			vars: map[string]interface{}{"name": "äöü", "ratio": 0.5, "region": "eu", "token": "t", "secret": Encrypted},
+		},
+		{
+			name:    "length counts characters",
+			vars:    map[string]interface{}{"name": "äöüßé", "region": "eu", "token": "t"},
+			invalid: []string{"name"},
+		},
+		{
+			name:    "float bounds",
+			vars:    map[string]interface{}{"ratio": 0.25, "region": "eu", "token": "t"},
+			invalid: []string{"ratio"},
+		},
+		{
+			name:    "empty default is no default",
+			vars:    map[string]interface{}{"token": "t"},
+			invalid: []string{"region"},
+		},
+		{
+			name:    "encrypted without default",
+			vars:    map[string]interface{}{"region": "eu", "token": Encrypted},
+			invalid: []string{"token"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := spec.Validate(tt.vars)
+			var invalid []string
+			if err != nil {
+				verr, ok := err.(*SurveyValidationError)
+				if !ok {
+					t.Fatalf("Validate: %v, want a *SurveyValidationError", err)
+				}
+				for _, fe := range verr.Errors {
+					invalid = append(invalid, fe.Variable)
+				}
+			}
+			if len(invalid) != len(tt.invalid) {
+				t.Fatalf("Validate: invalid %v, want %v (%v)", invalid, tt.invalid, err)
+			}
+			for i := range invalid {
+				if invalid[i] != tt.invalid[i] {
+					t.Errorf("Validate: invalid %v, want %v", invalid, tt.invalid)
+				}
+			}
+		})
+	}
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func equalFloat64Ptr(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func fmtFloat64Ptr(f *float64) interface{} {
+	if f == nil {
+		return nil
+	}
+	return *f
+}
@@ -0,0 +1,162 @@
+package awx
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// WithCABundle is a client option that trusts the PEM encoded certificates
+// in the given files, in addition to the system roots, when verifying the
+// AWX TLS certificate.
+func WithCABundle(paths ...string) ClientOpt {
+	return func(c *Client) error {
+		if len(paths) == 0 {
+			return NewArgError("paths", "cannot be empty")
+		}
+
+		tlsConfig, err := c.tlsConfig()
+		if err != nil {
+			return err
+		}
+		if tlsConfig.RootCAs == nil {
+			if tlsConfig.RootCAs, err = x509.SystemCertPool(); err != nil {
+				tlsConfig.RootCAs = x509.NewCertPool()
+			}
+		}
+
+		for _, path := range paths {
+			pem, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+				return fmt.Errorf("awx: no certificates found in CA bundle %s", path)
+			}
+		}
+
+		return nil
+	}
+}
+
+// WithClientCertificate is a client option that presents the PEM encoded
+// certificate and private key in the given files for mutual TLS. It may be
+// repeated to offer several certificates.
+func WithClientCertificate(certFile, keyFile string) ClientOpt {
+	return func(c *Client) error {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return err
+		}
+
+		tlsConfig, err := c.tlsConfig()
+		if err != nil {
+			return err
+		}
+		tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
+
+		return nil
+	}
+}
+
+// WithMinTLSVersion is a client option that sets the minimum TLS version
+// accepted, e.g. tls.VersionTLS12.
+func WithMinTLSVersion(version uint16) ClientOpt {
+	return func(c *Client) error {
+		if version < tls.VersionTLS10 || version > tls.VersionTLS13 {
+			return NewArgError("version", "is not a supported TLS version")
+		}
+
+		tlsConfig, err := c.tlsConfig()
+		if err != nil {
+			return err
+		}
+		tlsConfig.MinVersion = version
+
+		return nil
+	}
+}
+
+// WithInsecureSkipVerify is a client option that, when insecure is true,
+// accepts any certificate presented by AWX. Use it only for testing.
+func WithInsecureSkipVerify(insecure bool) ClientOpt {
+	return func(c *Client) error {
+		tlsConfig, err := c.tlsConfig()
+		if err != nil {
+			return err
+		}
+		tlsConfig.InsecureSkipVerify = insecure
+
+		return nil
+	}
+}
+
+// WithProxy is a client option that sends every request through the proxy
+// at proxyURL, e.g. "http://proxy.example.com:3128". An empty proxyURL
+// disables proxying, including proxies set in the environment.
+func WithProxy(proxyURL string) ClientOpt {
+	return func(c *Client) error {
+		transport, err := c.transport()
+		if err != nil {
+			return err
+		}
+
+		if proxyURL == "" {
+			transport.Proxy = nil
+			return nil
+		}
+
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return err
+		}
+		if u.Host == "" {
+			return NewArgError("proxyURL", "must be an absolute URL")
+		}
+		transport.Proxy = http.ProxyURL(u)
+
+		return nil
+	}
+}
+
+// transport returns the *http.Transport of the Client for TLS and proxy
+// options to modify. The first call replaces the HTTP client given to New
+// with a copy using a clone of its transport, so the caller's client is
+// never changed.
+func (c *Client) transport() (*http.Transport, error) {
+	if c.ownTransport != nil {
+		return c.ownTransport, nil
+	}
+
+	var transport *http.Transport
+	switch t := c.client.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		return nil, fmt.Errorf("awx: cannot configure transport of type %T", t)
+	}
+
+	client := *c.client
+	client.Transport = transport
+	c.client = &client
+	c.ownTransport = transport
+
+	return transport, nil
+}
+
+// tlsConfig returns the TLS configuration of the Client transport.
+func (c *Client) tlsConfig() (*tls.Config, error) {
+	transport, err := c.transport()
+	if err != nil {
+		return nil, err
+	}
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	return transport.TLSClientConfig, nil
+}
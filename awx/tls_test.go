@@ -0,0 +1,253 @@
+package awx
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCert is a certificate and its key, signed by its parent or by itself.
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newTestCert(t *testing.T, template *x509.Certificate, parent *testCert) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+// writePEM writes the certificate, and the key unless keyFile is empty, as
+// PEM files in dir.
+func (c *testCert) writePEM(t *testing.T, dir, certFile, keyFile string) {
+	t.Helper()
+
+	write := func(name, blockType string, der []byte) {
+		data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatalf("writing %s: %v", name, err)
+		}
+	}
+
+	write(certFile, "CERTIFICATE", c.der)
+	if keyFile != "" {
+		der, err := x509.MarshalECPrivateKey(c.key)
+		if err != nil {
+			t.Fatalf("encoding key: %v", err)
+		}
+		write(keyFile, "EC PRIVATE KEY", der)
+	}
+}
+
+// mutualTLSServer starts a TLS server with a certificate signed by a new CA
+// that requires a client certificate signed by the same CA. It writes ca.pem,
+// client.pem and client-key.pem to the returned directory.
+func mutualTLSServer(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+
+	ca := newTestCert(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "awx test CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+	server := newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "awx.example.com"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca)
+	client := newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "awx-client"},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca)
+
+	dir := t.TempDir()
+	ca.writePEM(t, dir, "ca.pem", "")
+	client.writePEM(t, dir, "client.pem", "client-key.pem")
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{"id": 1, "name": r.TLS.PeerCertificates[0].Subject.CommonName})
+	}))
+	srv.TLS = &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{server.der}, PrivateKey: server.key}},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	return srv, dir
+}
+
+func TestMutualTLS(t *testing.T) {
+	srv, dir := mutualTLSServer(t)
+	ca := filepath.Join(dir, "ca.pem")
+	cert, key := filepath.Join(dir, "client.pem"), filepath.Join(dir, "client-key.pem")
+
+	tests := []struct {
+		name string
+		opts []ClientOpt
+		ok   bool
+	}{
+		{name: "CA bundle and client certificate", opts: []ClientOpt{WithCABundle(ca), WithClientCertificate(cert, key), WithMinTLSVersion(tls.VersionTLS12)}, ok: true},
+		{name: "insecure with client certificate", opts: []ClientOpt{WithInsecureSkipVerify(true), WithClientCertificate(cert, key)}, ok: true},
+		{name: "without CA bundle", opts: []ClientOpt{WithClientCertificate(cert, key)}},
+		{name: "without client certificate", opts: []ClientOpt{WithCABundle(ca)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &http.Transport{}
+			httpClient := &http.Client{Transport: transport}
+
+			client, err := New(httpClient, tt.opts...)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+			org, _, err := client.Organization.Get(context.Background(), 1)
+			if tt.ok {
+				if err != nil {
+					t.Fatalf("Get: %v", err)
+				}
+				if org.Name != "awx-client" {
+					t.Errorf("server saw client certificate %q, want awx-client", org.Name)
+				}
+			} else if err == nil {
+				t.Errorf("Get succeeded, want a TLS error")
+			}
+
+			if httpClient.Transport != transport {
+				t.Errorf("caller's client transport replaced")
+			}
+			// net/http may fill in the HTTP/2 settings of the caller's
+			// transport on first use, but none of the options may show.
+			if c := transport.TLSClientConfig; c != nil && (c.RootCAs != nil || len(c.Certificates) > 0 || c.InsecureSkipVerify || c.MinVersion != 0) {
+				t.Errorf("caller's TLS config modified: %+v", c)
+			}
+			if transport.Proxy != nil {
+				t.Errorf("caller's transport given a proxy")
+			}
+		})
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	var proxied *http.Request
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{"id": 1, "name": "Default"})
+	}))
+	t.Cleanup(proxy.Close)
+
+	transport := &http.Transport{}
+	client, err := New(&http.Client{Transport: transport}, WithProxy(proxy.URL))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	client.BaseURL, _ = url.Parse("http://awx.invalid/")
+
+	if _, _, err := client.Organization.Get(context.Background(), 1); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if proxied == nil || proxied.URL.Host != "awx.invalid" || proxied.URL.Path != "/api/v2/organizations/1/" {
+		t.Errorf("proxy received %v, want the request for awx.invalid", proxied.URL)
+	}
+	if transport.Proxy != nil {
+		t.Errorf("caller's transport given a proxy")
+	}
+
+	if err := WithProxy("")(client); err != nil {
+		t.Fatalf("WithProxy(\"\"): %v", err)
+	}
+	if client.ownTransport.Proxy != nil {
+		t.Errorf("WithProxy(\"\") left a proxy set")
+	}
+}
+
+func TestTLSOptionErrors(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty.pem")
+	if err := os.WriteFile(empty, []byte("no certificates\n"), 0o600); err != nil {
+		t.Fatalf("writing %s: %v", empty, err)
+	}
+
+	tests := []struct {
+		name   string
+		opt    ClientOpt
+		argErr bool
+	}{
+		{name: "no CA bundle", opt: WithCABundle(), argErr: true},
+		{name: "empty CA bundle", opt: WithCABundle(empty)},
+		{name: "missing CA bundle", opt: WithCABundle(filepath.Join(dir, "missing.pem"))},
+		{name: "missing client certificate", opt: WithClientCertificate(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"))},
+		{name: "TLS version", opt: WithMinTLSVersion(0x0200), argErr: true},
+		{name: "relative proxy", opt: WithProxy("proxy.example.com"), argErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(nil, tt.opt)
+			var argErr *ArgError
+			if err == nil || errors.As(err, &argErr) != tt.argErr {
+				t.Errorf("New: %v, want an error (ArgError %v)", err, tt.argErr)
+			}
+		})
+	}
+}
+
+// roundTripperFunc is an http.RoundTripper that is not an *http.Transport.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTLSOptionsNeedTransport(t *testing.T) {
+	httpClient := &http.Client{Transport: roundTripperFunc(http.DefaultTransport.RoundTrip)}
+	if _, err := New(httpClient, WithInsecureSkipVerify(true)); err == nil {
+		t.Errorf("New with a custom RoundTripper succeeded, want an error")
+	}
+}
@@ -0,0 +1,461 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const unifiedJobBasePath = "unified_jobs/"
+
+// UnifiedJobService is an interface for interfacing with the UnifiedJob
+// endpoints of the AWX API
+// See: http://localhost/api/v2/unified_jobs/
+type UnifiedJobService interface {
+	List(context.Context, *UnifiedJobListOptions) ([]UnifiedJob, *Response, error)
+}
+
+// UnifiedJobServiceOp handles communication with the UnifiedJob related methods of the
+// AWX API.
+type UnifiedJobServiceOp struct {
+	client *Client
+}
+
+// UnifiedJob is any kind of job AWX runs. Values are one of *Job,
+// *ProjectUpdate, *InventoryUpdate, *WorkflowJob, *AdHocCommand, *SystemJob
+// or, for kinds this client does not know about, *UnknownUnifiedJob, and can
+// be told apart with a type switch.
+type UnifiedJob interface {
+	unifiedJob()
+}
+
+func (*Job) unifiedJob()               {}
+func (*ProjectUpdate) unifiedJob()     {}
+func (*InventoryUpdate) unifiedJob()   {}
+func (*WorkflowJob) unifiedJob()       {}
+func (*AdHocCommand) unifiedJob()      {}
+func (*SystemJob) unifiedJob()         {}
+func (*UnknownUnifiedJob) unifiedJob() {}
+
+// UnifiedJobListOptions filters the jobs returned by UnifiedJobService.List.
+// Zero values are ignored.
+type UnifiedJobListOptions struct {
+	// Status matches jobs in any of the given states, e.g. "running" or "failed".
+	Status []string
+	// LaunchType matches how the job was started, e.g. "manual" or "scheduled".
+	LaunchType string
+	// CreatedAfter and CreatedBefore bound the time the job was created.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// CreatedBy matches the username of the user who launched the job.
+	CreatedBy string
+	// OrderBy sorts the result by a field, prefixed with "-" to reverse it.
+	OrderBy string
+}
+
+func (o *UnifiedJobListOptions) values() url.Values {
+	v := url.Values{"page_size": {"200"}}
+	if o == nil {
+		return v
+	}
+
+	if len(o.Status) > 0 {
+		v.Set("status__in", strings.Join(o.Status, ","))
+	}
+	if o.LaunchType != "" {
+		v.Set("launch_type", o.LaunchType)
+	}
+	if !o.CreatedAfter.IsZero() {
+		v.Set("created__gte", o.CreatedAfter.UTC().Format(time.RFC3339Nano))
+	}
+	if !o.CreatedBefore.IsZero() {
+		v.Set("created__lt", o.CreatedBefore.UTC().Format(time.RFC3339Nano))
+	}
+	if o.CreatedBy != "" {
+		v.Set("created_by__username", o.CreatedBy)
+	}
+	if o.OrderBy != "" {
+		v.Set("order_by", o.OrderBy)
+	}
+
+	return v
+}
+
+// Job represents a AWX Job, a run of a JobTemplate.
+type Job struct {
+	ID      int    `json:"id"`
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+	Related struct {
+		CreatedBy          string `json:"created_by"`
+		Labels             string `json:"labels"`
+		Inventory          string `json:"inventory"`
+		Project            string `json:"project"`
+		Credentials        string `json:"credentials"`
+		UnifiedJobTemplate string `json:"unified_job_template"`
+		Stdout             string `json:"stdout"`
+		JobEvents          string `json:"job_events"`
+		JobHostSummaries   string `json:"job_host_summaries"`
+		ActivityStream     string `json:"activity_stream"`
+		Notifications      string `json:"notifications"`
+		JobTemplate        string `json:"job_template"`
+		Cancel             string `json:"cancel"`
+		Relaunch           string `json:"relaunch"`
+	} `json:"related"`
+	SummaryFields struct {
+		Organization       *ObjectSummary             `json:"organization"`
+		Inventory          *InventorySummary          `json:"inventory"`
+		Project            *ProjectSummary            `json:"project"`
+		JobTemplate        *ObjectSummary             `json:"job_template"`
+		UnifiedJobTemplate *UnifiedJobTemplateSummary `json:"unified_job_template"`
+		CreatedBy          *UserSummary               `json:"created_by"`
+		UserCapabilities   UserCapabilities           `json:"user_capabilities"`
+		Labels             LabelsSummary              `json:"labels"`
+	} `json:"summary_fields"`
+	Created              time.Time  `json:"created"`
+	Modified             time.Time  `json:"modified"`
+	Name                 string     `json:"name"`
+	Description          string     `json:"description"`
+	JobType              string     `json:"job_type"`
+	Inventory            *int       `json:"inventory"`
+	Project              *int       `json:"project"`
+	Playbook             string     `json:"playbook"`
+	ScmBranch            string     `json:"scm_branch"`
+	Forks                int        `json:"forks"`
+	Limit                string     `json:"limit"`
+	Verbosity            int        `json:"verbosity"`
+	ExtraVars            Vars       `json:"extra_vars"`
+	JobTags              string     `json:"job_tags"`
+	ForceHandlers        bool       `json:"force_handlers"`
+	SkipTags             string     `json:"skip_tags"`
+	StartAtTask          string     `json:"start_at_task"`
+	Timeout              int        `json:"timeout"`
+	UseFactCache         bool       `json:"use_fact_cache"`
+	Organization         *int       `json:"organization"`
+	UnifiedJobTemplate   *int       `json:"unified_job_template"`
+	LaunchType           string     `json:"launch_type"`
+	Status               string     `json:"status"`
+	ExecutionEnvironment *int       `json:"execution_environment"`
+	Failed               bool       `json:"failed"`
+	Started              *time.Time `json:"started"`
+	Finished             *time.Time `json:"finished"`
+	CanceledOn           *time.Time `json:"canceled_on"`
+	Elapsed              float64    `json:"elapsed"`
+	JobExplanation       string     `json:"job_explanation"`
+	ExecutionNode        string     `json:"execution_node"`
+	ControllerNode       string     `json:"controller_node"`
+	LaunchedBy           LaunchedBy `json:"launched_by"`
+	JobTemplate          *int       `json:"job_template"`
+	AllowSimultaneous    bool       `json:"allow_simultaneous"`
+	ScmRevision          string     `json:"scm_revision"`
+	InstanceGroup        *int       `json:"instance_group"`
+	DiffMode             bool       `json:"diff_mode"`
+	JobSliceNumber       int        `json:"job_slice_number"`
+	JobSliceCount        int        `json:"job_slice_count"`
+	// Extra holds the fields returned by AWX that have no field above,
+	// such as fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a Job, keeping unknown fields in Extra.
+func (j *Job) UnmarshalJSON(data []byte) error {
+	type job Job
+	extra, err := unmarshalWithExtra(data, (*job)(j))
+	j.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a Job together with the fields in Extra.
+func (j Job) MarshalJSON() ([]byte, error) {
+	type job Job
+	return marshalWithExtra(job(j), j.Extra)
+}
+
+// LaunchedBy identifies what started a job: a user, a schedule or a parent
+// workflow.
+type LaunchedBy struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// ProjectUpdate represents a AWX ProjectUpdate, the job created when a
+// Project is synced from source control.
+type ProjectUpdate struct {
+	ID      int    `json:"id"`
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+	Related struct {
+		CreatedBy           string `json:"created_by"`
+		UnifiedJobTemplate  string `json:"unified_job_template"`
+		Stdout              string `json:"stdout"`
+		Project             string `json:"project"`
+		Cancel              string `json:"cancel"`
+		Events              string `json:"events"`
+		Notifications       string `json:"notifications"`
+		ScmInventoryUpdates string `json:"scm_inventory_updates"`
+	} `json:"related"`
+	SummaryFields struct {
+		Organization     *ObjectSummary   `json:"organization"`
+		Project          *ProjectSummary  `json:"project"`
+		CreatedBy        *UserSummary     `json:"created_by"`
+		UserCapabilities UserCapabilities `json:"user_capabilities"`
+	} `json:"summary_fields"`
+	Created            time.Time  `json:"created"`
+	Modified           time.Time  `json:"modified"`
+	Name               string     `json:"name"`
+	Description        string     `json:"description"`
+	LocalPath          string     `json:"local_path"`
+	ScmType            string     `json:"scm_type"`
+	ScmURL             string     `json:"scm_url"`
+	ScmBranch          string     `json:"scm_branch"`
+	ScmRefspec         string     `json:"scm_refspec"`
+	ScmClean           bool       `json:"scm_clean"`
+	ScmDeleteOnUpdate  bool       `json:"scm_delete_on_update"`
+	Credential         *int       `json:"credential"`
+	Timeout            int        `json:"timeout"`
+	ScmRevision        string     `json:"scm_revision"`
+	UnifiedJobTemplate *int       `json:"unified_job_template"`
+	LaunchType         string     `json:"launch_type"`
+	Status             string     `json:"status"`
+	Failed             bool       `json:"failed"`
+	Started            *time.Time `json:"started"`
+	Finished           *time.Time `json:"finished"`
+	CanceledOn         *time.Time `json:"canceled_on"`
+	Elapsed            float64    `json:"elapsed"`
+	JobExplanation     string     `json:"job_explanation"`
+	ExecutionNode      string     `json:"execution_node"`
+	LaunchedBy         LaunchedBy `json:"launched_by"`
+	Project            *int       `json:"project"`
+	JobType            string     `json:"job_type"`
+	JobTags            string     `json:"job_tags"`
+	// Extra holds the fields returned by AWX that have no field above,
+	// such as fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a ProjectUpdate, keeping unknown fields in Extra.
+func (pu *ProjectUpdate) UnmarshalJSON(data []byte) error {
+	type projectUpdate ProjectUpdate
+	extra, err := unmarshalWithExtra(data, (*projectUpdate)(pu))
+	pu.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a ProjectUpdate together with the fields in Extra.
+func (pu ProjectUpdate) MarshalJSON() ([]byte, error) {
+	type projectUpdate ProjectUpdate
+	return marshalWithExtra(projectUpdate(pu), pu.Extra)
+}
+
+// WorkflowJob represents a AWX WorkflowJob, a run of a WorkflowJobTemplate.
+type WorkflowJob struct {
+	ID      int    `json:"id"`
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+	Related struct {
+		CreatedBy           string `json:"created_by"`
+		UnifiedJobTemplate  string `json:"unified_job_template"`
+		WorkflowJobTemplate string `json:"workflow_job_template"`
+		Notifications       string `json:"notifications"`
+		WorkflowNodes       string `json:"workflow_nodes"`
+		Labels              string `json:"labels"`
+		ActivityStream      string `json:"activity_stream"`
+		Relaunch            string `json:"relaunch"`
+		Cancel              string `json:"cancel"`
+	} `json:"related"`
+	SummaryFields struct {
+		Organization        *ObjectSummary   `json:"organization"`
+		WorkflowJobTemplate *ObjectSummary   `json:"workflow_job_template"`
+		CreatedBy           *UserSummary     `json:"created_by"`
+		UserCapabilities    UserCapabilities `json:"user_capabilities"`
+		Labels              LabelsSummary    `json:"labels"`
+	} `json:"summary_fields"`
+	Created             time.Time  `json:"created"`
+	Modified            time.Time  `json:"modified"`
+	Name                string     `json:"name"`
+	Description         string     `json:"description"`
+	UnifiedJobTemplate  *int       `json:"unified_job_template"`
+	LaunchType          string     `json:"launch_type"`
+	Status              string     `json:"status"`
+	Failed              bool       `json:"failed"`
+	Started             *time.Time `json:"started"`
+	Finished            *time.Time `json:"finished"`
+	CanceledOn          *time.Time `json:"canceled_on"`
+	Elapsed             float64    `json:"elapsed"`
+	JobExplanation      string     `json:"job_explanation"`
+	LaunchedBy          LaunchedBy `json:"launched_by"`
+	WorkflowJobTemplate *int       `json:"workflow_job_template"`
+	ExtraVars           Vars       `json:"extra_vars"`
+	AllowSimultaneous   bool       `json:"allow_simultaneous"`
+	IsSlicedJob         bool       `json:"is_sliced_job"`
+	Inventory           *int       `json:"inventory"`
+	Limit               string     `json:"limit"`
+	ScmBranch           string     `json:"scm_branch"`
+	// Extra holds the fields returned by AWX that have no field above,
+	// such as fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a WorkflowJob, keeping unknown fields in Extra.
+func (wj *WorkflowJob) UnmarshalJSON(data []byte) error {
+	type workflowJob WorkflowJob
+	extra, err := unmarshalWithExtra(data, (*workflowJob)(wj))
+	wj.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a WorkflowJob together with the fields in Extra.
+func (wj WorkflowJob) MarshalJSON() ([]byte, error) {
+	type workflowJob WorkflowJob
+	return marshalWithExtra(workflowJob(wj), wj.Extra)
+}
+
+// SystemJob represents a AWX SystemJob, a run of a built in maintenance
+// SystemJobTemplate such as activity stream cleanup.
+type SystemJob struct {
+	ID      int    `json:"id"`
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+	Related struct {
+		CreatedBy          string `json:"created_by"`
+		UnifiedJobTemplate string `json:"unified_job_template"`
+		Stdout             string `json:"stdout"`
+		SystemJobTemplate  string `json:"system_job_template"`
+		Notifications      string `json:"notifications"`
+		Cancel             string `json:"cancel"`
+		Events             string `json:"events"`
+	} `json:"related"`
+	SummaryFields struct {
+		SystemJobTemplate *ObjectSummary   `json:"system_job_template"`
+		CreatedBy         *UserSummary     `json:"created_by"`
+		UserCapabilities  UserCapabilities `json:"user_capabilities"`
+	} `json:"summary_fields"`
+	Created            time.Time  `json:"created"`
+	Modified           time.Time  `json:"modified"`
+	Name               string     `json:"name"`
+	Description        string     `json:"description"`
+	UnifiedJobTemplate *int       `json:"unified_job_template"`
+	LaunchType         string     `json:"launch_type"`
+	Status             string     `json:"status"`
+	Failed             bool       `json:"failed"`
+	Started            *time.Time `json:"started"`
+	Finished           *time.Time `json:"finished"`
+	CanceledOn         *time.Time `json:"canceled_on"`
+	Elapsed            float64    `json:"elapsed"`
+	JobExplanation     string     `json:"job_explanation"`
+	ExecutionNode      string     `json:"execution_node"`
+	LaunchedBy         LaunchedBy `json:"launched_by"`
+	SystemJobTemplate  *int       `json:"system_job_template"`
+	JobType            string     `json:"job_type"`
+	ExtraVars          Vars       `json:"extra_vars"`
+	ResultStdout       string     `json:"result_stdout"`
+	// Extra holds the fields returned by AWX that have no field above,
+	// such as fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a SystemJob, keeping unknown fields in Extra.
+func (sj *SystemJob) UnmarshalJSON(data []byte) error {
+	type systemJob SystemJob
+	extra, err := unmarshalWithExtra(data, (*systemJob)(sj))
+	sj.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a SystemJob together with the fields in Extra.
+func (sj SystemJob) MarshalJSON() ([]byte, error) {
+	type systemJob SystemJob
+	return marshalWithExtra(systemJob(sj), sj.Extra)
+}
+
+// UnknownUnifiedJob holds a job of a kind this client has no type for.
+type UnknownUnifiedJob struct {
+	ID   int    `json:"id"`
+	Type string `json:"type"`
+	URL  string `json:"url"`
+	// Extra holds the fields returned by AWX other than the ones above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes an UnknownUnifiedJob, keeping the other fields in Extra.
+func (uj *UnknownUnifiedJob) UnmarshalJSON(data []byte) error {
+	type unknownUnifiedJob UnknownUnifiedJob
+	extra, err := unmarshalWithExtra(data, (*unknownUnifiedJob)(uj))
+	uj.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes an UnknownUnifiedJob together with the fields in Extra.
+func (uj UnknownUnifiedJob) MarshalJSON() ([]byte, error) {
+	type unknownUnifiedJob UnknownUnifiedJob
+	return marshalWithExtra(unknownUnifiedJob(uj), uj.Extra)
+}
+
+// decodeUnifiedJob decodes a single result of the unified_jobs endpoint into
+// the concrete type named by its type field.
+func decodeUnifiedJob(data json.RawMessage) (UnifiedJob, error) {
+	var kind struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &kind); err != nil {
+		return nil, err
+	}
+
+	var job UnifiedJob
+	switch kind.Type {
+	case "job":
+		job = new(Job)
+	case "project_update":
+		job = new(ProjectUpdate)
+	case "inventory_update":
+		job = new(InventoryUpdate)
+	case "workflow_job":
+		job = new(WorkflowJob)
+	case "ad_hoc_command":
+		job = new(AdHocCommand)
+	case "system_job":
+		job = new(SystemJob)
+	default:
+		unknown := new(UnknownUnifiedJob)
+		if err := json.Unmarshal(data, unknown); err != nil {
+			return nil, err
+		}
+		return unknown, nil
+	}
+
+	if err := json.Unmarshal(data, job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// List every job matching opts, across all job kinds and pages.
+func (s *UnifiedJobServiceOp) List(ctx context.Context, opts *UnifiedJobListOptions) ([]UnifiedJob, *Response, error) {
+	path := unifiedJobBasePath + "?" + opts.values().Encode()
+
+	var jobs []UnifiedJob
+	resp, err := listAllPages(ctx, s.client, path, func(results json.RawMessage) error {
+		var raw []json.RawMessage
+		if err := json.Unmarshal(results, &raw); err != nil {
+			return err
+		}
+		for _, data := range raw {
+			job, err := decodeUnifiedJob(data)
+			if err != nil {
+				return err
+			}
+			jobs = append(jobs, job)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return jobs, resp, err
+}
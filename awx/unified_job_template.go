@@ -0,0 +1,198 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const unifiedJobTemplateBasePath = "unified_job_templates/"
+
+// UnifiedJobTemplateService is an interface for interfacing with the UnifiedJobTemplate
+// endpoints of the AWX API
+// See: http://localhost/api/v2/unified_job_templates/
+type UnifiedJobTemplateService interface {
+	List(context.Context, *UnifiedJobTemplateListOptions) ([]UnifiedJobTemplate, *Response, error)
+}
+
+// UnifiedJobTemplateServiceOp handles communication with the UnifiedJobTemplate related methods of the
+// AWX API.
+type UnifiedJobTemplateServiceOp struct {
+	client *Client
+}
+
+// UnifiedJobTemplate is anything AWX can launch a job from. Values are one of
+// *JobTemplate, *Project, *InventorySource, *WorkflowJobTemplate,
+// *SystemJobTemplate or, for kinds this client does not know about,
+// *UnknownUnifiedJobTemplate, and can be told apart with a type switch.
+type UnifiedJobTemplate interface {
+	unifiedJobTemplate()
+}
+
+func (*JobTemplate) unifiedJobTemplate()               {}
+func (*Project) unifiedJobTemplate()                   {}
+func (*InventorySource) unifiedJobTemplate()           {}
+func (*WorkflowJobTemplate) unifiedJobTemplate()       {}
+func (*SystemJobTemplate) unifiedJobTemplate()         {}
+func (*UnknownUnifiedJobTemplate) unifiedJobTemplate() {}
+
+// UnifiedJobTemplateListOptions filters the templates returned by
+// UnifiedJobTemplateService.List. Zero values are ignored.
+type UnifiedJobTemplateListOptions struct {
+	// Type matches templates of any of the given kinds, e.g. "job_template"
+	// or "workflow_job_template".
+	Type []string
+	// Status matches templates whose last job is in any of the given states.
+	Status []string
+	// OrderBy sorts the result by a field, prefixed with "-" to reverse it.
+	OrderBy string
+}
+
+func (o *UnifiedJobTemplateListOptions) values() url.Values {
+	v := url.Values{"page_size": {"200"}}
+	if o == nil {
+		return v
+	}
+
+	if len(o.Type) > 0 {
+		v.Set("type", strings.Join(o.Type, ","))
+	}
+	if len(o.Status) > 0 {
+		v.Set("status__in", strings.Join(o.Status, ","))
+	}
+	if o.OrderBy != "" {
+		v.Set("order_by", o.OrderBy)
+	}
+
+	return v
+}
+
+// SystemJobTemplate represents a AWX SystemJobTemplate, one of the built in
+// maintenance jobs.
+type SystemJobTemplate struct {
+	ID      int    `json:"id"`
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+	Related struct {
+		Jobs                         string `json:"jobs"`
+		Schedules                    string `json:"schedules"`
+		Launch                       string `json:"launch"`
+		NotificationTemplatesStarted string `json:"notification_templates_started"`
+		NotificationTemplatesSuccess string `json:"notification_templates_success"`
+		NotificationTemplatesError   string `json:"notification_templates_error"`
+	} `json:"related"`
+	Created       time.Time  `json:"created"`
+	Modified      time.Time  `json:"modified"`
+	Name          string     `json:"name"`
+	Description   string     `json:"description"`
+	LastJobRun    *time.Time `json:"last_job_run"`
+	LastJobFailed bool       `json:"last_job_failed"`
+	NextJobRun    *time.Time `json:"next_job_run"`
+	Status        string     `json:"status"`
+	JobType       string     `json:"job_type"`
+	// Extra holds the fields returned by AWX that have no field above,
+	// such as fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a SystemJobTemplate, keeping unknown fields in Extra.
+func (sjt *SystemJobTemplate) UnmarshalJSON(data []byte) error {
+	type systemJobTemplate SystemJobTemplate
+	extra, err := unmarshalWithExtra(data, (*systemJobTemplate)(sjt))
+	sjt.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a SystemJobTemplate together with the fields in Extra.
+func (sjt SystemJobTemplate) MarshalJSON() ([]byte, error) {
+	type systemJobTemplate SystemJobTemplate
+	return marshalWithExtra(systemJobTemplate(sjt), sjt.Extra)
+}
+
+// UnknownUnifiedJobTemplate holds a template of a kind this client has no type for.
+type UnknownUnifiedJobTemplate struct {
+	ID   int    `json:"id"`
+	Type string `json:"type"`
+	URL  string `json:"url"`
+	// Extra holds the fields returned by AWX other than the ones above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes an UnknownUnifiedJobTemplate, keeping the other fields in Extra.
+func (ujt *UnknownUnifiedJobTemplate) UnmarshalJSON(data []byte) error {
+	type unknownUnifiedJobTemplate UnknownUnifiedJobTemplate
+	extra, err := unmarshalWithExtra(data, (*unknownUnifiedJobTemplate)(ujt))
+	ujt.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes an UnknownUnifiedJobTemplate together with the fields in Extra.
+func (ujt UnknownUnifiedJobTemplate) MarshalJSON() ([]byte, error) {
+	type unknownUnifiedJobTemplate UnknownUnifiedJobTemplate
+	return marshalWithExtra(unknownUnifiedJobTemplate(ujt), ujt.Extra)
+}
+
+// decodeUnifiedJobTemplate decodes a single result of the
+// unified_job_templates endpoint into the concrete type named by its type field.
+func decodeUnifiedJobTemplate(data json.RawMessage) (UnifiedJobTemplate, error) {
+	var kind struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &kind); err != nil {
+		return nil, err
+	}
+
+	var template UnifiedJobTemplate
+	switch kind.Type {
+	case "job_template":
+		template = new(JobTemplate)
+	case "project":
+		template = new(Project)
+	case "inventory_source":
+		template = new(InventorySource)
+	case "workflow_job_template":
+		template = new(WorkflowJobTemplate)
+	case "system_job_template":
+		template = new(SystemJobTemplate)
+	default:
+		unknown := new(UnknownUnifiedJobTemplate)
+		if err := json.Unmarshal(data, unknown); err != nil {
+			return nil, err
+		}
+		return unknown, nil
+	}
+
+	if err := json.Unmarshal(data, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// List every template matching opts, across all template kinds and pages.
+func (s *UnifiedJobTemplateServiceOp) List(ctx context.Context, opts *UnifiedJobTemplateListOptions) ([]UnifiedJobTemplate, *Response, error) {
+	path := unifiedJobTemplateBasePath + "?" + opts.values().Encode()
+
+	var templates []UnifiedJobTemplate
+	resp, err := listAllPages(ctx, s.client, path, func(results json.RawMessage) error {
+		var raw []json.RawMessage
+		if err := json.Unmarshal(results, &raw); err != nil {
+			return err
+		}
+		for _, data := range raw {
+			template, err := decodeUnifiedJobTemplate(data)
+			if err != nil {
+				return err
+			}
+			templates = append(templates, template)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return templates, resp, err
+}
@@ -0,0 +1,69 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestUnifiedJobList(t *testing.T) {
+	client, mux := setup(t)
+	var queries []string
+	mux.HandleFunc("/api/v2/unified_jobs/", func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		if r.URL.Query().Get("page") == "2" {
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{
+				"count": 3,
+				"results": []map[string]interface{}{
+					{"id": 44, "type": "container_build", "url": "/api/v2/container_builds/44/", "status": "successful"},
+				},
+			})
+			return
+		}
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{
+			"count": 3,
+			"next":  "/api/v2/unified_jobs/?page=2",
+			"results": []map[string]interface{}{
+				{"id": 42, "type": "job", "name": "deploy", "status": "failed", "project": 3},
+				{"id": 43, "type": "project_update", "name": "playbooks", "status": "failed", "project": nil},
+			},
+		})
+	})
+
+	jobs, _, err := client.UnifiedJob.List(context.Background(), &UnifiedJobListOptions{
+		Status:       []string{"failed", "error"},
+		CreatedAfter: time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	if want := "created__gte=2024-06-03T10%3A00%3A00Z&page_size=200&status__in=failed%2Cerror"; queries[0] != want {
+		t.Errorf("query = %q, want %q", queries[0], want)
+	}
+	if len(jobs) != 3 {
+		t.Fatalf("List returned %d jobs, want 3 across both pages", len(jobs))
+	}
+	if job, ok := jobs[0].(*Job); !ok || job.ID != 42 || job.Project == nil || *job.Project != 3 {
+		t.Errorf("jobs[0] = %#v, want job 42 of project 3", jobs[0])
+	}
+	if update, ok := jobs[1].(*ProjectUpdate); !ok || update.ID != 43 || update.Project != nil {
+		t.Errorf("jobs[1] = %#v, want project update 43 without a project", jobs[1])
+	}
+	unknown, ok := jobs[2].(*UnknownUnifiedJob)
+	if !ok || unknown.ID != 44 || unknown.Type != "container_build" {
+		t.Fatalf("jobs[2] = %#v, want an unknown container_build job", jobs[2])
+	}
+	if len(unknown.Extra) != 1 || string(unknown.Extra["status"]) != `"successful"` {
+		t.Errorf("Extra = %s, want only the status", unknown.Extra)
+	}
+	data, err := json.Marshal(unknown)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if want := `{"id": 44, "type": "container_build", "url": "/api/v2/container_builds/44/", "status": "successful"}`; !equalJSON(t, data, []byte(want)) {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+}
@@ -0,0 +1,238 @@
+package awx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Encrypted is the placeholder AWX returns in place of secret values, such as
+// survey password answers. Sending it back unchanged tells AWX to keep the
+// stored secret.
+const Encrypted = "$encrypted$"
+
+// VarsFormat is the text format a set of Vars is stored in on the AWX side.
+type VarsFormat int
+
+const (
+	// VarsJSON stores Vars as a JSON object.
+	VarsJSON VarsFormat = iota
+	// VarsYAML stores Vars as a YAML mapping.
+	VarsYAML
+)
+
+// Vars holds Ansible variables, such as JobTemplate extra_vars or Inventory
+// variables. AWX accepts and returns these as a string containing either JSON
+// or YAML; Vars decodes both and, unless modified, sends back exactly the text
+// it received so the original format is preserved on a round trip.
+type Vars struct {
+	raw    string
+	format VarsFormat
+	values map[string]interface{}
+	dirty  bool
+}
+
+// NewVars creates Vars from a map[string]interface{} or any struct that
+// encodes to a JSON object.
+func NewVars(v interface{}) (*Vars, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]interface{}{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, NewArgError("v", "must encode to a JSON object")
+	}
+
+	return &Vars{values: values, format: VarsJSON, dirty: true}, nil
+}
+
+// ParseVars parses Vars from JSON or YAML text, as AWX returns it.
+func ParseVars(s string) (*Vars, error) {
+	v := &Vars{raw: s, values: map[string]interface{}{}}
+
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return v, nil
+	}
+
+	if strings.HasPrefix(trimmed, "{") && json.Valid([]byte(trimmed)) {
+		if err := json.Unmarshal([]byte(trimmed), &v.values); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+
+	v.format = VarsYAML
+	var values map[string]interface{}
+	if err := yaml.Unmarshal([]byte(s), &values); err != nil {
+		return nil, fmt.Errorf("vars are neither a JSON object nor a YAML mapping: %w", err)
+	}
+	if values != nil {
+		v.values = values
+	}
+
+	return v, nil
+}
+
+// Format returns the format the Vars will be sent to AWX in.
+func (v *Vars) Format() VarsFormat {
+	return v.format
+}
+
+// SetFormat changes the format the Vars will be sent to AWX in.
+func (v *Vars) SetFormat(format VarsFormat) {
+	if format != v.format {
+		v.format = format
+		v.dirty = true
+	}
+}
+
+// Len returns the number of top level variables.
+func (v *Vars) Len() int {
+	return len(v.values)
+}
+
+// Map returns a copy of the top level variables.
+func (v *Vars) Map() map[string]interface{} {
+	m := make(map[string]interface{}, len(v.values))
+	for k, val := range v.values {
+		m[k] = val
+	}
+	return m
+}
+
+// Get returns the value of a top level variable.
+func (v *Vars) Get(key string) (interface{}, bool) {
+	val, ok := v.values[key]
+	return val, ok
+}
+
+// Set sets the value of a top level variable.
+func (v *Vars) Set(key string, value interface{}) {
+	if v.values == nil {
+		v.values = map[string]interface{}{}
+	}
+	v.values[key] = value
+	v.dirty = true
+}
+
+// Delete removes a top level variable.
+func (v *Vars) Delete(key string) {
+	if _, ok := v.values[key]; ok {
+		delete(v.values, key)
+		v.dirty = true
+	}
+}
+
+// Decode stores the variables in the value pointed to by out, which may be a
+// map or a struct with json tags.
+func (v *Vars) Decode(out interface{}) error {
+	data, err := json.Marshal(v.values)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// IsEncrypted reports whether AWX returned the variable as an Encrypted placeholder.
+func (v *Vars) IsEncrypted(key string) bool {
+	val, ok := v.values[key].(string)
+	return ok && val == Encrypted
+}
+
+// EncryptedKeys returns the sorted names of all variables AWX returned as
+// Encrypted placeholders.
+func (v *Vars) EncryptedKeys() []string {
+	var keys []string
+	for k := range v.values {
+		if v.IsEncrypted(k) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// String returns the Vars as the JSON or YAML text sent to AWX. If modified
+// Vars cannot be encoded, it returns the text they were parsed from; use
+// MarshalJSON to get the error instead.
+func (v Vars) String() string {
+	text, err := v.text()
+	if err != nil {
+		return v.raw
+	}
+	return text
+}
+
+// text returns the Vars as the JSON or YAML text sent to AWX.
+func (v Vars) text() (string, error) {
+	if !v.dirty {
+		return v.raw, nil
+	}
+	if len(v.values) == 0 {
+		return "", nil
+	}
+
+	if v.format == VarsYAML {
+		data, err := yaml.Marshal(v.values)
+		if err != nil {
+			return "", err
+		}
+		return "---\n" + string(data), nil
+	}
+
+	data, err := json.Marshal(v.values)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// MarshalJSON encodes the Vars as a JSON string, the form AWX expects. It
+// fails if modified Vars cannot be encoded, rather than sending the text
+// they were parsed from.
+func (v Vars) MarshalJSON() ([]byte, error) {
+	text, err := v.text()
+	if err != nil {
+		return nil, fmt.Errorf("encoding vars: %w", err)
+	}
+	return json.Marshal(text)
+}
+
+// UnmarshalJSON decodes Vars from a JSON string holding JSON or YAML text, or
+// from a plain JSON object as returned by some endpoints.
+func (v *Vars) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*v = Vars{}
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '{' {
+		values := map[string]interface{}{}
+		if err := json.Unmarshal(data, &values); err != nil {
+			return err
+		}
+		*v = Vars{raw: string(data), values: values}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsed, err := ParseVars(s)
+	if err != nil {
+		return err
+	}
+	*v = *parsed
+
+	return nil
+}
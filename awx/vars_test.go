@@ -0,0 +1,78 @@
+package awx
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestVarsMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		vars func(t *testing.T) Vars
+		want string
+	}{
+		{
+			name: "unmodified json",
+			vars: func(t *testing.T) Vars { return parseVars(t, `{"b": 1, "a": 2}`) },
+			want: `"{\"b\": 1, \"a\": 2}"`,
+		},
+		{
+			name: "unmodified yaml",
+			vars: func(t *testing.T) Vars { return parseVars(t, "---\nb: 1\n") },
+			want: `"---\nb: 1\n"`,
+		},
+		{
+			name: "modified json",
+			vars: func(t *testing.T) Vars {
+				v := parseVars(t, `{"b": 1}`)
+				v.Set("a", "x")
+				return v
+			},
+			want: `"{\"a\":\"x\",\"b\":1}"`,
+		},
+		{
+			name: "modified yaml",
+			vars: func(t *testing.T) Vars {
+				v := parseVars(t, "b: 1\n")
+				v.Delete("b")
+				v.Set("a", "x")
+				return v
+			},
+			want: `"---\na: x\n"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.vars(t))
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVarsMarshalJSONError(t *testing.T) {
+	v := parseVars(t, `{"a": 1}`)
+	v.Set("a", math.Inf(1))
+
+	if data, err := json.Marshal(v); err == nil {
+		t.Fatalf("Marshal = %s, want an error instead of the unmodified vars", data)
+	}
+	if got := v.String(); got != `{"a": 1}` {
+		t.Errorf("String = %q, want the parsed text", got)
+	}
+}
+
+func parseVars(t *testing.T, s string) Vars {
+	t.Helper()
+	v, err := ParseVars(s)
+	if err != nil {
+		t.Fatalf("ParseVars(%q): %v", s, err)
+	}
+	return *v
+}
@@ -0,0 +1,200 @@
+package awx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrNotSupported is matched by errors returned for requests to endpoints the
+// server does not provide in its version.
+var ErrNotSupported = errors.New("awx: not supported by this server version")
+
+// Features gated on the server version. Only endpoints used by this package
+// that servers still in use may lack are listed: every other endpoint it uses
+// is provided by all AWX, Ansible Tower and Automation Controller releases it
+// supports, so requests to them are sent without a version check.
+const (
+	FeatureExecutionEnvironments = "execution_environments"
+)
+
+// feature is an API feature and the versions that introduced it. Versions
+// below 9 are Ansible Tower and Automation Controller releases, which are
+// numbered separately from AWX.
+type feature struct {
+	name       string
+	path       string
+	awx        string
+	controller string
+}
+
+var features = []feature{
+	{name: FeatureExecutionEnvironments, path: "/execution_environments/", awx: "18.0.0", controller: "4.0.0"},
+}
+
+// UnsupportedError is returned when a feature is not available in the version
+// of the server. It matches ErrNotSupported.
+type UnsupportedError struct {
+	Feature       string
+	ServerVersion string
+	MinVersion    string
+}
+
+var _ error = &UnsupportedError{}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("awx: %s not supported by this server version: requires %s or later, server runs %s",
+		e.Feature, e.MinVersion, e.ServerVersion)
+}
+
+// Is reports whether target is ErrNotSupported.
+func (e *UnsupportedError) Is(target error) bool {
+	return target == ErrNotSupported
+}
+
+// WithVersionNegotiation is a client option that checks requests against the
+// version of the server, fetched with Ping on first use. Requests to the
+// endpoints of a feature, such as FeatureExecutionEnvironments, that the
+// server is too old to provide fail with an *UnsupportedError instead of
+// being sent and answered with 404 Not Found.
+func WithVersionNegotiation() ClientOpt {
+	return func(c *Client) error {
+		c.negotiateVersion = true
+		return nil
+	}
+}
+
+// ServerVersion returns the version of the server, as reported by Ping. It is
+// fetched once and remembered for the lifetime of the Client. Concurrent
+// callers wait for the same Ping, and try again if it fails.
+func (c *Client) ServerVersion(ctx context.Context) (string, error) {
+	for {
+		c.versionMu.Lock()
+		if c.serverVersion != "" {
+			version := c.serverVersion
+			c.versionMu.Unlock()
+			return version, nil
+		}
+		fetch := c.versionFetch
+		if fetch == nil {
+			fetch = make(chan struct{})
+			c.versionFetch = fetch
+			c.versionMu.Unlock()
+			return c.fetchServerVersion(ctx, fetch)
+		}
+		c.versionMu.Unlock()
+
+		select {
+		case <-fetch:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+}
+
+// fetchServerVersion pings the server without holding versionMu, and closes
+// fetch once the outcome is recorded.
+func (c *Client) fetchServerVersion(ctx context.Context, fetch chan struct{}) (string, error) {
+	ping, _, err := c.Ping.Get(ctx)
+
+	c.versionMu.Lock()
+	if err == nil {
+		c.serverVersion = ping.Version
+	}
+	c.versionFetch = nil
+	c.versionMu.Unlock()
+	close(fetch)
+
+	if err != nil {
+		return "", err
+	}
+	return ping.Version, nil
+}
+
+// Supports reports whether the server provides a feature, such as
+// FeatureExecutionEnvironments.
+func (c *Client) Supports(ctx context.Context, name string) (bool, error) {
+	for _, f := range features {
+		if f.name != name {
+			continue
+		}
+		err := c.checkFeature(ctx, f)
+		if errors.Is(err, ErrNotSupported) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+	return false, NewArgError("name", "is not a known feature")
+}
+
+// checkVersion returns an *UnsupportedError if req is for a feature the
+// server does not provide.
+func (c *Client) checkVersion(ctx context.Context, req *http.Request) error {
+	for _, f := range features {
+		if strings.Contains(req.URL.Path, f.path) {
+			return c.checkFeature(ctx, f)
+		}
+	}
+	return nil
+}
+
+func (c *Client) checkFeature(ctx context.Context, f feature) error {
+	serverVersion, err := c.ServerVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	server, ok := parseVersion(serverVersion)
+	if !ok || server[0] == 0 {
+		// Development builds report versions that cannot be compared, such
+		// as "0.1.dev0+g1234567" when installed from a source checkout.
+		return nil
+	}
+
+	minVersion := f.awx
+	if server[0] < 9 {
+		minVersion = f.controller
+	}
+	minimum, _ := parseVersion(minVersion)
+	if compareVersions(server, minimum) < 0 {
+		return &UnsupportedError{Feature: f.name, ServerVersion: serverVersion, MinVersion: minVersion}
+	}
+
+	return nil
+}
+
+// parseVersion parses the leading major.minor.patch numbers of a version such
+// as "22.0.1.dev0+g1234567".
+func parseVersion(v string) ([3]int, bool) {
+	var version [3]int
+	parts := strings.SplitN(v, ".", 4)
+	if len(parts) < 2 {
+		return version, false
+	}
+	for i := 0; i < len(parts) && i < 3; i++ {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			if i < 2 {
+				return version, false
+			}
+			break
+		}
+		version[i] = n
+	}
+	return version, true
+}
+
+func compareVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
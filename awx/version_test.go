@@ -0,0 +1,87 @@
+package awx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestVersionNegotiation(t *testing.T) {
+	tests := []struct {
+		version   string
+		supported bool
+	}{
+		{version: "17.1.0", supported: false},
+		{version: "18.0.0", supported: true},
+		{version: "24.6.1", supported: true},
+		{version: "3.8.6", supported: false},
+		{version: "4.5.0", supported: true},
+		{version: "0.1.dev0+g1234567", supported: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			client, mux := setup(t)
+			mux.HandleFunc("/api/v2/ping/", func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(t, w, http.StatusOK, Ping{Version: tt.version})
+			})
+			mux.HandleFunc("/api/v2/execution_environments/", func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(t, w, http.StatusOK, map[string]interface{}{"count": 0, "results": []interface{}{}})
+			})
+			if err := WithVersionNegotiation()(client); err != nil {
+				t.Fatalf("WithVersionNegotiation: %v", err)
+			}
+
+			_, _, err := client.ExecutionEnvironment.List(context.Background())
+			if tt.supported && err != nil {
+				t.Errorf("List: %v, want no error", err)
+			}
+			if !tt.supported && !errors.Is(err, ErrNotSupported) {
+				t.Errorf("List: %v, want ErrNotSupported", err)
+			}
+
+			supported, err := client.Supports(context.Background(), FeatureExecutionEnvironments)
+			if err != nil || supported != tt.supported {
+				t.Errorf("Supports = %v, %v, want %v", supported, err, tt.supported)
+			}
+		})
+	}
+}
+
+func TestServerVersionPingsOnce(t *testing.T) {
+	client, mux := setup(t)
+	release := make(chan struct{})
+	var pings int32
+	mux.HandleFunc("/api/v2/ping/", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&pings, 1)
+		<-release
+		writeJSON(t, w, http.StatusOK, Ping{Version: "24.6.1"})
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if version, err := client.ServerVersion(context.Background()); err != nil || version != "24.6.1" {
+				t.Errorf("ServerVersion = %q, %v, want 24.6.1", version, err)
+			}
+		}()
+	}
+
+	// A caller whose context ends gives up without waiting for the Ping.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.ServerVersion(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("ServerVersion with a cancelled context: %v, want context.Canceled", err)
+	}
+
+	close(release)
+	wg.Wait()
+	if pings != 1 {
+		t.Errorf("pinged %d times, want 1", pings)
+	}
+}
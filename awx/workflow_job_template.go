@@ -0,0 +1,316 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const workflowJobTemplateBasePath = "workflow_job_templates/"
+
+// WorkflowJobTemplateService is an interface for interfacing with the WorkflowJobTemplate
+// endpoints of the AWX API
+// See: http://localhost/api/v2/workflow_job_templates/
+type WorkflowJobTemplateService interface {
+	List(context.Context) ([]WorkflowJobTemplate, *Response, error)
+	Get(context.Context, int) (*WorkflowJobTemplate, *Response, error)
+	Create(context.Context, *WorkflowJobTemplateCreateRequest) (*WorkflowJobTemplate, *Response, error)
+	Update(context.Context, *WorkflowJobTemplateCreateRequest, int) (*Response, error)
+	Delete(context.Context, int) (*Response, error)
+	GetSurveySpec(context.Context, int) (*SurveySpec, *Response, error)
+	SetSurveySpec(context.Context, int, *SurveySpec) (*Response, error)
+	DeleteSurveySpec(context.Context, int) (*Response, error)
+	ListLabels(context.Context, int) ([]Label, *Response, error)
+	AssociateLabel(context.Context, int, int) (*Response, error)
+	DisassociateLabel(context.Context, int, int) (*Response, error)
+}
+
+// WorkflowJobTemplateServiceOp handles communication with the WorkflowJobTemplate related methods of the
+// AWX API.
+type WorkflowJobTemplateServiceOp struct {
+	client *Client
+}
+
+// WorkflowJobTemplate represents a AWX WorkflowJobTemplate
+type WorkflowJobTemplate struct {
+	ID      int    `json:"id"`
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+	Related struct {
+		NamedURL                       string `json:"named_url"`
+		CreatedBy                      string `json:"created_by"`
+		ModifiedBy                     string `json:"modified_by"`
+		WorkflowJobs                   string `json:"workflow_jobs"`
+		Schedules                      string `json:"schedules"`
+		Launch                         string `json:"launch"`
+		WorkflowNodes                  string `json:"workflow_nodes"`
+		Labels                         string `json:"labels"`
+		ActivityStream                 string `json:"activity_stream"`
+		NotificationTemplatesStarted   string `json:"notification_templates_started"`
+		NotificationTemplatesSuccess   string `json:"notification_templates_success"`
+		NotificationTemplatesError     string `json:"notification_templates_error"`
+		NotificationTemplatesApprovals string `json:"notification_templates_approvals"`
+		AccessList                     string `json:"access_list"`
+		ObjectRoles                    string `json:"object_roles"`
+		SurveySpec                     string `json:"survey_spec"`
+		Copy                           string `json:"copy"`
+		Organization                   string `json:"organization"`
+		Inventory                      string `json:"inventory"`
+	} `json:"related"`
+	SummaryFields struct {
+		Organization *ObjectSummary `json:"organization"`
+		CreatedBy    *UserSummary   `json:"created_by"`
+		ModifiedBy   *UserSummary   `json:"modified_by"`
+		ObjectRoles  struct {
+			AdminRole    ObjectRole `json:"admin_role"`
+			ExecuteRole  ObjectRole `json:"execute_role"`
+			ReadRole     ObjectRole `json:"read_role"`
+			ApprovalRole ObjectRole `json:"approval_role"`
+		} `json:"object_roles"`
+		UserCapabilities UserCapabilities `json:"user_capabilities"`
+		Labels           LabelsSummary    `json:"labels"`
+	} `json:"summary_fields"`
+	Created              time.Time  `json:"created"`
+	Modified             time.Time  `json:"modified"`
+	Name                 string     `json:"name"`
+	Description          string     `json:"description"`
+	LastJobRun           *time.Time `json:"last_job_run"`
+	LastJobFailed        bool       `json:"last_job_failed"`
+	NextJobRun           *time.Time `json:"next_job_run"`
+	Status               string     `json:"status"`
+	ExtraVars            Vars       `json:"extra_vars"`
+	Organization         *int       `json:"organization"`
+	SurveyEnabled        bool       `json:"survey_enabled"`
+	AllowSimultaneous    bool       `json:"allow_simultaneous"`
+	AskVariablesOnLaunch bool       `json:"ask_variables_on_launch"`
+	Inventory            *int       `json:"inventory"`
+	Limit                string     `json:"limit"`
+	ScmBranch            string     `json:"scm_branch"`
+	AskInventoryOnLaunch bool       `json:"ask_inventory_on_launch"`
+	AskScmBranchOnLaunch bool       `json:"ask_scm_branch_on_launch"`
+	AskLimitOnLaunch     bool       `json:"ask_limit_on_launch"`
+	WebhookService       string     `json:"webhook_service"`
+	WebhookCredential    *int       `json:"webhook_credential"`
+	// Extra holds the fields returned by AWX that have no field above,
+	// such as fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a WorkflowJobTemplate, keeping unknown fields in Extra.
+func (wjt *WorkflowJobTemplate) UnmarshalJSON(data []byte) error {
+	type workflowJobTemplate WorkflowJobTemplate
+	extra, err := unmarshalWithExtra(data, (*workflowJobTemplate)(wjt))
+	wjt.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a WorkflowJobTemplate together with the fields in Extra.
+func (wjt WorkflowJobTemplate) MarshalJSON() ([]byte, error) {
+	type workflowJobTemplate WorkflowJobTemplate
+	return marshalWithExtra(workflowJobTemplate(wjt), wjt.Extra)
+}
+
+// WorkflowJobTemplateCreateRequest represents a request to create a WorkflowJobTemplate.
+type WorkflowJobTemplateCreateRequest struct {
+	Name                 string `json:"name"`
+	Description          string `json:"description,omitempty"`
+	ExtraVars            *Vars  `json:"extra_vars,omitempty"`
+	Organization         int    `json:"organization,omitempty"`
+	SurveyEnabled        bool   `json:"survey_enabled,omitempty"`
+	AllowSimultaneous    bool   `json:"allow_simultaneous,omitempty"`
+	AskVariablesOnLaunch bool   `json:"ask_variables_on_launch,omitempty"`
+	Inventory            int    `json:"inventory,omitempty"`
+	Limit                string `json:"limit,omitempty"`
+	ScmBranch            string `json:"scm_branch,omitempty"`
+	AskInventoryOnLaunch bool   `json:"ask_inventory_on_launch,omitempty"`
+	AskScmBranchOnLaunch bool   `json:"ask_scm_branch_on_launch,omitempty"`
+	AskLimitOnLaunch     bool   `json:"ask_limit_on_launch,omitempty"`
+	WebhookService       string `json:"webhook_service,omitempty"`
+	WebhookCredential    int    `json:"webhook_credential,omitempty"`
+	// Extra holds fields to send that have no field above, such as
+	// fields added in newer releases.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes a WorkflowJobTemplateCreateRequest together with the fields in Extra.
+func (r WorkflowJobTemplateCreateRequest) MarshalJSON() ([]byte, error) {
+	type request WorkflowJobTemplateCreateRequest
+	return marshalWithExtra(request(r), r.Extra)
+}
+
+// workflowJobTemplateRoot represents a WorkflowJobTemplate root
+type workflowJobTemplateRoot struct {
+	Count    int                   `json:"count"`
+	Next     string                `json:"next"`
+	Previous string                `json:"previous"`
+	Results  []WorkflowJobTemplate `json:"results"`
+}
+
+// List all WorkflowJobTemplates.
+func (s *WorkflowJobTemplateServiceOp) List(ctx context.Context) ([]WorkflowJobTemplate, *Response, error) {
+	path := workflowJobTemplateBasePath
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(workflowJobTemplateRoot)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root.Results, resp, err
+}
+
+// Get individual WorkflowJobTemplate.
+func (s *WorkflowJobTemplateServiceOp) Get(ctx context.Context, workflowJobTemplateID int) (*WorkflowJobTemplate, *Response, error) {
+	if workflowJobTemplateID < 1 {
+		return nil, nil, NewArgError("workflowJobTemplateID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/", workflowJobTemplateBasePath, workflowJobTemplateID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(WorkflowJobTemplate)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Create WorkflowJobTemplate
+func (s *WorkflowJobTemplateServiceOp) Create(ctx context.Context, createRequest *WorkflowJobTemplateCreateRequest) (*WorkflowJobTemplate, *Response, error) {
+	if createRequest == nil {
+		return nil, nil, NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := workflowJobTemplateBasePath
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(WorkflowJobTemplate)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Update WorkflowJobTemplate.
+func (s *WorkflowJobTemplateServiceOp) Update(ctx context.Context, createRequest *WorkflowJobTemplateCreateRequest, workflowJobTemplateID int) (*Response, error) {
+	if workflowJobTemplateID < 1 {
+		return nil, NewArgError("workflowJobTemplateID", "cannot be less than 1")
+	}
+	if createRequest == nil {
+		return nil, NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := fmt.Sprintf("%s%d/", workflowJobTemplateBasePath, workflowJobTemplateID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodPatch, path, createRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+
+	return resp, err
+}
+
+// Delete WorkflowJobTemplate.
+func (s *WorkflowJobTemplateServiceOp) Delete(ctx context.Context, workflowJobTemplateID int) (*Response, error) {
+	if workflowJobTemplateID < 1 {
+		return nil, NewArgError("workflowJobTemplateID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/", workflowJobTemplateBasePath, workflowJobTemplateID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+
+	return resp, err
+}
+
+// GetSurveySpec returns the survey of a WorkflowJobTemplate.
+func (s *WorkflowJobTemplateServiceOp) GetSurveySpec(ctx context.Context, workflowJobTemplateID int) (*SurveySpec, *Response, error) {
+	if workflowJobTemplateID < 1 {
+		return nil, nil, NewArgError("workflowJobTemplateID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/survey_spec/", workflowJobTemplateBasePath, workflowJobTemplateID)
+
+	return getSurveySpec(ctx, s.client, path)
+}
+
+// SetSurveySpec replaces the survey of a WorkflowJobTemplate. The survey is
+// only presented on launch while SurveyEnabled is set on the template.
+func (s *WorkflowJobTemplateServiceOp) SetSurveySpec(ctx context.Context, workflowJobTemplateID int, spec *SurveySpec) (*Response, error) {
+	if workflowJobTemplateID < 1 {
+		return nil, NewArgError("workflowJobTemplateID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/survey_spec/", workflowJobTemplateBasePath, workflowJobTemplateID)
+
+	return setSurveySpec(ctx, s.client, path, spec)
+}
+
+// DeleteSurveySpec removes the survey of a WorkflowJobTemplate.
+func (s *WorkflowJobTemplateServiceOp) DeleteSurveySpec(ctx context.Context, workflowJobTemplateID int) (*Response, error) {
+	if workflowJobTemplateID < 1 {
+		return nil, NewArgError("workflowJobTemplateID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/survey_spec/", workflowJobTemplateBasePath, workflowJobTemplateID)
+
+	return deleteSurveySpec(ctx, s.client, path)
+}
+
+// ListLabels lists the Labels of a WorkflowJobTemplate.
+func (s *WorkflowJobTemplateServiceOp) ListLabels(ctx context.Context, workflowJobTemplateID int) ([]Label, *Response, error) {
+	if workflowJobTemplateID < 1 {
+		return nil, nil, NewArgError("workflowJobTemplateID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/labels/", workflowJobTemplateBasePath, workflowJobTemplateID)
+
+	return listLabels(ctx, s.client, path)
+}
+
+// AssociateLabel adds a Label to a WorkflowJobTemplate.
+func (s *WorkflowJobTemplateServiceOp) AssociateLabel(ctx context.Context, workflowJobTemplateID, labelID int) (*Response, error) {
+	if workflowJobTemplateID < 1 {
+		return nil, NewArgError("workflowJobTemplateID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/labels/", workflowJobTemplateBasePath, workflowJobTemplateID)
+
+	return associate(ctx, s.client, path, labelID)
+}
+
+// DisassociateLabel removes a Label from a WorkflowJobTemplate.
+func (s *WorkflowJobTemplateServiceOp) DisassociateLabel(ctx context.Context, workflowJobTemplateID, labelID int) (*Response, error) {
+	if workflowJobTemplateID < 1 {
+		return nil, NewArgError("workflowJobTemplateID", "cannot be less than 1")
+	}
+
+	path := fmt.Sprintf("%s%d/labels/", workflowJobTemplateBasePath, workflowJobTemplateID)
+
+	return disassociate(ctx, s.client, path, labelID)
+}